@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+// cassetteForTest returns the Cassette which the HTTP Interactions made during this Test are recorded
+// into (or replayed from), or nil when neither recording or replaying Cassettes has been enabled.
+func cassetteForTest(t *testing.T) *common.Cassette {
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		return nil
+	}
+
+	mode, err := common.CassetteModeFromEnvironment()
+	if err != nil {
+		t.Fatalf("%+v", err)
+		return nil
+	}
+	if mode == nil {
+		return nil
+	}
+
+	directory := os.Getenv(common.CassetteDirectoryEnvironmentVariable)
+	if directory == "" {
+		directory = filepath.Join("testdata", "cassettes")
+	}
+	path := filepath.Join(directory, strings.ReplaceAll(t.Name(), "/", "_")+".json")
+
+	var cassette *common.Cassette
+	if *mode == common.CassetteModeReplay {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			t.Skipf("Skipping since no Cassette has been recorded for this Test at %q", path)
+			return nil
+		}
+
+		cassette, err = common.LoadCassette(path)
+		if err != nil {
+			t.Fatalf("%+v", err)
+			return nil
+		}
+	} else {
+		cassette = common.NewCassette(t.Name(), path)
+		t.Cleanup(func() {
+			if err := cassette.Save(); err != nil {
+				t.Errorf("saving the Cassette: %+v", err)
+			}
+		})
+	}
+
+	// the Acceptance Test Client is shared between Tests, so routes its HTTP Interactions to the active Cassettes
+	cassette.Activate()
+	t.Cleanup(cassette.Deactivate)
+
+	return cassette
+}
+
+// cassetteVariable returns the value for the named variable from the Cassette for this Test, which when
+// recording is obtained from generate - or when the Cassette isn't being used, the value from generate.
+func (td *TestData) cassetteVariable(name string, generate func() string) (string, error) {
+	if td.cassette == nil {
+		return generate(), nil
+	}
+
+	return td.cassette.Variable(name, generate)
+}
+
+// replayingCassette returns whether the HTTP Interactions made during the Acceptance Tests are being replayed.
+func replayingCassette() bool {
+	mode, err := common.CassetteModeFromEnvironment()
+	return err == nil && mode != nil && *mode == common.CassetteModeReplay
+}
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
@@ -55,34 +56,69 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// cassette is the Cassette the HTTP Interactions made during this Test are recorded into (or replayed from)
+	cassette *common.Cassette
 }
 
 // BuildTestData generates some test data for the given resource
 func BuildTestData(t *testing.T, resourceType string, resourceLabel string) TestData {
 	testData := TestData{
-		RandomInteger:   RandTimeInt(),
-		RandomString:    randString(5),
 		ResourceName:    fmt.Sprintf("%s.%s", resourceType, resourceLabel),
 		EnvironmentName: EnvironmentName(),
 		MetadataURL:     os.Getenv("ARM_METADATA_HOSTNAME"),
 
 		ResourceType:  resourceType,
 		resourceLabel: resourceLabel,
+
+		cassette: cassetteForTest(t),
 	}
 
-	if features.UseDynamicTestLocations() {
-		testData.Locations = availableLocations()
-	} else {
-		testData.Locations = Regions{
-			Primary:   os.Getenv("ARM_TEST_LOCATION"),
-			Secondary: os.Getenv("ARM_TEST_LOCATION_ALT"),
-			Ternary:   os.Getenv("ARM_TEST_LOCATION_ALT2"),
+	// when replaying a Cassette the values used to record it are used, so that the Test Configurations match
+	variable := func(name string, generate func() string) string {
+		value, err := testData.cassetteVariable(name, generate)
+		if err != nil {
+			t.Fatalf("%+v", err)
 		}
+		return value
+	}
+
+	randomInteger, err := strconv.Atoi(variable("random_integer", func() string {
+		return strconv.Itoa(RandTimeInt())
+	}))
+	if err != nil {
+		t.Fatalf("parsing the random integer: %+v", err)
+	}
+	testData.RandomInteger = randomInteger
+	testData.RandomString = variable("random_string", func() string {
+		return randString(5)
+	})
+
+	var locations *Regions
+	testLocations := func() Regions {
+		if locations == nil {
+			if features.UseDynamicTestLocations() {
+				v := availableLocations()
+				locations = &v
+			} else {
+				locations = &Regions{
+					Primary:   os.Getenv("ARM_TEST_LOCATION"),
+					Secondary: os.Getenv("ARM_TEST_LOCATION_ALT"),
+					Ternary:   os.Getenv("ARM_TEST_LOCATION_ALT2"),
+				}
+			}
+		}
+		return *locations
+	}
+	testData.Locations = Regions{
+		Primary:   variable("location_primary", func() string { return testLocations().Primary }),
+		Secondary: variable("location_secondary", func() string { return testLocations().Secondary }),
+		Ternary:   variable("location_ternary", func() string { return testLocations().Ternary }),
 	}
 
 	testData.Subscriptions = Subscriptions{
-		Primary:   os.Getenv("ARM_SUBSCRIPTION_ID"),
-		Secondary: os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"),
+		Primary:   variable("subscription_primary", func() string { return os.Getenv("ARM_SUBSCRIPTION_ID") }),
+		Secondary: variable("subscription_secondary", func() string { return os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT") }),
 	}
 
 	return testData
@@ -123,7 +159,14 @@ func (td *TestData) RandomStringOfLength(len int) string {
 		panic("Invalid Test: RandomStringOfLength: length argument must be between 1 and 1024 characters")
 	}
 
-	return randString(len)
+	value, err := td.cassetteVariable(fmt.Sprintf("random_string_of_length_%d", len), func() string {
+		return randString(len)
+	})
+	if err != nil {
+		panic(fmt.Sprintf("Invalid Test: RandomStringOfLength: %+v", err))
+	}
+
+	return value
 }
 
 // randString generates a random alphanumeric string of the length specified
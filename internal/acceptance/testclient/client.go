@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
@@ -73,6 +74,16 @@ func Build() (*clients.Client, error) {
 			SubscriptionID:           os.Getenv("ARM_SUBSCRIPTION_ID"),
 		}
 
+		// the Test Client is shared between the Acceptance Tests, so when recording or replaying Cassettes
+		// the HTTP Interactions it makes are routed to the Cassette for the relevant Test
+		mode, err := common.CassetteModeFromEnvironment()
+		if err != nil {
+			return nil, fmt.Errorf("building test client: %+v", err)
+		}
+		if mode != nil {
+			clientBuilder.Cassette = common.ActiveCassettes()
+		}
+
 		client, err := clients.Build(ctx, clientBuilder)
 		if err != nil {
 			return nil, fmt.Errorf("building test client: %+v", err)
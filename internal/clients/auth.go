@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/claims"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients/graph"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
 
 type ResourceManagerAccount struct {
@@ -26,6 +27,9 @@ type ResourceManagerAccount struct {
 
 	AuthenticatedAsAServicePrincipal bool
 	SkipResourceProviderRegistration bool
+
+	// RegisteredResourceProviders are the Resource Providers automatically registered when the Provider is configured
+	RegisteredResourceProviders map[string]struct{}
 }
 
 func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, subscriptionId string, skipResourceProviderRegistration bool) (*ResourceManagerAccount, error) {
@@ -136,3 +140,44 @@ func NewResourceManagerAccount(ctx context.Context, config auth.Credentials, sub
 
 	return &account, nil
 }
+
+// resourceManagerAccountFromCassette returns the Account which was used to record the Cassette, since
+// no authentication takes place when replaying a Cassette.
+func resourceManagerAccountFromCassette(cassette common.CassetteRecorder, environment environments.Environment, skipResourceProviderRegistration bool) (*ResourceManagerAccount, error) {
+	account, err := cassette.Account()
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the Account from the Cassette: %+v", err)
+	}
+
+	return &ResourceManagerAccount{
+		Environment: environment,
+
+		ClientId:       account.ClientId,
+		ObjectId:       account.ObjectId,
+		SubscriptionId: account.SubscriptionId,
+		TenantId:       account.TenantId,
+
+		AuthenticatedAsAServicePrincipal: account.AuthenticatedAsAServicePrincipal,
+		SkipResourceProviderRegistration: skipResourceProviderRegistration,
+	}, nil
+}
+
+// resourceManagerAccountForStorageEmulator returns a placeholder Account when using a Storage Emulator, since
+// no authentication takes place - the Subscription ID is only used to build the Resource Manager IDs of the
+// Storage Account, so falls back to an empty GUID when not specified.
+func resourceManagerAccountForStorageEmulator(config auth.Credentials, subscriptionId string) *ResourceManagerAccount {
+	if subscriptionId == "" {
+		subscriptionId = "00000000-0000-0000-0000-000000000000"
+	}
+
+	return &ResourceManagerAccount{
+		Environment: config.Environment,
+
+		ClientId:       config.ClientID,
+		SubscriptionId: subscriptionId,
+		TenantId:       config.TenantID,
+
+		// there are no Resource Providers to register within the Storage Emulator
+		SkipResourceProviderRegistration: true,
+	}
+}
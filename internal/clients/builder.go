@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
@@ -28,11 +29,27 @@ type ClientBuilder struct {
 	SkipProviderRegistration    bool
 	StorageUseAzureAD           bool
 
+	// RegisterResourceProvidersOnDemand specifies whether the Resource Provider for each Resource being created
+	// should be registered on-demand (when it's not already registered)
+	RegisterResourceProvidersOnDemand bool
+
 	CustomCorrelationRequestID string
 	MetadataHost               string
 	PartnerID                  string
 	SubscriptionID             string
 	TerraformVersion           string
+
+	// MaxConcurrentRequests is the maximum number of concurrent requests made to each Resource Provider (0 means unlimited),
+	// which can be overridden for specific Resource Providers using ResourceProviderMaxConcurrentRequests
+	MaxConcurrentRequests                 int
+	ResourceProviderMaxConcurrentRequests map[string]int
+
+	// Cassette (when set) records or replays the HTTP Interactions made by the Clients, for use in the Acceptance Tests
+	Cassette common.CassetteRecorder
+
+	// StorageEmulator (when set) points the Storage Data Plane Clients at a local Storage Emulator (such as Azurite),
+	// in which case no authentication takes place and requests to any other API (e.g. Resource Manager) fail
+	StorageEmulator *common.StorageEmulator
 }
 
 const azureStackEnvironmentError = `
@@ -52,25 +69,41 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf(azureStackEnvironmentError)
 	}
 
+	replaying := builder.Cassette != nil && builder.Cassette.Mode() == common.CassetteModeReplay
+	emulatingStorage := builder.StorageEmulator != nil
+
+	// when replaying a Cassette no authentication takes place, since the HTTP Responses are served locally - and when
+	// using a Storage Emulator only the Storage Data Plane APIs (which authenticate using the Account Key) are available,
+	// as such requests to any other API return an error rather than being sent to Azure
+	newAuthorizer := func(api environments.Api) (auth.Authorizer, error) {
+		if replaying {
+			return common.CassetteAuthorizer(), nil
+		}
+		if emulatingStorage {
+			return common.StorageEmulatorAuthorizer(), nil
+		}
+		return auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, api)
+	}
+
 	var resourceManagerAuth, storageAuth, synapseAuth, batchManagementAuth, keyVaultAuth auth.Authorizer
 
-	resourceManagerAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ResourceManager)
+	resourceManagerAuth, err = newAuthorizer(builder.AuthConfig.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Resource Manager API: %+v", err)
 	}
 
-	storageAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Storage)
+	storageAuth, err = newAuthorizer(builder.AuthConfig.Environment.Storage)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Storage API: %+v", err)
 	}
 
-	keyVaultAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.KeyVault)
+	keyVaultAuth, err = newAuthorizer(builder.AuthConfig.Environment.KeyVault)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Key Vault API: %+v", err)
 	}
 
 	if builder.AuthConfig.Environment.Synapse.Available() {
-		synapseAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Synapse)
+		synapseAuth, err = newAuthorizer(builder.AuthConfig.Environment.Synapse)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Synapse API: %+v", err)
 		}
@@ -79,7 +112,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	if builder.AuthConfig.Environment.Batch.Available() {
-		batchManagementAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Batch)
+		batchManagementAuth, err = newAuthorizer(builder.AuthConfig.Environment.Batch)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Batch Management API: %+v", err)
 		}
@@ -89,7 +122,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 
 	// Helper for obtaining endpoint-specific tokens
 	authorizerFunc := common.ApiAuthorizerFunc(func(api environments.Api) (auth.Authorizer, error) {
-		authorizer, err := auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, api)
+		authorizer, err := newAuthorizer(api)
 		if err != nil {
 			return nil, fmt.Errorf("building custom authorizer for API %q: %+v", api.Name(), err)
 		}
@@ -97,14 +130,31 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return authorizer, nil
 	})
 
-	account, err := NewResourceManagerAccount(ctx, *builder.AuthConfig, builder.SubscriptionID, builder.SkipProviderRegistration)
+	var account *ResourceManagerAccount
+	if replaying {
+		account, err = resourceManagerAccountFromCassette(builder.Cassette, builder.AuthConfig.Environment, builder.SkipProviderRegistration)
+	} else if emulatingStorage {
+		account = resourceManagerAccountForStorageEmulator(*builder.AuthConfig, builder.SubscriptionID)
+	} else {
+		account, err = NewResourceManagerAccount(ctx, *builder.AuthConfig, builder.SubscriptionID, builder.SkipProviderRegistration)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("building account: %+v", err)
 	}
 
+	if builder.Cassette != nil && !replaying {
+		builder.Cassette.SetAccount(common.CassetteAccount{
+			ClientId:                         account.ClientId,
+			ObjectId:                         account.ObjectId,
+			SubscriptionId:                   account.SubscriptionId,
+			TenantId:                         account.TenantId,
+			AuthenticatedAsAServicePrincipal: account.AuthenticatedAsAServicePrincipal,
+		})
+	}
+
 	var managedHSMAuth auth.Authorizer
 	if builder.AuthConfig.Environment.ManagedHSM.Available() {
-		managedHSMAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ManagedHSM)
+		managedHSMAuth, err = newAuthorizer(builder.AuthConfig.Environment.ManagedHSM)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Managed HSM API: %+v", err)
 		}
@@ -117,10 +167,39 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf("unable to determine resource manager endpoint for the current environment")
 	}
 
+	// sensitive values are redacted from the HTTP Requests and Responses prior to them being logged
+	redactor, err := common.NewRedactorFromEnvironment()
+	if err != nil {
+		return nil, fmt.Errorf("building the log redactor: %+v", err)
+	}
+
+	// requests to Resource Manager are throttled cooperatively across all of the Clients, however there's
+	// no need to when replaying a Cassette
+	var rateLimiter *common.RateLimiter
+	if !replaying {
+		rateLimiter, err = common.NewRateLimiter(builder.MaxConcurrentRequests, builder.ResourceProviderMaxConcurrentRequests)
+		if err != nil {
+			return nil, fmt.Errorf("building the rate limiter: %+v", err)
+		}
+	}
+
 	client := Client{
 		Account: account,
 	}
 
+	// there's no need to register Resource Providers when replaying a Cassette or using a Storage Emulator
+	var registerResourceProvider common.ResourceProviderRegistrationFunc
+	if builder.RegisterResourceProvidersOnDemand && !replaying && !emulatingStorage {
+		registerResourceProvider = func(ctx context.Context, subscriptionId string, resourceProvider string) error {
+			// Resource Providers are only registered on-demand within the configured Subscription
+			if !strings.EqualFold(subscriptionId, account.SubscriptionId) || client.Resource == nil {
+				return nil
+			}
+
+			return resourceproviders.RegisterIfRequired(ctx, client.Resource.ResourceProvidersClient, commonids.NewSubscriptionID(account.SubscriptionId), resourceProvider)
+		}
+	}
+
 	o := &common.ClientOptions{
 		Authorizers: &common.Authorizers{
 			BatchManagement: batchManagementAuth,
@@ -154,13 +233,20 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		StorageUseAzureAD:           builder.StorageUseAzureAD,
 
 		ResourceManagerEndpoint: *resourceManagerEndpoint,
+		StorageEmulator:         builder.StorageEmulator,
+
+		RegisterResourceProvider: registerResourceProvider,
+		RateLimiter:              rateLimiter,
+		Redactor:                 redactor,
+		Cassette:                 builder.Cassette,
 	}
 
 	if err := client.Build(ctx, o); err != nil {
 		return nil, fmt.Errorf("building Client: %+v", err)
 	}
 
-	if features.EnhancedValidationEnabled() {
+	// the supported locations are retrieved outside of the Clients, so can't be replayed from a Cassette
+	if features.EnhancedValidationEnabled() && !replaying && !emulatingStorage {
 		subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
 
 		ctx2, cancel := context.WithTimeout(ctx, 10*time.Minute)
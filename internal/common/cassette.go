@@ -0,0 +1,437 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CassetteMode specifies whether the HTTP Interactions made by the Clients are recorded into a Cassette,
+// or replayed from a previously recorded Cassette.
+type CassetteMode string
+
+const (
+	CassetteModeRecord CassetteMode = "record"
+	CassetteModeReplay CassetteMode = "replay"
+)
+
+const (
+	// CassetteModeEnvironmentVariable is the Environment Variable used to enable recording or replaying
+	// the HTTP Interactions made during the Acceptance Tests.
+	CassetteModeEnvironmentVariable = "ARM_TEST_CASSETTE_MODE"
+
+	// CassetteDirectoryEnvironmentVariable is the Environment Variable used to override the directory
+	// the Cassettes are stored in, which defaults to `./testdata/cassettes` within the Test Package.
+	CassetteDirectoryEnvironmentVariable = "ARM_TEST_CASSETTE_DIR"
+)
+
+// CassetteModeFromEnvironment returns the CassetteMode specified in the Environment, or nil when
+// recording/replaying Cassettes hasn't been enabled.
+func CassetteModeFromEnvironment() (*CassetteMode, error) {
+	value := os.Getenv(CassetteModeEnvironmentVariable)
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, mode := range []CassetteMode{CassetteModeRecord, CassetteModeReplay} {
+		if strings.EqualFold(value, string(mode)) {
+			return &mode, nil
+		}
+	}
+
+	return nil, fmt.Errorf("`%s` must be either %q or %q but got %q", CassetteModeEnvironmentVariable, string(CassetteModeRecord), string(CassetteModeReplay), value)
+}
+
+// CassetteRecorder records and replays the HTTP Interactions made by a Client.
+type CassetteRecorder interface {
+	// Mode returns whether HTTP Interactions are being recorded or replayed.
+	Mode() CassetteMode
+
+	// Account returns the details of the Account which was used when the Cassette was recorded.
+	Account() (*CassetteAccount, error)
+
+	// SetAccount records the details of the Account being used to record the Cassette.
+	SetAccount(account CassetteAccount)
+
+	// Record records a (sanitised) HTTP Interaction, returning an error when it can't be recorded.
+	Record(interaction CassetteInteraction) error
+
+	// Replay returns the recorded HTTP Response for the specified HTTP Method and URI.
+	Replay(method string, uri *url.URL) (*CassetteResponse, error)
+}
+
+// CassetteAccount is the subset of the Account used when recording a Cassette which is required
+// to build the Clients when replaying it, since no authentication takes place.
+type CassetteAccount struct {
+	ClientId       string `json:"client_id"`
+	ObjectId       string `json:"object_id"`
+	SubscriptionId string `json:"subscription_id"`
+	TenantId       string `json:"tenant_id"`
+
+	AuthenticatedAsAServicePrincipal bool `json:"authenticated_as_a_service_principal"`
+}
+
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+type CassetteRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type CassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+var _ CassetteRecorder = &Cassette{}
+
+// Cassette is a file containing the HTTP Interactions made during a single Acceptance Test, alongside
+// the values (such as random integers and strings) the Test Configurations were generated from.
+type Cassette struct {
+	Name           string                `json:"name"`
+	AccountDetails *CassetteAccount      `json:"account,omitempty"`
+	Variables      map[string]string     `json:"variables,omitempty"`
+	Interactions   []CassetteInteraction `json:"interactions"`
+
+	mode CassetteMode
+	path string
+
+	lock           sync.Mutex
+	replayed       map[int]bool
+	variableCounts map[string]int
+}
+
+// NewCassette returns an empty Cassette which records HTTP Interactions into the file at path.
+func NewCassette(name, path string) *Cassette {
+	return &Cassette{
+		Name:         name,
+		Variables:    make(map[string]string),
+		Interactions: make([]CassetteInteraction, 0),
+
+		mode:           CassetteModeRecord,
+		path:           path,
+		replayed:       make(map[int]bool),
+		variableCounts: make(map[string]int),
+	}
+}
+
+// LoadCassette loads the previously recorded Cassette at path for replaying.
+func LoadCassette(path string) (*Cassette, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading Cassette %q: %+v", path, err)
+	}
+
+	cassette := Cassette{}
+	if err := json.Unmarshal(contents, &cassette); err != nil {
+		return nil, fmt.Errorf("unmarshaling Cassette %q: %+v", path, err)
+	}
+
+	if cassette.Variables == nil {
+		cassette.Variables = make(map[string]string)
+	}
+	cassette.mode = CassetteModeReplay
+	cassette.path = path
+	cassette.replayed = make(map[int]bool)
+	cassette.variableCounts = make(map[string]int)
+
+	return &cassette, nil
+}
+
+// Save writes the recorded Cassette to disk.
+func (c *Cassette) Save() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.mode != CassetteModeRecord {
+		return nil
+	}
+
+	contents, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling Cassette %q: %+v", c.Name, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("creating directory for Cassette %q: %+v", c.path, err)
+	}
+
+	if err := os.WriteFile(c.path, contents, 0o644); err != nil {
+		return fmt.Errorf("writing Cassette %q: %+v", c.path, err)
+	}
+
+	return nil
+}
+
+func (c *Cassette) Mode() CassetteMode {
+	return c.mode
+}
+
+func (c *Cassette) Account() (*CassetteAccount, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.AccountDetails == nil {
+		return nil, fmt.Errorf("the Cassette %q contains no Account details", c.Name)
+	}
+
+	account := *c.AccountDetails
+	return &account, nil
+}
+
+func (c *Cassette) SetAccount(account CassetteAccount) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.AccountDetails = &account
+}
+
+// Variable returns the value for the named variable - when recording this is obtained from generate
+// and stored in the Cassette, when replaying the recorded value is returned. Variables can be requested
+// multiple times, with each subsequent call for the same name being stored separately.
+func (c *Cassette) Variable(name string, generate func() string) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := name
+	if count := c.variableCounts[name]; count > 0 {
+		key = fmt.Sprintf("%s.%d", name, count)
+	}
+	c.variableCounts[name]++
+
+	if c.mode == CassetteModeReplay {
+		value, ok := c.Variables[key]
+		if !ok {
+			return "", fmt.Errorf("the variable %q wasn't recorded in the Cassette %q", key, c.Name)
+		}
+		return value, nil
+	}
+
+	value := generate()
+	c.Variables[key] = value
+	return value, nil
+}
+
+func (c *Cassette) Record(interaction CassetteInteraction) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.mode != CassetteModeRecord {
+		return nil
+	}
+
+	c.Interactions = append(c.Interactions, interaction)
+	return nil
+}
+
+// Replay returns the first HTTP Response which hasn't yet been replayed for a matching HTTP Request, falling
+// back to the last matching HTTP Response, since the number of requests made when polling can differ. Requests
+// are matched on the HTTP Method and URI, first exactly, and then with any UUIDs (for example, the names of
+// Role Assignments) wildcarded, since these are generated at runtime.
+func (c *Cassette) Replay(method string, uri *url.URL) (*CassetteResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, matcher := range []func(string) string{cassetteRequestKey, cassetteWildcardedRequestKey} {
+		expected := matcher(fmt.Sprintf("%s %s", method, uri.String()))
+		lastMatch := -1
+		for i, interaction := range c.Interactions {
+			if matcher(fmt.Sprintf("%s %s", interaction.Request.Method, interaction.Request.URL)) != expected {
+				continue
+			}
+
+			lastMatch = i
+			if !c.replayed[i] {
+				c.replayed[i] = true
+				response := interaction.Response
+				return &response, nil
+			}
+		}
+
+		if lastMatch != -1 {
+			response := c.Interactions[lastMatch].Response
+			return &response, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no recorded interaction was found in the Cassette %q for %s %s", c.Name, method, uri.String())
+}
+
+// hasInteractionsFor returns whether the Cassette contains any HTTP Interactions for the same host and path as uri.
+func (c *Cassette) hasInteractionsFor(uri *url.URL) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, interaction := range c.Interactions {
+		recorded, err := url.Parse(interaction.Request.URL)
+		if err != nil {
+			continue
+		}
+
+		if strings.EqualFold(recorded.Host, uri.Host) && strings.EqualFold(strings.TrimSuffix(recorded.Path, "/"), strings.TrimSuffix(uri.Path, "/")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cassetteRequestKey normalises a HTTP Method and URI (in the format `{method} {uri}`), since Azure Resource
+// Manager is case-insensitive and the order of the query string parameters isn't significant.
+func cassetteRequestKey(input string) string {
+	method, rawUri, ok := strings.Cut(input, " ")
+	if !ok {
+		return strings.ToLower(input)
+	}
+
+	uri, err := url.Parse(rawUri)
+	if err != nil {
+		return strings.ToLower(input)
+	}
+
+	// sensitive Query String Parameters are redacted when recording
+	uri = defaultRedactor.RedactURL(uri)
+	query := uri.Query()
+	keys := make([]string, 0)
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, 0)
+	for _, k := range keys {
+		for _, v := range query[k] {
+			params = append(params, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+
+	path := strings.TrimSuffix(uri.Path, "/")
+	return strings.ToLower(fmt.Sprintf("%s %s%s?%s", method, uri.Host, path, strings.Join(params, "&")))
+}
+
+var cassetteUuidRegex = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+func cassetteWildcardedRequestKey(input string) string {
+	return cassetteUuidRegex.ReplaceAllString(cassetteRequestKey(input), "{uuid}")
+}
+
+var _ CassetteRecorder = &cassetteRouter{}
+
+// cassetteRouter routes the HTTP Interactions made by a Client which is shared between Tests (such as the
+// Acceptance Test Client) to the Cassette for the Test, based on the Resources each Cassette has seen.
+type cassetteRouter struct {
+	lock      sync.Mutex
+	cassettes []*Cassette
+}
+
+var activeCassettes = &cassetteRouter{}
+
+// ActiveCassettes returns a CassetteRecorder which routes HTTP Interactions to the active Cassettes.
+func ActiveCassettes() CassetteRecorder {
+	return activeCassettes
+}
+
+// Activate makes the Cassette available to Clients using ActiveCassettes.
+func (c *Cassette) Activate() {
+	activeCassettes.lock.Lock()
+	defer activeCassettes.lock.Unlock()
+
+	activeCassettes.cassettes = append(activeCassettes.cassettes, c)
+}
+
+// Deactivate removes the Cassette from the Cassettes available to Clients using ActiveCassettes, and from the
+// local replay server.
+func (c *Cassette) Deactivate() {
+	cassetteReplayServer.unregister(c)
+
+	activeCassettes.lock.Lock()
+	defer activeCassettes.lock.Unlock()
+
+	cassettes := make([]*Cassette, 0)
+	for _, v := range activeCassettes.cassettes {
+		if v != c {
+			cassettes = append(cassettes, v)
+		}
+	}
+	activeCassettes.cassettes = cassettes
+}
+
+func (r *cassetteRouter) active() []*Cassette {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return append([]*Cassette{}, r.cassettes...)
+}
+
+func (r *cassetteRouter) Mode() CassetteMode {
+	if cassettes := r.active(); len(cassettes) > 0 {
+		return cassettes[0].Mode()
+	}
+
+	if mode, err := CassetteModeFromEnvironment(); err == nil && mode != nil {
+		return *mode
+	}
+
+	return CassetteModeRecord
+}
+
+func (r *cassetteRouter) Account() (*CassetteAccount, error) {
+	for _, cassette := range r.active() {
+		if account, err := cassette.Account(); err == nil {
+			return account, nil
+		}
+	}
+
+	return nil, fmt.Errorf("none of the active Cassettes contain Account details")
+}
+
+func (r *cassetteRouter) SetAccount(_ CassetteAccount) {
+	// the Account is recorded by the Cassette for each Test
+}
+
+// Record records the HTTP Interaction into the only active Cassette, or when multiple Tests are running in parallel
+// the Cassette which has seen the same Resource - an error is returned when this can't be determined, rather than
+// the HTTP Interaction being omitted from the Cassette (which would then fail when replaying).
+func (r *cassetteRouter) Record(interaction CassetteInteraction) error {
+	cassettes := r.active()
+	if len(cassettes) == 1 {
+		return cassettes[0].Record(interaction)
+	}
+
+	if uri, err := url.Parse(interaction.Request.URL); err == nil {
+		for _, cassette := range cassettes {
+			if cassette.hasInteractionsFor(uri) {
+				return cassette.Record(interaction)
+			}
+		}
+	}
+
+	return fmt.Errorf("unable to determine which of the %d active Cassette(s) to record %s %s into", len(cassettes), interaction.Request.Method, interaction.Request.URL)
+}
+
+func (r *cassetteRouter) Replay(method string, uri *url.URL) (*CassetteResponse, error) {
+	cassettes := r.active()
+	for _, cassette := range cassettes {
+		if response, err := cassette.Replay(method, uri); err == nil {
+			return response, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no recorded interaction was found in the %d active Cassette(s) for %s %s", len(cassettes), method, uri.String())
+}
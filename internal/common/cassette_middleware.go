@@ -0,0 +1,322 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"golang.org/x/oauth2"
+)
+
+const (
+	headerCassetteId          = "X-Terraform-Cassette-Id"
+	headerCassetteOriginalUrl = "X-Terraform-Cassette-Original-Url"
+)
+
+type cassetteRequestBodyKey struct{}
+
+type cassetteOriginalUrlKey struct{}
+
+// cassetteRequestMiddleware captures the HTTP Request body when recording, and when replaying redirects
+// the HTTP Request to the local replay server, since the go-azure-sdk base layer doesn't allow the
+// HTTP Transport to be overridden.
+func cassetteRequestMiddleware(recorder CassetteRecorder) client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		body, err := readRequestBody(request)
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %+v", err)
+		}
+
+		ctx := context.WithValue(request.Context(), cassetteRequestBodyKey{}, body)
+		if recorder.Mode() != CassetteModeReplay {
+			return request.WithContext(ctx), nil
+		}
+
+		id, endpoint, err := cassetteReplayServer.register(recorder)
+		if err != nil {
+			return nil, fmt.Errorf("starting the Cassette replay server: %+v", err)
+		}
+
+		originalUrl := *request.URL
+		ctx = context.WithValue(ctx, cassetteOriginalUrlKey{}, &originalUrl)
+		request = request.WithContext(ctx)
+
+		replayUrl := originalUrl
+		replayUrl.Scheme = endpoint.Scheme
+		replayUrl.Host = endpoint.Host
+		request.URL = &replayUrl
+		request.Host = endpoint.Host
+		request.Header.Set(headerCassetteId, id)
+		request.Header.Set(headerCassetteOriginalUrl, originalUrl.String())
+
+		return request, nil
+	}
+}
+
+// cassetteResponseMiddleware records the HTTP Interaction when recording, and when replaying restores the
+// original URL of the HTTP Request, since this is used by the pollers for Long Running Operations.
+func cassetteResponseMiddleware(recorder CassetteRecorder) client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		if recorder.Mode() == CassetteModeReplay {
+			if originalUrl, ok := request.Context().Value(cassetteOriginalUrlKey{}).(*url.URL); ok {
+				request.URL = originalUrl
+				request.Host = originalUrl.Host
+				request.Header.Del(headerCassetteId)
+				request.Header.Del(headerCassetteOriginalUrl)
+				if response.Request != nil {
+					response.Request.URL = originalUrl
+					response.Request.Host = originalUrl.Host
+				}
+			}
+			return response, nil
+		}
+
+		body, _ := request.Context().Value(cassetteRequestBodyKey{}).([]byte)
+		if err := recordCassetteInteraction(recorder, request, body, response); err != nil {
+			return nil, err
+		}
+
+		return response, nil
+	}
+}
+
+// cassetteSender wraps the Sender for go-autorest Clients, to record or replay HTTP Interactions.
+func cassetteSender(recorder CassetteRecorder, sender autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(request *http.Request) (*http.Response, error) {
+		body, err := readRequestBody(request)
+		if err != nil {
+			return nil, fmt.Errorf("reading request body: %+v", err)
+		}
+
+		if recorder.Mode() == CassetteModeReplay {
+			replayed, err := recorder.Replay(request.Method, request.URL)
+			if err != nil {
+				return nil, err
+			}
+			return replayedCassetteResponse(request, replayed), nil
+		}
+
+		response, err := sender.Do(request)
+		if err != nil {
+			return response, err
+		}
+
+		if err := recordCassetteInteraction(recorder, request, body, response); err != nil {
+			return nil, err
+		}
+
+		return response, nil
+	})
+}
+
+func recordCassetteInteraction(recorder CassetteRecorder, request *http.Request, requestBody []byte, response *http.Response) error {
+	responseBody, err := readResponseBody(response)
+	if err != nil {
+		return fmt.Errorf("reading response body: %+v", err)
+	}
+
+	// the Query String is sanitised using the same rules when replaying, so that the HTTP Requests match
+	err = recorder.Record(CassetteInteraction{
+		Request: CassetteRequest{
+			Method:  request.Method,
+			URL:     defaultRedactor.RedactURL(request.URL).String(),
+			Headers: sanitiseCassetteHeaders(request.URL, request.Header),
+			Body:    sanitiseCassetteBody(request.URL, request.Header.Get("Content-Type"), requestBody),
+		},
+		Response: CassetteResponse{
+			StatusCode: response.StatusCode,
+			Headers:    sanitiseCassetteHeaders(request.URL, response.Header),
+			Body:       sanitiseCassetteBody(request.URL, response.Header.Get("Content-Type"), responseBody),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("recording %s %s: %+v", request.Method, request.URL.String(), err)
+	}
+
+	return nil
+}
+
+func replayedCassetteResponse(request *http.Request, replayed *CassetteResponse) *http.Response {
+	headers := replayedCassetteHeaders(replayed.Headers)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", replayed.StatusCode, http.StatusText(replayed.StatusCode)),
+		StatusCode:    replayed.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        headers,
+		Body:          io.NopCloser(strings.NewReader(replayed.Body)),
+		ContentLength: int64(len(replayed.Body)),
+		Request:       request,
+	}
+}
+
+// replayedCassetteHeaders returns a copy of the recorded HTTP Headers, with any `Retry-After` header
+// zeroed, since there's no need to wait between polling requests when replaying.
+func replayedCassetteHeaders(input http.Header) http.Header {
+	output := input.Clone()
+	if output == nil {
+		output = http.Header{}
+	}
+	if output.Get("Retry-After") != "" {
+		output.Set("Retry-After", "0")
+	}
+	output.Del("Content-Length")
+	return output
+}
+
+// cassetteHeaders are the HTTP Headers used to route HTTP Requests to the local replay server
+var cassetteHeaders = []string{
+	headerCassetteId,
+	headerCassetteOriginalUrl,
+}
+
+// sanitiseCassetteHeaders removes the sensitive HTTP Headers (using the built-in Redaction Rules) prior to recording
+func sanitiseCassetteHeaders(uri *url.URL, input http.Header) http.Header {
+	output := input.Clone()
+	for _, header := range defaultRedactor.sensitiveHeaders(uri) {
+		output.Del(header)
+	}
+	for _, header := range cassetteHeaders {
+		output.Del(header)
+	}
+	return output
+}
+
+// sanitiseCassetteBody redacts the sensitive values (using the built-in Redaction Rules) prior to recording - values
+// which are Base64 encoded are replaced with a Base64 encoded value of the same length, since these can be decoded
+// by the Provider when replaying.
+func sanitiseCassetteBody(uri *url.URL, contentType string, input []byte) string {
+	return string(defaultRedactor.redactBody(uri, contentType, input, redactedCassetteValue))
+}
+
+func redactedCassetteValue(input string) string {
+	if decoded, err := base64.StdEncoding.DecodeString(input); err == nil && len(decoded) > 0 {
+		return base64.StdEncoding.EncodeToString(make([]byte, len(decoded)))
+	}
+
+	return "REDACTED"
+}
+
+// cassetteReplayServer serves the recorded HTTP Responses for each CassetteRecorder being replayed.
+var cassetteReplayServer = &replayServer{
+	ids: make(map[CassetteRecorder]string),
+}
+
+type replayServer struct {
+	lock      sync.Mutex
+	endpoint  *url.URL
+	ids       map[CassetteRecorder]string
+	nextId    int
+	recorders sync.Map
+}
+
+func (s *replayServer) register(recorder CassetteRecorder) (string, *url.URL, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.endpoint == nil {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return "", nil, fmt.Errorf("listening on a local port: %+v", err)
+		}
+
+		go func() {
+			if err := http.Serve(listener, http.HandlerFunc(s.serveHTTP)); err != nil {
+				log.Printf("[DEBUG] The Cassette replay server stopped: %+v", err)
+			}
+		}()
+
+		s.endpoint = &url.URL{
+			Scheme: "http",
+			Host:   listener.Addr().String(),
+		}
+	}
+
+	id, ok := s.ids[recorder]
+	if !ok {
+		// IDs aren't reused, since a HTTP Request for an unregistered CassetteRecorder could still be in-flight
+		id = strconv.Itoa(s.nextId)
+		s.nextId++
+		s.ids[recorder] = id
+		s.recorders.Store(id, recorder)
+	}
+
+	return id, s.endpoint, nil
+}
+
+// unregister removes the CassetteRecorder once it's no longer being replayed (when the Test completes), so
+// that it's not retained for the lifetime of the Test Binary.
+func (s *replayServer) unregister(recorder CassetteRecorder) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if id, ok := s.ids[recorder]; ok {
+		delete(s.ids, recorder)
+		s.recorders.Delete(id)
+	}
+}
+
+func (s *replayServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	v, ok := s.recorders.Load(r.Header.Get(headerCassetteId))
+	if !ok {
+		http.Error(w, "unknown Cassette", http.StatusNotImplemented)
+		return
+	}
+	recorder := v.(CassetteRecorder)
+
+	originalUrl, err := url.Parse(r.Header.Get(headerCassetteOriginalUrl))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("parsing the original URL: %+v", err), http.StatusNotImplemented)
+		return
+	}
+
+	replayed, err := recorder.Replay(r.Method, originalUrl)
+	if err != nil {
+		// a 501 is returned since this isn't retried
+		log.Printf("[DEBUG] %+v", err)
+		http.Error(w, err.Error(), http.StatusNotImplemented)
+		return
+	}
+
+	for k, v := range replayedCassetteHeaders(replayed.Headers) {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(replayed.StatusCode)
+	_, _ = w.Write([]byte(replayed.Body))
+}
+
+var _ auth.Authorizer = cassetteAuthorizer{}
+
+// cassetteAuthorizer is an auth.Authorizer used when replaying a Cassette, where no authentication takes place.
+type cassetteAuthorizer struct{}
+
+// CassetteAuthorizer returns an auth.Authorizer for use when replaying a Cassette.
+func CassetteAuthorizer() auth.Authorizer {
+	return cassetteAuthorizer{}
+}
+
+func (cassetteAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: "replayed",
+		TokenType:   "Bearer",
+	}, nil
+}
+
+func (cassetteAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
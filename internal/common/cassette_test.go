@@ -0,0 +1,250 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+const cassetteTestPath = "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example"
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != cassetteTestPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "30")
+		_, _ = w.Write([]byte(`{"name":"example","properties":{"primaryKey":"c2VjcmV0","location":"westeurope"}}`))
+	}))
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recording := NewCassette(t.Name(), path)
+	recording.SetAccount(CassetteAccount{
+		SubscriptionId: "11111111-1111-1111-1111-111111111111",
+	})
+
+	recorded, err := executeCassetteTestRequest(server.URL, cassetteTestPath, recording)
+	if err != nil {
+		t.Fatalf("recording: %+v", err)
+	}
+	if !strings.Contains(recorded, "c2VjcmV0") {
+		t.Fatalf("expected the unsanitised body to be returned when recording but got %q", recorded)
+	}
+	if len(recording.Interactions) != 1 {
+		t.Fatalf("expected 1 interaction to be recorded but got %d", len(recording.Interactions))
+	}
+	if v := recording.Interactions[0].Request.Headers.Get("Authorization"); v != "" {
+		t.Fatalf("expected the Authorization header to be removed but got %q", v)
+	}
+	if err := recording.Save(); err != nil {
+		t.Fatalf("saving: %+v", err)
+	}
+
+	// the server is closed to confirm no requests are made when replaying
+	server.Close()
+
+	replaying, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("loading: %+v", err)
+	}
+	account, err := replaying.Account()
+	if err != nil {
+		t.Fatalf("retrieving the account: %+v", err)
+	}
+	if account.SubscriptionId != "11111111-1111-1111-1111-111111111111" {
+		t.Fatalf("expected the recorded Subscription ID but got %q", account.SubscriptionId)
+	}
+
+	replayed, err := executeCassetteTestRequest(server.URL, cassetteTestPath, replaying)
+	if err != nil {
+		t.Fatalf("replaying: %+v", err)
+	}
+	expected := `{"name":"example","properties":{"location":"westeurope","primaryKey":"AAAAAAAA"}}`
+	if replayed != expected {
+		t.Fatalf("expected the replayed body to be %q but got %q", expected, replayed)
+	}
+
+	if _, err := executeCassetteTestRequest(server.URL, "/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/other", replaying); err == nil {
+		t.Fatalf("expected an error when replaying a request which wasn't recorded")
+	}
+}
+
+func TestCassetteReplayMatching(t *testing.T) {
+	cassette := Cassette{
+		Name: "example",
+		Interactions: []CassetteInteraction{
+			{
+				Request:  CassetteRequest{Method: "GET", URL: "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example?b=2&api-version=2020-01-01"},
+				Response: CassetteResponse{StatusCode: http.StatusAccepted},
+			},
+			{
+				Request:  CassetteRequest{Method: "GET", URL: "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example?b=2&api-version=2020-01-01"},
+				Response: CassetteResponse{StatusCode: http.StatusOK},
+			},
+			{
+				Request:  CassetteRequest{Method: "PUT", URL: "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/providers/Microsoft.Authorization/roleAssignments/22222222-2222-2222-2222-222222222222?api-version=2022-04-01"},
+				Response: CassetteResponse{StatusCode: http.StatusCreated},
+			},
+		},
+		mode:     CassetteModeReplay,
+		replayed: map[int]bool{},
+	}
+
+	testData := []struct {
+		Method   string
+		Url      string
+		Expected int
+		Error    bool
+	}{
+		{
+			// the first matching interaction, with a differently cased path and different order of parameters
+			Method:   "GET",
+			Url:      "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourcegroups/EXAMPLE?api-version=2020-01-01&b=2",
+			Expected: http.StatusAccepted,
+		},
+		{
+			Method:   "GET",
+			Url:      "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example?api-version=2020-01-01&b=2",
+			Expected: http.StatusOK,
+		},
+		{
+			// the last matching interaction is re-used once all have been replayed
+			Method:   "GET",
+			Url:      "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example?api-version=2020-01-01&b=2",
+			Expected: http.StatusOK,
+		},
+		{
+			// UUIDs generated at runtime are wildcarded
+			Method:   "PUT",
+			Url:      "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/providers/Microsoft.Authorization/roleAssignments/33333333-3333-3333-3333-333333333333?api-version=2022-04-01",
+			Expected: http.StatusCreated,
+		},
+		{
+			Method: "DELETE",
+			Url:    "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example?api-version=2020-01-01&b=2",
+			Error:  true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %s %q", v.Method, v.Url)
+
+		uri, err := url.Parse(v.Url)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", v.Url, err)
+		}
+
+		actual, err := cassette.Replay(v.Method, uri)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but got a %d", actual.StatusCode)
+		}
+
+		if actual.StatusCode != v.Expected {
+			t.Fatalf("expected a %d but got a %d", v.Expected, actual.StatusCode)
+		}
+	}
+}
+
+func executeCassetteTestRequest(baseUri, path string, recorder CassetteRecorder) (string, error) {
+	c := client.NewClient(baseUri, "example", "2020-01-01")
+	c.DisableRetries = true
+	ClientOptions{Cassette: recorder}.Configure(c, CassetteAuthorizer())
+
+	ctx := context.TODO()
+	req, err := c.NewRequest(ctx, client.RequestOptions{
+		ContentType:         "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{http.StatusOK},
+		HttpMethod:          http.MethodGet,
+		Path:                path,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.Execute(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+func TestCassetteRouterRecord(t *testing.T) {
+	first := NewCassette("first", filepath.Join(t.TempDir(), "first.json"))
+	_ = first.Record(CassetteInteraction{
+		Request: CassetteRequest{Method: "GET", URL: "https://first.vault.azure.net/secrets/example"},
+	})
+	second := NewCassette("second", filepath.Join(t.TempDir(), "second.json"))
+	_ = second.Record(CassetteInteraction{
+		Request: CassetteRequest{Method: "GET", URL: "https://second.vault.azure.net/secrets/example"},
+	})
+
+	first.Activate()
+	t.Cleanup(first.Deactivate)
+	second.Activate()
+	t.Cleanup(second.Deactivate)
+
+	if err := ActiveCassettes().Record(CassetteInteraction{
+		Request: CassetteRequest{Method: "DELETE", URL: "https://second.vault.azure.net/secrets/example"},
+	}); err != nil {
+		t.Fatalf("expected no error but got: %+v", err)
+	}
+	if len(first.Interactions) != 1 || len(second.Interactions) != 2 {
+		t.Fatalf("expected the interaction to be recorded into the second Cassette but got %d and %d interactions", len(first.Interactions), len(second.Interactions))
+	}
+
+	if err := ActiveCassettes().Record(CassetteInteraction{
+		Request: CassetteRequest{Method: "GET", URL: "https://third.vault.azure.net/secrets/example"},
+	}); err == nil {
+		t.Fatalf("expected an error when the Cassette to record into can't be determined")
+	}
+}
+
+func TestCassetteReplayServerUnregister(t *testing.T) {
+	first := NewCassette("first", filepath.Join(t.TempDir(), "first.json"))
+	firstId, _, err := cassetteReplayServer.register(first)
+	if err != nil {
+		t.Fatalf("registering: %+v", err)
+	}
+
+	first.Deactivate()
+	if _, ok := cassetteReplayServer.recorders.Load(firstId); ok {
+		t.Fatalf("expected the Cassette to be unregistered when deactivated")
+	}
+
+	second := NewCassette("second", filepath.Join(t.TempDir(), "second.json"))
+	t.Cleanup(second.Deactivate)
+	secondId, _, err := cassetteReplayServer.register(second)
+	if err != nil {
+		t.Fatalf("registering: %+v", err)
+	}
+	if secondId == firstId {
+		t.Fatalf("expected the ID %q not to be reused", firstId)
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
-	"github.com/hashicorp/go-azure-helpers/sender"
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
@@ -52,6 +51,23 @@ type ClientOptions struct {
 
 	ResourceManagerEndpoint string
 
+	// StorageEmulator (when set) configures the Storage Data Plane Clients to use a local Storage Emulator
+	StorageEmulator *StorageEmulator
+
+	// RegisterResourceProvider (when set) is used to register the Resource Provider for each Resource being
+	// created or updated on-demand, should it not already be registered
+	RegisterResourceProvider ResourceProviderRegistrationFunc
+
+	// RateLimiter (when set) throttles the requests made to Resource Manager across all of the Clients
+	RateLimiter *RateLimiter
+
+	// Redactor redacts sensitive values from the HTTP Requests and Responses prior to them being logged,
+	// when unset the built-in Redaction Rules are used
+	Redactor *Redactor
+
+	// Cassette (when set) records or replays the HTTP Interactions made by the Clients, for use in the Acceptance Tests
+	Cassette CassetteRecorder
+
 	// Legacy authorizers for go-autorest
 	BatchManagementAuthorizer autorest.Authorizer
 	KeyVaultAuthorizer        autorest.Authorizer
@@ -73,8 +89,31 @@ func (o ClientOptions) Configure(c client.BaseClient, authorizer auth.Authorizer
 		c.AppendRequestMiddleware(correlationRequestIDMiddleware(id))
 	}
 
-	c.AppendRequestMiddleware(requestLoggerMiddleware("AzureRM"))
-	c.AppendResponseMiddleware(responseLoggerMiddleware("AzureRM"))
+	if o.RegisterResourceProvider != nil {
+		c.AppendRequestMiddleware(resourceProviderRegistrationMiddleware(o.RegisterResourceProvider))
+	}
+
+	if o.RateLimiter != nil {
+		c.AppendRequestMiddleware(rateLimiterRequestMiddleware(o.RateLimiter))
+	}
+
+	c.AppendRequestMiddleware(tracingRequestMiddleware(o.redactor()))
+	c.AppendRequestMiddleware(requestLoggerMiddleware("AzureRM", o.redactor()))
+
+	// the Cassette middleware must be the last Request Middleware and the first Response Middleware, since
+	// when replaying the HTTP Request is redirected to the local replay server
+	if o.Cassette != nil {
+		c.AppendRequestMiddleware(cassetteRequestMiddleware(o.Cassette))
+		c.AppendResponseMiddleware(cassetteResponseMiddleware(o.Cassette))
+	}
+
+	c.AppendResponseMiddleware(tracingResponseMiddleware())
+
+	if o.RateLimiter != nil {
+		c.AppendResponseMiddleware(rateLimiterResponseMiddleware(o.RateLimiter))
+	}
+
+	c.AppendResponseMiddleware(responseLoggerMiddleware("AzureRM", o.redactor()))
 }
 
 // ConfigureClient sets up an autorest.Client using an autorest.Authorizer
@@ -82,7 +121,10 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 	c.UserAgent = userAgent(c.UserAgent, o.TerraformVersion, o.PartnerId, o.DisableTerraformPartnerID)
 
 	c.Authorizer = authorizer
-	c.Sender = sender.BuildSender("AzureRM")
+	c.Sender = tracingSender(o.redactor(), buildSender("AzureRM", o.redactor(), o.RateLimiter))
+	if o.Cassette != nil {
+		c.Sender = cassetteSender(o.Cassette, c.Sender)
+	}
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
 	if !o.DisableCorrelationRequestID {
 		id := o.CustomCorrelationRequestID
@@ -93,6 +135,14 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 	}
 }
 
+func (o ClientOptions) redactor() *Redactor {
+	if o.Redactor != nil {
+		return o.Redactor
+	}
+
+	return defaultRedactor
+}
+
 func userAgent(userAgent, tfVersion, partnerID string, disableTerraformPartnerID bool) string {
 	tfUserAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s", tfVersion, meta.SDKVersionString())
 
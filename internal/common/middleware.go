@@ -4,10 +4,13 @@
 package common
 
 import (
+	"bytes"
+	"io"
 	"log"
 	"net/http"
 	"net/http/httputil"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
 )
 
@@ -19,41 +22,134 @@ func correlationRequestIDMiddleware(id string) client.RequestMiddleware {
 	}
 }
 
-func requestLoggerMiddleware(providerName string) client.RequestMiddleware {
+func requestLoggerMiddleware(providerName string, redactor *Redactor) client.RequestMiddleware {
 	return func(request *http.Request) (*http.Request, error) {
-		// strip the authorization header prior to printing
-		authHeaderName := "Authorization"
-		auth := request.Header.Get(authHeaderName)
-		if auth != "" {
-			request.Header.Del(authHeaderName)
-		}
+		logRequest(providerName, redactor, request)
+		return request, nil
+	}
+}
 
-		// dump request to wire format
-		if dump, err := httputil.DumpRequestOut(request, true); err == nil {
-			log.Printf("[DEBUG] %s Request: \n%s\n", providerName, dump)
-		} else {
-			// fallback to basic message
-			log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, request.Method, request.URL)
-		}
+func responseLoggerMiddleware(providerName string, redactor *Redactor) client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		logResponse(providerName, redactor, request, response)
+		return response, nil
+	}
+}
 
-		// add the auth header back
-		if auth != "" {
-			request.Header.Add(authHeaderName, auth)
-		}
+// buildSender returns a Sender for go-autorest Clients which logs the (redacted) HTTP Requests and Responses,
+// and which waits for the RateLimiter (when set) prior to sending each request.
+func buildSender(providerName string, redactor *Redactor, limiter *RateLimiter) autorest.Sender {
+	return autorest.DecorateSender(&http.Client{
+		Transport: rateLimitedTransportFor(limiter, &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+		}),
+	}, func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(request *http.Request) (*http.Response, error) {
+			logRequest(providerName, redactor, request)
 
-		return request, nil
+			response, err := s.Do(request)
+			if response != nil {
+				logResponse(providerName, redactor, request, response)
+			} else if err != nil {
+				log.Printf("[DEBUG] %s Response Error: %s for %s\n", providerName, err, redactor.RedactURL(request.URL))
+			} else {
+				log.Printf("[DEBUG] Request to %s completed with no response", redactor.RedactURL(request.URL))
+			}
+			return response, err
+		})
+	})
+}
+
+// logRequest logs a copy of the HTTP Request with any sensitive values redacted
+func logRequest(providerName string, redactor *Redactor, request *http.Request) {
+	body, err := readRequestBody(request)
+	if err != nil {
+		log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, request.Method, redactor.RedactURL(request.URL))
+		return
+	}
+
+	redacted := request.Clone(request.Context())
+	redacted.URL = redactor.RedactURL(request.URL)
+	redacted.Header = redactor.RedactHeaders(request.URL, request.Header)
+	setRequestBody(redacted, redactor.TruncateBody(redactor.RedactBody(request.URL, request.Header.Get("Content-Type"), body)))
+
+	// dump request to wire format
+	if dump, err := httputil.DumpRequestOut(redacted, true); err == nil {
+		log.Printf("[DEBUG] %s Request: \n%s\n", providerName, dump)
+	} else {
+		// fallback to basic message
+		log.Printf("[DEBUG] %s Request: %s to %s\n", providerName, redacted.Method, redacted.URL)
 	}
 }
 
-func responseLoggerMiddleware(providerName string) client.ResponseMiddleware {
-	return func(request *http.Request, response *http.Response) (*http.Response, error) {
-		// dump response to wire format
-		if dump, err2 := httputil.DumpResponse(response, true); err2 == nil {
-			log.Printf("[DEBUG] %s Response for %s: \n%s\n", providerName, request.URL, dump)
-		} else {
-			// fallback to basic message
-			log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, request.URL)
+// logResponse logs a copy of the HTTP Response with any sensitive values redacted
+func logResponse(providerName string, redactor *Redactor, request *http.Request, response *http.Response) {
+	body, err := readResponseBody(response)
+	if err != nil {
+		log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, redactor.RedactURL(request.URL))
+		return
+	}
+
+	redactedBody := redactor.TruncateBody(redactor.RedactBody(request.URL, response.Header.Get("Content-Type"), body))
+	redacted := *response
+	redacted.Header = redactor.RedactHeaders(request.URL, response.Header)
+	redacted.Body = io.NopCloser(bytes.NewReader(redactedBody))
+	redacted.ContentLength = int64(len(redactedBody))
+	redacted.TransferEncoding = nil
+
+	// dump response to wire format
+	if dump, err := httputil.DumpResponse(&redacted, true); err == nil {
+		log.Printf("[DEBUG] %s Response for %s: \n%s\n", providerName, redactor.RedactURL(request.URL), dump)
+	} else {
+		// fallback to basic message
+		log.Printf("[DEBUG] %s Response: %s for %s\n", providerName, response.Status, redactor.RedactURL(request.URL))
+	}
+}
+
+// readRequestBody reads the body of the HTTP Request, replacing it so that it can be read again.
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	request.Body.Close()
+	setRequestBody(request, body)
+
+	return body, nil
+}
+
+func setRequestBody(request *http.Request, body []byte) {
+	request.ContentLength = int64(len(body))
+	if len(body) == 0 {
+		request.Body = http.NoBody
+		request.GetBody = func() (io.ReadCloser, error) {
+			return http.NoBody, nil
 		}
-		return response, nil
+		return
+	}
+
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
 	}
 }
+
+// readResponseBody reads the body of the HTTP Response, replacing it so that it can be read again.
+func readResponseBody(response *http.Response) ([]byte, error) {
+	if response.Body == nil || response.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
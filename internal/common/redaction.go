@@ -0,0 +1,465 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LogRedactionRulesEnvironmentVariable is the Environment Variable used to specify the path to a JSON file
+	// containing additional RedactionRules, which are applied alongside the built-in RedactionRules.
+	LogRedactionRulesEnvironmentVariable = "ARM_LOG_REDACTION_RULES"
+
+	// LogMaxBodySizeEnvironmentVariable is the Environment Variable used to override the maximum size (in bytes)
+	// of the HTTP Request and Response bodies which are logged, where `0` disables the limit.
+	LogMaxBodySizeEnvironmentVariable = "ARM_LOG_MAX_BODY_SIZE"
+
+	// defaultLogMaxBodySize is the maximum size (in bytes) of the HTTP Request and Response bodies which are logged
+	defaultLogMaxBodySize = 64 * 1024
+
+	redactedValue = "REDACTED"
+)
+
+// RedactionRule specifies the sensitive values which should be redacted from HTTP Requests and Responses.
+type RedactionRule struct {
+	// PathPattern is an optional (case-insensitive) regular expression matched against the path of the
+	// HTTP Request - when specified this Rule is only applied to matching HTTP Requests.
+	PathPattern string `json:"path_pattern,omitempty"`
+
+	// Headers are the names of the HTTP Headers whose values should be redacted.
+	Headers []string `json:"headers,omitempty"`
+
+	// QueryParameters are the names of the Query String Parameters (and fields within form-encoded bodies)
+	// whose values should be redacted.
+	QueryParameters []string `json:"query_parameters,omitempty"`
+
+	// JsonPaths are the JSONPath expressions for the values within JSON bodies which should be redacted - these
+	// support child (`$.properties.password`), wildcard (`$.keys[*].value`) and recursive descent (`$..password`)
+	// segments, where matching an object or array redacts all of the values within it.
+	JsonPaths []string `json:"json_paths,omitempty"`
+}
+
+// builtInRedactionRules are the RedactionRules which are always applied, covering the well-known sensitive
+// HTTP Headers, Query String Parameters and properties, alongside the Resource Manager operations whose
+// responses contain secrets (such as `listKeys`) and the Key Vault and token endpoints.
+var builtInRedactionRules = []RedactionRule{
+	{
+		Headers: []string{
+			"Authorization",
+			"Cookie",
+			"Ocp-Apim-Subscription-Key",
+			"Set-Cookie",
+			"x-functions-key",
+			"x-ms-authorization-auxiliary",
+			"x-ms-copy-source-authorization",
+			"x-ms-encryption-key",
+		},
+		QueryParameters: []string{
+			"client_assertion",
+			"client_secret",
+			"code",
+			"sig",
+		},
+		JsonPaths: []string{
+			"$..access_token",
+			"$..adminPassword",
+			"$..administratorLoginPassword",
+			"$..clientSecret",
+			"$..connectionString",
+			"$..password",
+			"$..primaryConnectionString",
+			"$..primaryKey",
+			"$..primaryMasterKey",
+			"$..primaryReadonlyMasterKey",
+			"$..refresh_token",
+			"$..sasToken",
+			"$..secondaryConnectionString",
+			"$..secondaryKey",
+			"$..secondaryMasterKey",
+			"$..secondaryReadonlyMasterKey",
+			"$..secret",
+		},
+	},
+	{
+		// Resource Manager operations which return keys, connection strings or credentials
+		PathPattern: `/(listKeys|listAdminKeys|listQueryKeys|listAuthKeys|listConnectionStrings|listCredential|listCredentials|listSecrets|listClusterAdminCredential|listClusterUserCredential|listClusterMonitoringUserCredential|regenerateKey|regenerateKeys|regenerateAuthKey|regenerateAccessKey|regenerateCredential)$`,
+		JsonPaths: []string{
+			"$..*",
+		},
+	},
+	{
+		// Key Vault secrets, certificates (when imported) and managed storage SAS definitions
+		PathPattern: `^/(secrets|certificates|storage)/`,
+		JsonPaths: []string{
+			"$.value",
+			"$.pwd",
+		},
+	},
+	{
+		// the private components of Key Vault / Managed HSM keys
+		PathPattern: `^/keys/`,
+		JsonPaths: []string{
+			"$.key.d",
+			"$.key.dp",
+			"$.key.dq",
+			"$.key.k",
+			"$.key.p",
+			"$.key.q",
+			"$.key.qi",
+		},
+	},
+	{
+		// token requests
+		PathPattern: `/oauth2(/v2\.0)?/token$`,
+		QueryParameters: []string{
+			"assertion",
+			"password",
+			"refresh_token",
+		},
+		JsonPaths: []string{
+			"$.id_token",
+		},
+	},
+}
+
+// Redactor redacts sensitive values from HTTP Requests and Responses prior to them being logged.
+type Redactor struct {
+	rules       []redactionRule
+	maxBodySize int
+}
+
+type redactionRule struct {
+	pathPattern     *regexp.Regexp
+	headers         []string
+	queryParameters []string
+	jsonPaths       []jsonPath
+}
+
+var defaultRedactor = mustBuildRedactor(builtInRedactionRules, defaultLogMaxBodySize)
+
+// NewRedactor returns a Redactor applying the built-in RedactionRules alongside the specified RedactionRules,
+// which truncates bodies longer than maxBodySize bytes (where 0 disables truncation).
+func NewRedactor(rules []RedactionRule, maxBodySize int) (*Redactor, error) {
+	if maxBodySize < 0 {
+		return nil, fmt.Errorf("the maximum body size must be 0 or greater but got %d", maxBodySize)
+	}
+
+	return buildRedactor(append(append([]RedactionRule{}, builtInRedactionRules...), rules...), maxBodySize)
+}
+
+// NewRedactorFromEnvironment returns a Redactor configured using the `ARM_LOG_REDACTION_RULES` and
+// `ARM_LOG_MAX_BODY_SIZE` Environment Variables.
+func NewRedactorFromEnvironment() (*Redactor, error) {
+	maxBodySize := defaultLogMaxBodySize
+	if v := os.Getenv(LogMaxBodySizeEnvironmentVariable); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("parsing `%s` %q as an integer: %+v", LogMaxBodySizeEnvironmentVariable, v, err)
+		}
+		maxBodySize = size
+	}
+
+	rules := make([]RedactionRule, 0)
+	if path := os.Getenv(LogRedactionRulesEnvironmentVariable); path != "" {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading the Redaction Rules from %q (specified in `%s`): %+v", path, LogRedactionRulesEnvironmentVariable, err)
+		}
+
+		if err := json.Unmarshal(contents, &rules); err != nil {
+			return nil, fmt.Errorf("parsing the Redaction Rules from %q (specified in `%s`): %+v", path, LogRedactionRulesEnvironmentVariable, err)
+		}
+	}
+
+	return NewRedactor(rules, maxBodySize)
+}
+
+func buildRedactor(input []RedactionRule, maxBodySize int) (*Redactor, error) {
+	rules := make([]redactionRule, 0)
+	for i, v := range input {
+		rule := redactionRule{
+			headers:         v.Headers,
+			queryParameters: v.QueryParameters,
+		}
+
+		if v.PathPattern != "" {
+			pattern, err := regexp.Compile("(?i)" + v.PathPattern)
+			if err != nil {
+				return nil, fmt.Errorf("parsing the Path Pattern %q for Redaction Rule %d: %+v", v.PathPattern, i, err)
+			}
+			rule.pathPattern = pattern
+		}
+
+		for _, p := range v.JsonPaths {
+			path, err := parseJsonPath(p)
+			if err != nil {
+				return nil, fmt.Errorf("parsing the JSON Path %q for Redaction Rule %d: %+v", p, i, err)
+			}
+			rule.jsonPaths = append(rule.jsonPaths, path)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return &Redactor{
+		rules:       rules,
+		maxBodySize: maxBodySize,
+	}, nil
+}
+
+func mustBuildRedactor(rules []RedactionRule, maxBodySize int) *Redactor {
+	redactor, err := buildRedactor(rules, maxBodySize)
+	if err != nil {
+		panic(err)
+	}
+	return redactor
+}
+
+// rulesFor returns the Redaction Rules which apply to the specified URI.
+func (r *Redactor) rulesFor(uri *url.URL) []redactionRule {
+	output := make([]redactionRule, 0)
+	for _, rule := range r.rules {
+		if rule.pathPattern != nil && (uri == nil || !rule.pathPattern.MatchString(uri.Path)) {
+			continue
+		}
+		output = append(output, rule)
+	}
+	return output
+}
+
+// RedactHeaders returns a copy of the HTTP Headers with any sensitive values redacted.
+func (r *Redactor) RedactHeaders(uri *url.URL, input http.Header) http.Header {
+	output := input.Clone()
+	for _, header := range r.sensitiveHeaders(uri) {
+		if output.Get(header) != "" {
+			output.Set(header, redactedValue)
+		}
+	}
+	return output
+}
+
+func (r *Redactor) sensitiveHeaders(uri *url.URL) []string {
+	output := make([]string, 0)
+	for _, rule := range r.rulesFor(uri) {
+		output = append(output, rule.headers...)
+	}
+	return output
+}
+
+// RedactURL returns a copy of the URL with any sensitive Query String Parameters redacted.
+func (r *Redactor) RedactURL(uri *url.URL) *url.URL {
+	if uri == nil {
+		return nil
+	}
+
+	output := *uri
+	if uri.RawQuery == "" {
+		return &output
+	}
+
+	query := uri.Query()
+	if r.redactValues(uri, query) {
+		output.RawQuery = query.Encode()
+	}
+	return &output
+}
+
+// RedactBody returns the body with any sensitive values redacted, based on the Content Type of the body.
+func (r *Redactor) RedactBody(uri *url.URL, contentType string, body []byte) []byte {
+	return r.redactBody(uri, contentType, body, func(string) string {
+		return redactedValue
+	})
+}
+
+func (r *Redactor) redactBody(uri *url.URL, contentType string, body []byte, replace func(string) string) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	if strings.Contains(strings.ToLower(contentType), "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return []byte(redactedValue)
+		}
+		if r.redactValues(uri, values) {
+			return []byte(values.Encode())
+		}
+		return body
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		// not all bodies are JSON (e.g. Storage Blobs and the XML Storage APIs) - and these are returned as-is
+		return body
+	}
+
+	changed := false
+	for _, rule := range r.rulesFor(uri) {
+		for _, path := range rule.jsonPaths {
+			if path.redact(decoded, replace) {
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return body
+	}
+
+	output, err := json.Marshal(decoded)
+	if err != nil {
+		return []byte(redactedValue)
+	}
+	return output
+}
+
+// TruncateBody truncates the body to the maximum body size configured for this Redactor.
+func (r *Redactor) TruncateBody(body []byte) []byte {
+	if r.maxBodySize == 0 || len(body) <= r.maxBodySize {
+		return body
+	}
+
+	return append(body[:r.maxBodySize:r.maxBodySize], []byte(fmt.Sprintf("... [truncated %d bytes]", len(body)-r.maxBodySize))...)
+}
+
+func (r *Redactor) redactValues(uri *url.URL, values url.Values) bool {
+	changed := false
+	for _, rule := range r.rulesFor(uri) {
+		for _, parameter := range rule.queryParameters {
+			for key := range values {
+				if strings.EqualFold(key, parameter) {
+					values.Set(key, redactedValue)
+					changed = true
+				}
+			}
+		}
+	}
+	return changed
+}
+
+// jsonPath is a simplified JSONPath expression, supporting child (`.name`), wildcard (`.*` and `[*]`)
+// and recursive descent (`..name`) segments.
+type jsonPath []jsonPathSegment
+
+type jsonPathSegment struct {
+	name      string
+	recursive bool
+}
+
+func (s jsonPathSegment) matches(key string) bool {
+	return s.name == "*" || strings.EqualFold(s.name, key)
+}
+
+func parseJsonPath(input string) (jsonPath, error) {
+	if !strings.HasPrefix(input, "$") {
+		return nil, fmt.Errorf("expected the JSON Path to start with `$`")
+	}
+
+	remaining := strings.ReplaceAll(strings.TrimPrefix(input, "$"), "[*]", ".*")
+	output := make(jsonPath, 0)
+	for remaining != "" {
+		segment := jsonPathSegment{}
+		switch {
+		case strings.HasPrefix(remaining, ".."):
+			segment.recursive = true
+			remaining = strings.TrimPrefix(remaining, "..")
+		case strings.HasPrefix(remaining, "."):
+			remaining = strings.TrimPrefix(remaining, ".")
+		default:
+			return nil, fmt.Errorf("expected a `.` or `..` but got %q", remaining)
+		}
+
+		end := strings.Index(remaining, ".")
+		if end == -1 {
+			end = len(remaining)
+		}
+		segment.name = remaining[:end]
+		remaining = remaining[end:]
+		if segment.name == "" {
+			return nil, fmt.Errorf("expected a property name or `*` but got an empty segment")
+		}
+
+		output = append(output, segment)
+	}
+
+	if len(output) == 0 {
+		return nil, fmt.Errorf("expected at least one segment")
+	}
+
+	return output, nil
+}
+
+// redact replaces the values matching the JSON Path within input, returning whether any values were replaced.
+func (p jsonPath) redact(input interface{}, replace func(string) string) bool {
+	segment := p[0]
+	last := len(p) == 1
+	changed := false
+
+	visit := func(child interface{}, matches bool, set func(interface{})) {
+		if matches {
+			if last {
+				set(redactJsonValue(child, replace))
+				changed = true
+				return
+			}
+
+			if p[1:].redact(child, replace) {
+				changed = true
+			}
+		}
+
+		if segment.recursive && p.redact(child, replace) {
+			changed = true
+		}
+	}
+
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			key := key
+			visit(child, segment.matches(key), func(value interface{}) {
+				v[key] = value
+			})
+		}
+
+	case []interface{}:
+		for i, child := range v {
+			i := i
+			visit(child, segment.name == "*", func(value interface{}) {
+				v[i] = value
+			})
+		}
+	}
+
+	return changed
+}
+
+// redactJsonValue replaces all of the strings within input, retaining the structure of any objects or arrays.
+func redactJsonValue(input interface{}, replace func(string) string) interface{} {
+	switch v := input.(type) {
+	case string:
+		return replace(v)
+
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = redactJsonValue(child, replace)
+		}
+		return v
+
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactJsonValue(child, replace)
+		}
+		return v
+	}
+
+	return input
+}
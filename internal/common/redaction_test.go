@@ -0,0 +1,269 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRedactorRedactBody(t *testing.T) {
+	redactor, err := NewRedactor([]RedactionRule{
+		{
+			PathPattern: `/providers/Microsoft.Example/widgets/[^/]+$`,
+			JsonPaths:   []string{"$.properties.settings[*].token"},
+		},
+	}, 0)
+	if err != nil {
+		t.Fatalf("building redactor: %+v", err)
+	}
+
+	testData := []struct {
+		Name        string
+		Url         string
+		ContentType string
+		Input       string
+		Expected    string
+	}{
+		{
+			Name:     "no sensitive values",
+			Url:      "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example",
+			Input:    `{"location":"westeurope"}`,
+			Expected: `{"location":"westeurope"}`,
+		},
+		{
+			Name:     "nested password",
+			Url:      "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example/providers/Microsoft.Sql/servers/example",
+			Input:    `{"properties":{"administratorLogin":"admin","administratorLoginPassword":"P@ssw0rd!"}}`,
+			Expected: `{"properties":{"administratorLogin":"admin","administratorLoginPassword":"REDACTED"}}`,
+		},
+		{
+			Name:     "list keys",
+			Url:      "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/listKeys",
+			Input:    `{"keys":[{"keyName":"key1","permissions":"FULL","value":"c2VjcmV0"}]}`,
+			Expected: `{"keys":[{"keyName":"REDACTED","permissions":"REDACTED","value":"REDACTED"}]}`,
+		},
+		{
+			Name:     "key vault secret",
+			Url:      "https://example.vault.azure.net/secrets/example/00000000000000000000000000000000",
+			Input:    `{"id":"https://example.vault.azure.net/secrets/example","value":"hunter2"}`,
+			Expected: `{"id":"https://example.vault.azure.net/secrets/example","value":"REDACTED"}`,
+		},
+		{
+			Name:        "token request",
+			Url:         "https://login.microsoftonline.com/11111111-1111-1111-1111-111111111111/oauth2/v2.0/token",
+			ContentType: "application/x-www-form-urlencoded",
+			Input:       "client_id=example&client_secret=hunter2&grant_type=client_credentials",
+			Expected:    "client_id=example&client_secret=REDACTED&grant_type=client_credentials",
+		},
+		{
+			Name:     "custom rule",
+			Url:      "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example/providers/Microsoft.Example/widgets/example",
+			Input:    `{"properties":{"settings":[{"name":"first","token":"hunter2"}]}}`,
+			Expected: `{"properties":{"settings":[{"name":"first","token":"REDACTED"}]}}`,
+		},
+		{
+			Name:     "not json",
+			Url:      "https://example.blob.core.windows.net/container/blob",
+			Input:    `hello world`,
+			Expected: `hello world`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		uri, err := url.Parse(v.Url)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", v.Url, err)
+		}
+
+		actual := string(redactor.RedactBody(uri, v.ContentType, []byte(v.Input)))
+		if actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestRedactorRedactURLAndHeaders(t *testing.T) {
+	uri, err := url.Parse("https://example.blob.core.windows.net/container/blob?sv=2022-11-02&sig=c2VjcmV0&se=2024-01-01")
+	if err != nil {
+		t.Fatalf("parsing: %+v", err)
+	}
+
+	actual := defaultRedactor.RedactURL(uri)
+	if actual.Query().Get("sig") != redactedValue {
+		t.Fatalf("expected `sig` to be redacted but got %q", actual.String())
+	}
+	if actual.Query().Get("sv") != "2022-11-02" {
+		t.Fatalf("expected `sv` to be retained but got %q", actual.String())
+	}
+	if uri.Query().Get("sig") != "c2VjcmV0" {
+		t.Fatalf("expected the original URL to be unchanged but got %q", uri.String())
+	}
+
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer abc123")
+	headers.Set("Content-Type", "application/json")
+	redacted := defaultRedactor.RedactHeaders(uri, headers)
+	if v := redacted.Get("Authorization"); v != redactedValue {
+		t.Fatalf("expected the Authorization header to be redacted but got %q", v)
+	}
+	if v := redacted.Get("Content-Type"); v != "application/json" {
+		t.Fatalf("expected the Content-Type header to be retained but got %q", v)
+	}
+	if v := headers.Get("Authorization"); v != "Bearer abc123" {
+		t.Fatalf("expected the original headers to be unchanged but got %q", v)
+	}
+}
+
+func TestRedactorTruncateBody(t *testing.T) {
+	redactor, err := NewRedactor(nil, 5)
+	if err != nil {
+		t.Fatalf("building redactor: %+v", err)
+	}
+
+	if actual := string(redactor.TruncateBody([]byte("abc"))); actual != "abc" {
+		t.Fatalf("expected %q but got %q", "abc", actual)
+	}
+
+	expected := "abcde... [truncated 3 bytes]"
+	if actual := string(redactor.TruncateBody([]byte("abcdefgh"))); actual != expected {
+		t.Fatalf("expected %q but got %q", expected, actual)
+	}
+}
+
+func TestNewRedactorFromEnvironment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(`[{"headers":["x-example-key"]}]`), 0o644); err != nil {
+		t.Fatalf("writing rules: %+v", err)
+	}
+	t.Setenv(LogRedactionRulesEnvironmentVariable, path)
+	t.Setenv(LogMaxBodySizeEnvironmentVariable, "10")
+
+	redactor, err := NewRedactorFromEnvironment()
+	if err != nil {
+		t.Fatalf("building redactor: %+v", err)
+	}
+	if redactor.maxBodySize != 10 {
+		t.Fatalf("expected a max body size of 10 but got %d", redactor.maxBodySize)
+	}
+
+	headers := http.Header{}
+	headers.Set("x-example-key", "hunter2")
+	if v := redactor.RedactHeaders(nil, headers).Get("x-example-key"); v != redactedValue {
+		t.Fatalf("expected the custom header to be redacted but got %q", v)
+	}
+
+	t.Setenv(LogMaxBodySizeEnvironmentVariable, "-1")
+	if _, err := NewRedactorFromEnvironment(); err == nil {
+		t.Fatalf("expected an error for a negative max body size")
+	}
+}
+
+func TestParseJsonPath(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected jsonPath
+		Error    bool
+	}{
+		{
+			Input: "",
+			Error: true,
+		},
+		{
+			Input: "$",
+			Error: true,
+		},
+		{
+			Input: "properties.password",
+			Error: true,
+		},
+		{
+			Input: "$.properties.",
+			Error: true,
+		},
+		{
+			Input:    "$.properties.password",
+			Expected: jsonPath{{name: "properties"}, {name: "password"}},
+		},
+		{
+			Input:    "$.keys[*].value",
+			Expected: jsonPath{{name: "keys"}, {name: "*"}, {name: "value"}},
+		},
+		{
+			Input:    "$..password",
+			Expected: jsonPath{{name: "password", recursive: true}},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := parseJsonPath(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if len(actual) != len(v.Expected) {
+			t.Fatalf("expected %d segments but got %d", len(v.Expected), len(actual))
+		}
+		for i := range actual {
+			if actual[i] != v.Expected[i] {
+				t.Fatalf("expected segment %d to be %+v but got %+v", i, v.Expected[i], actual[i])
+			}
+		}
+	}
+}
+
+func TestRequestLoggerMiddlewareRedactsSecrets(t *testing.T) {
+	output := bytes.Buffer{}
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	body := `{"properties":{"administratorLoginPassword":"hunter2"}}`
+	request, err := http.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example/providers/Microsoft.Sql/servers/example?api-version=2023-05-01-preview", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	request.Header.Set("Authorization", "Bearer abc123")
+	request.Header.Set("Content-Type", "application/json")
+
+	request, err = requestLoggerMiddleware("AzureRM", defaultRedactor)(request)
+	if err != nil {
+		t.Fatalf("logging request: %+v", err)
+	}
+
+	for _, secret := range []string{"hunter2", "abc123"} {
+		if strings.Contains(output.String(), secret) {
+			t.Fatalf("expected %q to be redacted from the log output: %s", secret, output.String())
+		}
+	}
+
+	// the request itself should be unchanged
+	if v := request.Header.Get("Authorization"); v != "Bearer abc123" {
+		t.Fatalf("expected the Authorization header to be retained but got %q", v)
+	}
+	actual, err := io.ReadAll(request.Body)
+	if err != nil {
+		t.Fatalf("reading body: %+v", err)
+	}
+	if string(actual) != body {
+		t.Fatalf("expected the body to be %q but got %q", body, string(actual))
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+// ResourceProviderRegistrationFunc registers the specified Resource Provider within the specified Subscription
+// should it not already be registered
+type ResourceProviderRegistrationFunc func(ctx context.Context, subscriptionId string, resourceProvider string) error
+
+// resourceProviderRegistrationMiddleware ensures that the Resource Provider for each Resource being created or
+// updated is registered prior to the request being sent, so that Resource Providers are registered on-demand
+func resourceProviderRegistrationMiddleware(register ResourceProviderRegistrationFunc) client.RequestMiddleware {
+	return func(r *http.Request) (*http.Request, error) {
+		if r.Method != http.MethodPut {
+			return r, nil
+		}
+
+		subscriptionId, resourceProvider, ok := resourceManagerScopeFromUri(r.URL.Path)
+		if !ok || subscriptionId == tenantResourceManagerScope || strings.EqualFold(resourceProvider, "Microsoft.Resources") {
+			return r, nil
+		}
+
+		if err := register(r.Context(), subscriptionId, resourceProvider); err != nil {
+			return r, fmt.Errorf("registering the Resource Provider %q: %+v", resourceProvider, err)
+		}
+
+		return r, nil
+	}
+}
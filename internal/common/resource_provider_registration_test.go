@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestResourceProviderRegistrationMiddleware(t *testing.T) {
+	testData := []struct {
+		Method   string
+		Uri      string
+		Expected string
+	}{
+		{
+			Method: http.MethodGet,
+			Uri:    "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example",
+		},
+		{
+			Method:   http.MethodPut,
+			Uri:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example",
+			Expected: "Microsoft.Network",
+		},
+		{
+			Method: http.MethodPut,
+			Uri:    "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+		},
+		{
+			Method: http.MethodPut,
+			Uri:    "https://management.azure.com/providers/Microsoft.Management/managementGroups/example",
+		},
+		{
+			Method: http.MethodPut,
+			Uri:    "https://example.vault.azure.net/secrets/example",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %s %q", v.Method, v.Uri)
+
+		registered := ""
+		middleware := resourceProviderRegistrationMiddleware(func(_ context.Context, subscriptionId string, resourceProvider string) error {
+			if subscriptionId != "00000000-0000-0000-0000-000000000000" {
+				t.Fatalf("expected the Subscription ID to be %q but got %q", "00000000-0000-0000-0000-000000000000", subscriptionId)
+			}
+			registered = resourceProvider
+			return nil
+		})
+
+		req, err := http.NewRequest(v.Method, v.Uri, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		if _, err := middleware(req); err != nil {
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if registered != v.Expected {
+			t.Fatalf("expected %q to be registered but got %q", v.Expected, registered)
+		}
+	}
+}
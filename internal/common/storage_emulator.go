@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// StorageEmulatorDefaultAccountName is the name of the Storage Account which is available in Azurite by default
+	StorageEmulatorDefaultAccountName = "devstoreaccount1"
+
+	// StorageEmulatorDefaultAccountKey is the (publicly documented) Account Key for the default Azurite Storage Account
+	StorageEmulatorDefaultAccountKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+
+	StorageEmulatorDefaultBlobEndpoint  = "http://127.0.0.1:10000"
+	StorageEmulatorDefaultQueueEndpoint = "http://127.0.0.1:10001"
+	StorageEmulatorDefaultTableEndpoint = "http://127.0.0.1:10002"
+)
+
+// StorageEmulator configures the Storage Data Plane Clients to use a local Storage Emulator (such as Azurite) rather
+// than Azure - in which case no requests are made to Azure Resource Manager to look up the Storage Account
+type StorageEmulator struct {
+	AccountName string
+	AccountKey  string
+
+	// BlobEndpoint, QueueEndpoint and TableEndpoint are the endpoints for each service exposed by the Storage Emulator,
+	// which are combined with the Account Name to form a path-style URI, e.g. `http://127.0.0.1:10000/devstoreaccount1`
+	BlobEndpoint  string
+	QueueEndpoint string
+	TableEndpoint string
+}
+
+// storageEmulatorAuthorizer is the auth.Authorizer used when a Storage Emulator is configured, which fails to authorize
+// any request - since only the Storage Data Plane APIs are available, which authenticate using the Account Key
+type storageEmulatorAuthorizer struct{}
+
+// StorageEmulatorAuthorizer returns an auth.Authorizer for use when a Storage Emulator is configured, which returns an
+// error for any request to an API other than the Storage Data Plane APIs (e.g. Resource Manager or Key Vault)
+func StorageEmulatorAuthorizer() auth.Authorizer {
+	return storageEmulatorAuthorizer{}
+}
+
+func (storageEmulatorAuthorizer) Token(_ context.Context, req *http.Request) (*oauth2.Token, error) {
+	return nil, storageEmulatorUnsupportedApiError(req)
+}
+
+func (storageEmulatorAuthorizer) AuxiliaryTokens(_ context.Context, req *http.Request) ([]*oauth2.Token, error) {
+	return nil, storageEmulatorUnsupportedApiError(req)
+}
+
+func storageEmulatorUnsupportedApiError(req *http.Request) error {
+	host := "this API"
+	if req != nil && req.URL != nil {
+		host = req.URL.Host
+	}
+	return fmt.Errorf("the `storage_emulator` block is configured, as such only the Blob, Queue and Table Data Plane APIs of the Storage Emulator are available and requests to %s can't be made - remove the `storage_emulator` block to manage resources within Azure", host)
+}
@@ -0,0 +1,430 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+const (
+	remainingReadsHeader  = "x-ms-ratelimit-remaining-subscription-reads"
+	remainingWritesHeader = "x-ms-ratelimit-remaining-subscription-writes"
+
+	// throttlingLowWaterMark is the number of remaining requests reported by Resource Manager below
+	// which requests for that Subscription are progressively delayed, up to throttlingMaxDelay
+	throttlingLowWaterMark = 50
+	throttlingMaxDelay     = 5 * time.Second
+
+	// tenantResourceManagerScope is used for requests which aren't scoped to a Subscription
+	tenantResourceManagerScope = "tenant"
+)
+
+// RateLimiter cooperatively throttles the requests made to Resource Manager by all of the Clients
+// sharing it, based on the remaining request budget and any `Retry-After` returned by Resource Manager,
+// and optionally limits the number of concurrent requests made to each Resource Provider.
+type RateLimiter struct {
+	// MaxConcurrentRequests is the maximum number of concurrent requests made to each Resource Provider,
+	// where 0 means unlimited
+	MaxConcurrentRequests int
+
+	// ResourceProviderMaxConcurrentRequests overrides MaxConcurrentRequests for specific Resource Providers
+	// (for example `Microsoft.Network`)
+	ResourceProviderMaxConcurrentRequests map[string]int
+
+	lock    sync.Mutex
+	budgets map[string]*throttlingBudget
+	slots   map[string]chan struct{}
+
+	// now and sleep are overridden in the tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+type throttlingBudget struct {
+	remainingReads  *int
+	remainingWrites *int
+	blockedUntil    time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing up to maxConcurrentRequests concurrent requests to each
+// Resource Provider (0 meaning unlimited), with any overrides for specific Resource Providers
+func NewRateLimiter(maxConcurrentRequests int, resourceProviderMaxConcurrentRequests map[string]int) (*RateLimiter, error) {
+	if maxConcurrentRequests < 0 {
+		return nil, fmt.Errorf("the maximum number of concurrent requests must not be negative but got %d", maxConcurrentRequests)
+	}
+
+	overrides := make(map[string]int)
+	for k, v := range resourceProviderMaxConcurrentRequests {
+		if v < 0 {
+			return nil, fmt.Errorf("the maximum number of concurrent requests for %q must not be negative but got %d", k, v)
+		}
+		overrides[strings.ToLower(k)] = v
+	}
+
+	return &RateLimiter{
+		MaxConcurrentRequests:                 maxConcurrentRequests,
+		ResourceProviderMaxConcurrentRequests: overrides,
+	}, nil
+}
+
+// Wait blocks until the request can be sent - that is once any throttling for the Subscription has elapsed
+// and a slot is available for the Resource Provider - returning a function which must be called once the
+// request has completed.
+func (r *RateLimiter) Wait(req *http.Request) (func(), error) {
+	subscriptionId, resourceProvider, ok := resourceManagerScopeFromUri(req.URL.Path)
+	if !ok {
+		return func() {}, nil
+	}
+
+	ctx := req.Context()
+	if delay := r.delay(subscriptionId, isWriteRequest(req.Method)); delay > 0 {
+		log.Printf("[DEBUG] Throttling: delaying request to %s %s by %s", req.Method, req.URL.Path, delay)
+		if err := r.sleepFor(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+
+	slots := r.slotsFor(resourceProvider)
+	if slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a request slot for %q: %+v", resourceProvider, ctx.Err())
+	}
+
+	once := sync.Once{}
+	return func() {
+		once.Do(func() {
+			<-slots
+		})
+	}, nil
+}
+
+// Observe updates the remaining request budget for the Subscription from the Response returned by Resource Manager
+func (r *RateLimiter) Observe(req *http.Request, resp *http.Response) {
+	if req == nil || req.URL == nil || resp == nil {
+		return
+	}
+
+	subscriptionId, _, ok := resourceManagerScopeFromUri(req.URL.Path)
+	if !ok {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	budget := r.budgetFor(subscriptionId)
+	if v, ok := headerAsInt(resp.Header, remainingReadsHeader); ok {
+		budget.remainingReads = &v
+	}
+	if v, ok := headerAsInt(resp.Header, remainingWritesHeader); ok {
+		budget.remainingWrites = &v
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter := 0
+		if v, ok := headerAsInt(resp.Header, "Retry-After"); ok {
+			retryAfter = v
+		}
+		if until := r.currentTime().Add(time.Duration(retryAfter) * time.Second); until.After(budget.blockedUntil) {
+			log.Printf("[DEBUG] Throttling: Resource Manager throttled requests for %q, pausing requests for %ds", subscriptionId, retryAfter)
+			budget.blockedUntil = until
+		}
+	}
+}
+
+func (r *RateLimiter) delay(subscriptionId string, write bool) time.Duration {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	budget := r.budgetFor(subscriptionId)
+	delay := budget.blockedUntil.Sub(r.currentTime())
+	if delay < 0 {
+		delay = 0
+	}
+
+	remaining := budget.remainingReads
+	if write {
+		remaining = budget.remainingWrites
+	}
+	if remaining != nil && *remaining < throttlingLowWaterMark {
+		available := *remaining
+		if available < 0 {
+			available = 0
+		}
+		// spread the remaining requests out as the budget is exhausted
+		budgetDelay := throttlingMaxDelay * time.Duration(throttlingLowWaterMark-available) / throttlingLowWaterMark
+		if budgetDelay > delay {
+			delay = budgetDelay
+		}
+	}
+
+	return delay
+}
+
+func (r *RateLimiter) budgetFor(subscriptionId string) *throttlingBudget {
+	if r.budgets == nil {
+		r.budgets = make(map[string]*throttlingBudget)
+	}
+	budget, ok := r.budgets[subscriptionId]
+	if !ok {
+		budget = &throttlingBudget{}
+		r.budgets[subscriptionId] = budget
+	}
+	return budget
+}
+
+func (r *RateLimiter) slotsFor(resourceProvider string) chan struct{} {
+	limit := r.MaxConcurrentRequests
+	if v, ok := r.ResourceProviderMaxConcurrentRequests[strings.ToLower(resourceProvider)]; ok {
+		limit = v
+	}
+	if limit == 0 {
+		return nil
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := strings.ToLower(resourceProvider)
+	if r.slots == nil {
+		r.slots = make(map[string]chan struct{})
+	}
+	slots, ok := r.slots[key]
+	if !ok {
+		slots = make(chan struct{}, limit)
+		r.slots[key] = slots
+	}
+	return slots
+}
+
+func (r *RateLimiter) currentTime() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+func (r *RateLimiter) sleepFor(ctx context.Context, d time.Duration) error {
+	if r.sleep != nil {
+		return r.sleep(ctx, d)
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for throttling to elapse: %+v", ctx.Err())
+	}
+}
+
+// resourceManagerScopeFromUri returns the Subscription ID and Resource Provider for a Resource Manager URI, returning
+// false for URIs which aren't Resource Manager URIs (such as the Data Plane APIs)
+func resourceManagerScopeFromUri(path string) (subscriptionId string, resourceProvider string, ok bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	subscriptionId = tenantResourceManagerScope
+	isResourceManager := false
+	for i := 0; i < len(segments)-1; i++ {
+		switch strings.ToLower(segments[i]) {
+		case "subscriptions":
+			if i == 0 {
+				subscriptionId = strings.ToLower(segments[i+1])
+				isResourceManager = true
+			}
+		case "providers":
+			resourceProvider = segments[i+1]
+			isResourceManager = true
+		}
+	}
+
+	if !isResourceManager {
+		return "", "", false
+	}
+
+	if resourceProvider == "" {
+		// e.g. Resource Groups and Subscriptions are managed by the Resources Resource Provider
+		resourceProvider = "Microsoft.Resources"
+	}
+
+	return subscriptionId, resourceProvider, true
+}
+
+func isWriteRequest(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+func headerAsInt(header http.Header, name string) (int, bool) {
+	v := header.Get(name)
+	if v == "" {
+		return 0, false
+	}
+	i, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
+
+// rateLimitedTransport is an http.RoundTripper which waits for the RateLimiter prior to sending each request,
+// observing each response and releasing the slot for the Resource Provider once the round trip has returned
+type rateLimitedTransport struct {
+	limiter *RateLimiter
+	next    http.RoundTripper
+}
+
+func (t rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.Wait(req)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	resp, err := t.next.RoundTrip(req)
+	t.limiter.Observe(req, resp)
+	return resp, err
+}
+
+// rateLimitedTransportFor returns the http.RoundTripper used to send requests, which is rate limited when a
+// RateLimiter is configured
+func rateLimitedTransportFor(limiter *RateLimiter, transport http.RoundTripper) http.RoundTripper {
+	if limiter == nil {
+		return transport
+	}
+	return rateLimitedTransport{
+		limiter: limiter,
+		next:    transport,
+	}
+}
+
+// rateLimitedAttempts gates each attempt to send a request made by a go-azure-sdk Client on the RateLimiter.
+//
+// The go-azure-sdk Client retries requests within its own (fixed) HTTP Transport, as such each attempt is
+// detected using a httptrace.ClientTrace, which the Transport invokes prior to obtaining a connection for each
+// attempt - and the slot for the Resource Provider is released as each attempt completes, rather than once
+// the Client has finished retrying.
+type rateLimitedAttempts struct {
+	limiter *RateLimiter
+	req     *http.Request
+
+	lock    sync.Mutex
+	release func()
+
+	// stopReleaseOnDone stops the fallback which releases the slot should the context be cancelled (or its
+	// deadline exceeded) whilst the slot is held
+	stopReleaseOnDone func() bool
+}
+
+func (a *rateLimitedAttempts) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn: func(string) {
+			a.attemptStarted()
+		},
+		GotFirstResponseByte: a.attemptCompleted,
+		ConnectDone: func(_, _ string, err error) {
+			if err != nil {
+				a.attemptCompleted()
+			}
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			if err != nil {
+				a.attemptCompleted()
+			}
+		},
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err != nil {
+				a.attemptCompleted()
+			}
+		},
+	}
+}
+
+func (a *rateLimitedAttempts) attemptStarted() {
+	// the previous attempt has completed if the request is being retried
+	a.attemptCompleted()
+
+	release, err := a.limiter.Wait(a.req)
+	if err != nil {
+		// the context has been cancelled, which the Transport surfaces when sending the request
+		log.Printf("[DEBUG] Throttling: %+v", err)
+		return
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.release = release
+
+	// ensure the slot is released should the attempt fail without a response
+	a.stopReleaseOnDone = context.AfterFunc(a.req.Context(), a.attemptCompleted)
+}
+
+func (a *rateLimitedAttempts) attemptCompleted() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.stopReleaseOnDone != nil {
+		a.stopReleaseOnDone()
+		a.stopReleaseOnDone = nil
+	}
+
+	if a.release != nil {
+		a.release()
+		a.release = nil
+	}
+}
+
+type rateLimitedAttemptsKey struct{}
+
+// rateLimiterRequestMiddleware waits for the RateLimiter prior to each attempt to send the request
+func rateLimiterRequestMiddleware(limiter *RateLimiter) client.RequestMiddleware {
+	return func(r *http.Request) (*http.Request, error) {
+		if _, _, ok := resourceManagerScopeFromUri(r.URL.Path); !ok {
+			return r, nil
+		}
+
+		attempts := &rateLimitedAttempts{
+			limiter: limiter,
+			req:     r,
+		}
+		ctx := context.WithValue(r.Context(), rateLimitedAttemptsKey{}, attempts)
+		ctx = httptrace.WithClientTrace(ctx, attempts.trace())
+
+		return r.WithContext(ctx), nil
+	}
+}
+
+func rateLimiterResponseMiddleware(limiter *RateLimiter) client.ResponseMiddleware {
+	return func(req *http.Request, resp *http.Response) (*http.Response, error) {
+		limiter.Observe(req, resp)
+
+		if req != nil {
+			if attempts, ok := req.Context().Value(rateLimitedAttemptsKey{}).(*rateLimitedAttempts); ok {
+				attempts.attemptCompleted()
+			}
+		}
+
+		return resp, nil
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestResourceManagerScopeFromUri(t *testing.T) {
+	testData := []struct {
+		Input            string
+		SubscriptionId   string
+		ResourceProvider string
+		Valid            bool
+	}{
+		{
+			Input: "/secrets/example",
+			Valid: false,
+		},
+		{
+			Input:            "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			SubscriptionId:   "00000000-0000-0000-0000-000000000000",
+			ResourceProvider: "Microsoft.Resources",
+			Valid:            true,
+		},
+		{
+			Input:            "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example",
+			SubscriptionId:   "00000000-0000-0000-0000-000000000000",
+			ResourceProvider: "Microsoft.Network",
+			Valid:            true,
+		},
+		{
+			Input:            "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example/providers/Microsoft.Authorization/roleAssignments/example",
+			SubscriptionId:   "00000000-0000-0000-0000-000000000000",
+			ResourceProvider: "Microsoft.Authorization",
+			Valid:            true,
+		},
+		{
+			Input:            "/providers/Microsoft.Management/managementGroups/example",
+			SubscriptionId:   tenantResourceManagerScope,
+			ResourceProvider: "Microsoft.Management",
+			Valid:            true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		subscriptionId, resourceProvider, ok := resourceManagerScopeFromUri(v.Input)
+		if ok != v.Valid {
+			t.Fatalf("expected valid to be %t but got %t", v.Valid, ok)
+		}
+		if subscriptionId != v.SubscriptionId {
+			t.Fatalf("expected the subscription to be %q but got %q", v.SubscriptionId, subscriptionId)
+		}
+		if resourceProvider != v.ResourceProvider {
+			t.Fatalf("expected the resource provider to be %q but got %q", v.ResourceProvider, resourceProvider)
+		}
+	}
+}
+
+func TestRateLimiterDelaysRequests(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var slept []time.Duration
+
+	limiter, err := NewRateLimiter(0, nil)
+	if err != nil {
+		t.Fatalf("building the rate limiter: %+v", err)
+	}
+	limiter.now = func() time.Time {
+		return now
+	}
+	limiter.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+
+	readUri := "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example?api-version=2020-01-01"
+	otherUri := "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example?api-version=2020-01-01"
+
+	send := func(method, uri string) {
+		t.Helper()
+		req, err := http.NewRequest(method, uri, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		release, err := limiter.Wait(req)
+		if err != nil {
+			t.Fatalf("waiting: %+v", err)
+		}
+		release()
+	}
+	observe := func(method, uri string, statusCode int, headers map[string]string) {
+		t.Helper()
+		u, _ := url.Parse(uri)
+		resp := &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{},
+		}
+		for k, v := range headers {
+			resp.Header.Set(k, v)
+		}
+		limiter.Observe(&http.Request{Method: method, URL: u}, resp)
+	}
+
+	// plenty of budget remaining
+	observe(http.MethodGet, readUri, http.StatusOK, map[string]string{remainingReadsHeader: "11999"})
+	send(http.MethodGet, readUri)
+	if len(slept) != 0 {
+		t.Fatalf("expected no delay but got %+v", slept)
+	}
+
+	// the read budget is exhausted, but not the write budget
+	observe(http.MethodGet, readUri, http.StatusOK, map[string]string{remainingReadsHeader: "0", remainingWritesHeader: "1199"})
+	send(http.MethodGet, readUri)
+	send(http.MethodPut, readUri)
+	send(http.MethodGet, otherUri)
+	if len(slept) != 1 || slept[0] != throttlingMaxDelay {
+		t.Fatalf("expected a single delay of %s but got %+v", throttlingMaxDelay, slept)
+	}
+
+	// throttled by Resource Manager
+	slept = nil
+	observe(http.MethodPut, readUri, http.StatusTooManyRequests, map[string]string{remainingReadsHeader: "1000", "Retry-After": "17"})
+	send(http.MethodGet, readUri)
+	send(http.MethodGet, otherUri)
+	if len(slept) != 1 || slept[0] != 17*time.Second {
+		t.Fatalf("expected a single delay of 17s but got %+v", slept)
+	}
+
+	// once the Retry-After has elapsed
+	slept = nil
+	now = now.Add(20 * time.Second)
+	send(http.MethodGet, readUri)
+	if len(slept) != 0 {
+		t.Fatalf("expected no delay but got %+v", slept)
+	}
+}
+
+func TestRateLimiterLimitsConcurrentRequests(t *testing.T) {
+	limiter, err := NewRateLimiter(2, map[string]int{
+		"microsoft.network": 1,
+	})
+	if err != nil {
+		t.Fatalf("building the rate limiter: %+v", err)
+	}
+
+	newRequest := func(ctx context.Context, resourceProvider string) *http.Request {
+		uri := "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/" + resourceProvider + "/example"
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		return req
+	}
+
+	release, err := limiter.Wait(newRequest(context.Background(), "Microsoft.Network"))
+	if err != nil {
+		t.Fatalf("waiting: %+v", err)
+	}
+
+	// the override only allows a single concurrent request to Microsoft.Network
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.Wait(newRequest(ctx, "Microsoft.Network")); err == nil {
+		t.Fatalf("expected an error waiting for a second Microsoft.Network slot but didn't get one")
+	}
+
+	// other resource providers use the default limit
+	for i := 0; i < 2; i++ {
+		if _, err := limiter.Wait(newRequest(context.Background(), "Microsoft.Compute")); err != nil {
+			t.Fatalf("waiting: %+v", err)
+		}
+	}
+
+	release()
+	release()
+	ctx2, cancel2 := context.WithTimeout(context.Background(), time.Second)
+	defer cancel2()
+	if _, err := limiter.Wait(newRequest(ctx2, "Microsoft.Network")); err != nil {
+		t.Fatalf("expected a Microsoft.Network slot to be available once released but got: %+v", err)
+	}
+}
+
+func TestRateLimitedTransportReleasesSlotOnceRoundTripReturns(t *testing.T) {
+	limiter, err := NewRateLimiter(1, nil)
+	if err != nil {
+		t.Fatalf("building the rate limiter: %+v", err)
+	}
+
+	attempts := 0
+	transport := rateLimitedTransport{
+		limiter: limiter,
+		next: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, fmt.Errorf("connection reset")
+			}
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header: http.Header{
+					"Retry-After": []string{"17"},
+				},
+			}, nil
+		}),
+	}
+
+	uri := "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Network/example"
+	for i := 0; i < 2; i++ {
+		// the context is never cancelled, so the slot must be released when the round trip returns
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		_, _ = transport.RoundTrip(req)
+		if err := ctx.Err(); err != nil {
+			t.Fatalf("expected the slot to be released when the round trip returned but the request timed out: %+v", err)
+		}
+		cancel()
+	}
+
+	// each attempt is observed, including the throttled response
+	if delay := limiter.delay("00000000-0000-0000-0000-000000000000", false); delay <= 0 {
+		t.Fatalf("expected the throttled response to have been observed but got a delay of %s", delay)
+	}
+}
+
+func TestRateLimiterMiddlewareGatesEachAttempt(t *testing.T) {
+	limiter, err := NewRateLimiter(1, nil)
+	if err != nil {
+		t.Fatalf("building the rate limiter: %+v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Network/example", nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	req, err = rateLimiterRequestMiddleware(limiter)(req)
+	if err != nil {
+		t.Fatalf("running the request middleware: %+v", err)
+	}
+
+	// retrying the request (as the go-azure-sdk Client does) must wait for, and release, a slot for each attempt
+	httpClient := server.Client()
+	for i := 0; i < 3; i++ {
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			t.Fatalf("attempt %d: %+v", i, err)
+		}
+		resp.Body.Close()
+	}
+
+	if _, err := rateLimiterResponseMiddleware(limiter)(req, nil); err != nil {
+		t.Fatalf("running the response middleware: %+v", err)
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+	defer waitCancel()
+	next, err := http.NewRequestWithContext(waitCtx, http.MethodGet, req.URL.String(), nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	release, err := limiter.Wait(next)
+	if err != nil {
+		t.Fatalf("expected the slot to be released once the request completed but got: %+v", err)
+	}
+	release()
+}
+
+func TestRateLimitedAttemptsReleaseSlotOnlyWhilstHeld(t *testing.T) {
+	limiter, err := NewRateLimiter(1, nil)
+	if err != nil {
+		t.Fatalf("building the rate limiter: %+v", err)
+	}
+
+	uri := "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Network/example"
+	waitForSlot := func() {
+		waitCtx, waitCancel := context.WithTimeout(context.Background(), time.Second)
+		defer waitCancel()
+		next, err := http.NewRequestWithContext(waitCtx, http.MethodGet, uri, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		release, err := limiter.Wait(next)
+		if err != nil {
+			t.Fatalf("expected the slot to have been released but got: %+v", err)
+		}
+		release()
+	}
+
+	// a completed attempt must stop the fallback registered on the context
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	attempts := &rateLimitedAttempts{limiter: limiter, req: req}
+	attempts.attemptStarted()
+	if attempts.stopReleaseOnDone == nil {
+		t.Fatalf("expected a fallback to be registered whilst the slot is held")
+	}
+	attempts.attemptCompleted()
+	if attempts.stopReleaseOnDone != nil {
+		t.Fatalf("expected the fallback to be stopped once the attempt completed")
+	}
+	waitForSlot()
+
+	// an attempt which doesn't complete must release the slot once the context is cancelled
+	cancelledCtx, cancelAttempt := context.WithCancel(context.Background())
+	req, err = http.NewRequestWithContext(cancelledCtx, http.MethodGet, uri, nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	attempts = &rateLimitedAttempts{limiter: limiter, req: req}
+	attempts.attemptStarted()
+	cancelAttempt()
+	waitForSlot()
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const headerRequestID = "x-ms-request-id"
+
+// longRunningOperationPathSegments are the path segments used by the Azure-AsyncOperation and Location URIs
+// returned by Resource Manager when polling a Long Running Operation.
+var longRunningOperationPathSegments = []string{
+	"/asyncoperations/",
+	"/operationresults/",
+	"/operations/",
+	"/operationstatuses/",
+	"/operationsstatus/",
+}
+
+type tracingSpanKey struct{}
+
+type requestSpan struct {
+	span trace.Span
+	stop func() bool
+}
+
+// tracingRequestMiddleware starts a span for each HTTP Request as a child of the span for the current operation,
+// the span is ended by tracingResponseMiddleware.
+func tracingRequestMiddleware(redactor *Redactor) client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		ctx, span := startRequestSpan(request, redactor)
+
+		// the Response Middlewares aren't called when no response is received, so in that case the span
+		// is ended when the context for the Request is cancelled
+		stop := context.AfterFunc(ctx, func() {
+			tracing.EndSpan(span, errors.New("no response was received"))
+		})
+
+		ctx = context.WithValue(ctx, tracingSpanKey{}, &requestSpan{
+			span: span,
+			stop: stop,
+		})
+		return request.WithContext(ctx), nil
+	}
+}
+
+func tracingResponseMiddleware() client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		v, ok := request.Context().Value(tracingSpanKey{}).(*requestSpan)
+		if !ok || !v.stop() {
+			return response, nil
+		}
+
+		endRequestSpan(v.span, response, nil)
+		return response, nil
+	}
+}
+
+// tracingSender returns a Sender for go-autorest Clients which starts a span for each HTTP Request.
+func tracingSender(redactor *Redactor, sender autorest.Sender) autorest.Sender {
+	return autorest.SenderFunc(func(request *http.Request) (*http.Response, error) {
+		ctx, span := startRequestSpan(request, redactor)
+
+		response, err := sender.Do(request.WithContext(ctx))
+		endRequestSpan(span, response, err)
+		return response, err
+	})
+}
+
+func startRequestSpan(request *http.Request, redactor *Redactor) (context.Context, trace.Span) {
+	poll := isLongRunningOperationPoll(request)
+
+	attributes := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(request.Method),
+		semconv.ServerAddress(request.URL.Hostname()),
+		semconv.URLFull(redactor.RedactURL(request.URL).String()),
+		tracing.AttributeLongRunningPoll.Bool(poll),
+	}
+	if id := request.Header.Get(HeaderCorrelationRequestID); id != "" {
+		attributes = append(attributes, tracing.AttributeCorrelationRequestID.String(id))
+	}
+
+	name := fmt.Sprintf("HTTP %s", request.Method)
+	if poll {
+		name = "Poll Long Running Operation"
+	}
+
+	return tracing.StartSpan(request.Context(), name, attributes...)
+}
+
+func endRequestSpan(span trace.Span, response *http.Response, err error) {
+	if response != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(response.StatusCode))
+		if id := response.Header.Get(headerRequestID); id != "" {
+			span.SetAttributes(tracing.AttributeRequestID.String(id))
+		}
+		if err == nil && response.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, response.Status)
+		}
+	}
+
+	tracing.EndSpan(span, err)
+}
+
+// isLongRunningOperationPoll determines whether the HTTP Request is polling the status of a Long Running Operation.
+//
+// Pollers which check the `provisioningState` of the Resource itself can't be distinguished from a Read and so
+// are traced as a regular HTTP Request.
+func isLongRunningOperationPoll(request *http.Request) bool {
+	if request.Method != http.MethodGet || request.URL == nil {
+		return false
+	}
+
+	path := strings.ToLower(request.URL.Path)
+	for _, segment := range longRunningOperationPathSegments {
+		if strings.Contains(path, segment) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestIsLongRunningOperationPoll(t *testing.T) {
+	cases := []struct {
+		Method   string
+		Url      string
+		Expected bool
+	}{
+		{
+			Method:   http.MethodGet,
+			Url:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Compute/locations/westeurope/operations/11111111-1111-1111-1111-111111111111?api-version=2024-03-01",
+			Expected: true,
+		},
+		{
+			Method:   http.MethodGet,
+			Url:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Network/locations/westeurope/operationResults/11111111-1111-1111-1111-111111111111?api-version=2023-09-01",
+			Expected: true,
+		},
+		{
+			Method:   http.MethodGet,
+			Url:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Resources/asyncOperations/11111111?api-version=2022-09-01",
+			Expected: true,
+		},
+		{
+			Method:   http.MethodGet,
+			Url:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example?api-version=2022-09-01",
+			Expected: false,
+		},
+		{
+			Method:   http.MethodDelete,
+			Url:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Compute/locations/westeurope/operations/11111111?api-version=2024-03-01",
+			Expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %s %q", tc.Method, tc.Url)
+
+		request, err := http.NewRequest(tc.Method, tc.Url, nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+
+		if actual := isLongRunningOperationPoll(request); actual != tc.Expected {
+			t.Fatalf("expected %t but got %t", tc.Expected, actual)
+		}
+	}
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	exporter := testTracerProvider(t)
+
+	ctx, parent := tracing.StartSpan(context.Background(), "parent")
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Compute/locations/westeurope/operations/11111111?api-version=2024-03-01", nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	request.Header.Set(HeaderCorrelationRequestID, "22222222-2222-2222-2222-222222222222")
+
+	request, err = tracingRequestMiddleware(defaultRedactor)(request)
+	if err != nil {
+		t.Fatalf("running the request middleware: %+v", err)
+	}
+
+	response := &http.Response{
+		Status:     "200 OK",
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"X-Ms-Request-Id": []string{"33333333-3333-3333-3333-333333333333"},
+		},
+	}
+	if _, err := tracingResponseMiddleware()(request, response); err != nil {
+		t.Fatalf("running the response middleware: %+v", err)
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans but got %d", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name != "Poll Long Running Operation" {
+		t.Fatalf("expected the span to be named %q but got %q", "Poll Long Running Operation", span.Name)
+	}
+	if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("expected the span to be a child of the parent span")
+	}
+	if span.Status.Code == codes.Error {
+		t.Fatalf("expected the span not to have an error status")
+	}
+
+	expected := map[attribute.Key]string{
+		tracing.AttributeCorrelationRequestID: "22222222-2222-2222-2222-222222222222",
+		tracing.AttributeRequestID:            "33333333-3333-3333-3333-333333333333",
+		tracing.AttributeLongRunningPoll:      "true",
+	}
+	actual := make(map[attribute.Key]string)
+	for _, v := range span.Attributes {
+		actual[v.Key] = v.Value.Emit()
+	}
+	for k, v := range expected {
+		if actual[k] != v {
+			t.Fatalf("expected the attribute %q to be %q but got %q", k, v, actual[k])
+		}
+	}
+}
+
+func TestTracingMiddlewareNoResponse(t *testing.T) {
+	exporter := testTracerProvider(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example?api-version=2022-09-01", nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	if _, err := tracingRequestMiddleware(defaultRedactor)(request); err != nil {
+		t.Fatalf("running the request middleware: %+v", err)
+	}
+	cancel()
+
+	// the span is ended asynchronously once the context is cancelled
+	for i := 0; i < 50 && len(exporter.GetSpans()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span but got %d", len(spans))
+	}
+	if spans[0].Name != "HTTP PUT" {
+		t.Fatalf("expected the span to be named %q but got %q", "HTTP PUT", spans[0].Name)
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Fatalf("expected the span to have an error status")
+	}
+}
+
+func testTracerProvider(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	existing := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(existing)
+	})
+
+	return exporter
+}
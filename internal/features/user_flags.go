@@ -3,6 +3,8 @@
 
 package features
 
+import "time"
+
 type UserFeatures struct {
 	ApiManagement            ApiManagementFeatures
 	AppConfiguration         AppConfigurationFeatures
@@ -19,6 +21,7 @@ type UserFeatures struct {
 	PostgresqlFlexibleServer PostgresqlFlexibleServerFeatures
 	MachineLearning          MachineLearningFeatures
 	RecoveryService          RecoveryServiceFeatures
+	NetworkSecurityGroup     NetworkSecurityGroupFeatures
 }
 
 type CognitiveAccountFeatures struct {
@@ -33,11 +36,23 @@ type VirtualMachineFeatures struct {
 
 type VirtualMachineScaleSetFeatures struct {
 	ForceDelete               bool
+	ManualUpgrade             *VirtualMachineScaleSetManualUpgradeFeatures
 	ReimageOnManualUpgrade    bool
 	RollInstancesWhenRequired bool
 	ScaleToZeroOnDelete       bool
 }
 
+// VirtualMachineScaleSetManualUpgradeFeatures configures how the instances in a Scale Set with a `Manual` Upgrade Policy
+// are rolled - when omitted each instance is rolled one at a time
+type VirtualMachineScaleSetManualUpgradeFeatures struct {
+	HealthCheckEnabled          bool
+	HealthCheckTimeout          time.Duration
+	MaxBatchInstanceCount       int
+	MaxBatchInstancePercent     int
+	MaxUnhealthyInstancePercent int
+	PauseBetweenBatches         time.Duration
+}
+
 type KeyVaultFeatures struct {
 	PurgeSoftDeleteOnDestroy         bool
 	PurgeSoftDeletedKeysOnDestroy    bool
@@ -96,3 +111,7 @@ type RecoveryServiceFeatures struct {
 	VMBackupStopProtectionAndRetainDataOnDestroy bool
 	PurgeProtectedItemsFromVaultOnDestroy        bool
 }
+
+type NetworkSecurityGroupFeatures struct {
+	ErrorOnConflictingRules bool
+}
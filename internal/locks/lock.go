@@ -3,25 +3,43 @@
 
 package locks
 
+import (
+	"context"
+	"sort"
+)
+
 // armMutexKV is the instance of MutexKV for ARM resources
 var armMutexKV = newMutexKV()
 
-func ByID(id string) {
-	armMutexKV.Lock(id)
+// ByID locks the specified ID, returning an error if the lock can't be acquired before the
+// context is cancelled or its deadline is exceeded
+func ByID(ctx context.Context, id string) error {
+	return armMutexKV.Lock(ctx, id)
 }
 
-// handle the case of using the same name for different kinds of resources
-func ByName(name string, resourceType string) {
+// ByName locks the specified name for this resource type (to handle the case of using the same name
+// for different kinds of resources), returning an error if the lock can't be acquired before the
+// context is cancelled or its deadline is exceeded
+func ByName(ctx context.Context, name string, resourceType string) error {
 	updatedName := resourceType + "." + name
-	armMutexKV.Lock(updatedName)
+	return armMutexKV.Lock(ctx, updatedName)
 }
 
-func MultipleByName(names *[]string, resourceType string) {
-	newSlice := removeDuplicatesFromStringArray(*names)
-
-	for _, name := range newSlice {
-		ByName(name, resourceType)
+// MultipleByName locks each of the specified names for this resource type, the names are locked in a
+// consistent order to avoid deadlocking with other callers locking an overlapping set of names. An error
+// is returned (and any names already locked are unlocked) if the locks can't be acquired before the
+// context is cancelled or its deadline is exceeded
+func MultipleByName(ctx context.Context, names *[]string, resourceType string) error {
+	locked := make([]string, 0)
+	for _, name := range sortedNames(names) {
+		if err := ByName(ctx, name, resourceType); err != nil {
+			UnlockMultipleByName(&locked, resourceType)
+			return err
+		}
+		locked = append(locked, name)
 	}
+
+	return nil
 }
 
 func UnlockByID(id string) {
@@ -34,9 +52,16 @@ func UnlockByName(name string, resourceType string) {
 }
 
 func UnlockMultipleByName(names *[]string, resourceType string) {
-	newSlice := removeDuplicatesFromStringArray(*names)
+	newSlice := sortedNames(names)
 
-	for _, name := range newSlice {
-		UnlockByName(name, resourceType)
+	// unlock in the reverse order to which the names were locked
+	for i := len(newSlice) - 1; i >= 0; i-- {
+		UnlockByName(newSlice[i], resourceType)
 	}
 }
+
+func sortedNames(names *[]string) []string {
+	newSlice := removeDuplicatesFromStringArray(*names)
+	sort.Strings(newSlice)
+	return newSlice
+}
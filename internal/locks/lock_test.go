@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestByNameReportsTheHolder(t *testing.T) {
+	if err := ByName(context.Background(), "held", "testResource"); err != nil {
+		t.Fatalf("acquiring lock: %+v", err)
+	}
+	defer UnlockByName("held", "testResource")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := ByName(ctx, "held", "testResource")
+	if err == nil {
+		t.Fatalf("expected an error acquiring a held lock but didn't get one")
+	}
+
+	for _, expected := range []string{`"testResource.held"`, "TestByNameReportsTheHolder", context.DeadlineExceeded.Error()} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("expected the error %q to contain %q", err.Error(), expected)
+		}
+	}
+}
+
+func TestMultipleByNameUnlocksOnFailure(t *testing.T) {
+	if err := ByName(context.Background(), "second", "testResource"); err != nil {
+		t.Fatalf("acquiring lock: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := MultipleByName(ctx, &[]string{"third", "second", "first"}, "testResource"); err == nil {
+		t.Fatalf("expected an error acquiring a held lock but didn't get one")
+	}
+
+	// "first" is locked prior to "second", so should have been unlocked
+	ctx2, cancel2 := context.WithTimeout(context.Background(), time.Second)
+	defer cancel2()
+	if err := ByName(ctx2, "first", "testResource"); err != nil {
+		t.Fatalf("expected %q to have been unlocked: %+v", "first", err)
+	}
+	UnlockByName("first", "testResource")
+	UnlockByName("second", "testResource")
+}
+
+func TestMultipleByNameLocksInAConsistentOrder(t *testing.T) {
+	inputs := [][]string{
+		{"alpha", "bravo", "charlie"},
+		{"charlie", "bravo", "alpha"},
+		{"bravo", "charlie", "alpha", "bravo"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	wg := sync.WaitGroup{}
+	errs := make(chan error, len(inputs)*50)
+	for _, input := range inputs {
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(names []string) {
+				defer wg.Done()
+				if err := MultipleByName(ctx, &names, "testOrderedResource"); err != nil {
+					errs <- err
+					return
+				}
+				UnlockMultipleByName(&names, "testOrderedResource")
+			}(append([]string{}, input...))
+		}
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("acquiring locks: %+v", err)
+	}
+}
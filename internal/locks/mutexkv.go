@@ -4,48 +4,137 @@
 package locks
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"runtime"
+	"strings"
 	"sync"
+	"time"
 )
 
+// defaultWaitWarningThreshold is how long to wait for a lock before logging which caller holds it
+const defaultWaitWarningThreshold = time.Minute
+
 // mutexKV is a simple key/value store for arbitrary mutexes. It can be used to
 // serialize changes across arbitrary collaborators that share knowledge of the
 // keys they must serialize on.
 type mutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]*keyedMutex
+
+	// waitWarningThreshold is the interval at which a warning is logged whilst waiting to acquire a lock
+	waitWarningThreshold time.Duration
 }
 
-// Locks the mutex for the given key. Caller is responsible for calling Unlock
-// for the same key
-func (m *mutexKV) Lock(key string) {
+// keyedMutex is a mutex which can be acquired using a context, and which tracks the caller holding it
+type keyedMutex struct {
+	semaphore chan struct{}
+
+	lock      sync.Mutex
+	holder    string
+	heldSince time.Time
+}
+
+// Lock locks the mutex for the given key, returning an error if the context is cancelled
+// or its deadline is exceeded before the lock is acquired. Caller is responsible for calling Unlock
+// for the same key when this doesn't return an error.
+func (m *mutexKV) Lock(ctx context.Context, key string) error {
 	log.Printf("[DEBUG] Locking %q", key)
-	m.get(key).Lock()
-	log.Printf("[DEBUG] Locked %q", key)
+	mutex := m.get(key)
+	caller := callerOutsideOfLocks()
+	start := time.Now()
+
+	ticker := time.NewTicker(m.waitWarningThreshold)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case mutex.semaphore <- struct{}{}:
+			mutex.acquiredBy(caller)
+			log.Printf("[DEBUG] Locked %q", key)
+			return nil
+
+		case <-ticker.C:
+			holder, heldFor := mutex.heldBy()
+			log.Printf("[WARN] %s has been waiting %s to acquire the lock %q, which is held by %s (for %s)", caller, roundDuration(time.Since(start)), key, holder, roundDuration(heldFor))
+
+		case <-ctx.Done():
+			holder, heldFor := mutex.heldBy()
+			return fmt.Errorf("waited %s to acquire the lock %q, which is held by %s (for %s): %+v", roundDuration(time.Since(start)), key, holder, roundDuration(heldFor), ctx.Err())
+		}
+	}
 }
 
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first
 func (m *mutexKV) Unlock(key string) {
 	log.Printf("[DEBUG] Unlocking %q", key)
-	m.get(key).Unlock()
+	mutex := m.get(key)
+	mutex.acquiredBy("")
+	select {
+	case <-mutex.semaphore:
+	default:
+		panic(fmt.Sprintf("unlocking %q which isn't locked", key))
+	}
 	log.Printf("[DEBUG] Unlocked %q", key)
 }
 
 // Returns a mutex for the given key, no guarantee of its lock status
-func (m *mutexKV) get(key string) *sync.Mutex {
+func (m *mutexKV) get(key string) *keyedMutex {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	mutex, ok := m.store[key]
 	if !ok {
-		mutex = &sync.Mutex{}
+		mutex = &keyedMutex{
+			semaphore: make(chan struct{}, 1),
+		}
 		m.store[key] = mutex
 	}
 	return mutex
 }
 
+func (m *keyedMutex) acquiredBy(holder string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.holder = holder
+	m.heldSince = time.Now()
+}
+
+func (m *keyedMutex) heldBy() (string, time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.holder == "" {
+		return "an unknown caller", 0
+	}
+	return m.holder, time.Since(m.heldSince)
+}
+
+// callerOutsideOfLocks returns the name of the first function in the call stack outside of this package,
+// which is used to identify which Resource is holding (or waiting for) a lock
+func callerOutsideOfLocks() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.File, "/internal/locks/") || strings.HasSuffix(frame.File, "_test.go") {
+			return strings.TrimPrefix(frame.Function, "github.com/hashicorp/terraform-provider-azurerm/")
+		}
+		if !more {
+			break
+		}
+	}
+	return "an unknown caller"
+}
+
+func roundDuration(input time.Duration) time.Duration {
+	return input.Round(time.Second)
+}
+
 // newMutexKV returns a properly initialized mutexKV
 func newMutexKV() *mutexKV {
 	return &mutexKV{
-		store: make(map[string]*sync.Mutex),
+		store:                make(map[string]*keyedMutex),
+		waitWarningThreshold: defaultWaitWarningThreshold,
 	}
 }
@@ -5,10 +5,12 @@ package provider
 
 import (
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 )
 
 func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
@@ -226,6 +228,50 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 						Optional: true,
 						Default:  false,
 					},
+					//lintignore:XS003
+					"manual_upgrade": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"health_check_enabled": {
+									Type:     pluginsdk.TypeBool,
+									Optional: true,
+									Default:  true,
+								},
+								"health_check_timeout_in_minutes": {
+									Type:         pluginsdk.TypeInt,
+									Optional:     true,
+									Default:      10,
+									ValidateFunc: validation.IntAtLeast(1),
+								},
+								"max_batch_instance_count": {
+									Type:         pluginsdk.TypeInt,
+									Optional:     true,
+									Default:      1,
+									ValidateFunc: validation.IntAtLeast(1),
+								},
+								"max_batch_instance_percent": {
+									Type:         pluginsdk.TypeInt,
+									Optional:     true,
+									ValidateFunc: validation.IntBetween(1, 100),
+								},
+								"max_unhealthy_instance_percent": {
+									Type:         pluginsdk.TypeInt,
+									Optional:     true,
+									Default:      20,
+									ValidateFunc: validation.IntBetween(0, 100),
+								},
+								"pause_between_batches_in_seconds": {
+									Type:         pluginsdk.TypeInt,
+									Optional:     true,
+									Default:      0,
+									ValidateFunc: validation.IntAtLeast(0),
+								},
+							},
+						},
+					},
 					"reimage_on_manual_upgrade": {
 						Type:     pluginsdk.TypeBool,
 						Optional: true,
@@ -338,6 +384,21 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 				},
 			},
 		},
+
+		"network_security_group": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"error_on_conflicting_rules": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
 	}
 
 	// this is a temporary hack to enable us to gradually add provider blocks to test configurations
@@ -502,6 +563,20 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 			if v, ok := scaleSetRaw["force_delete"]; ok {
 				featuresMap.VirtualMachineScaleSet.ForceDelete = v.(bool)
 			}
+			if v, ok := scaleSetRaw["manual_upgrade"]; ok {
+				manualUpgradeItems := v.([]interface{})
+				if len(manualUpgradeItems) > 0 && manualUpgradeItems[0] != nil {
+					manualUpgradeRaw := manualUpgradeItems[0].(map[string]interface{})
+					featuresMap.VirtualMachineScaleSet.ManualUpgrade = &features.VirtualMachineScaleSetManualUpgradeFeatures{
+						HealthCheckEnabled:          manualUpgradeRaw["health_check_enabled"].(bool),
+						HealthCheckTimeout:          time.Duration(manualUpgradeRaw["health_check_timeout_in_minutes"].(int)) * time.Minute,
+						MaxBatchInstanceCount:       manualUpgradeRaw["max_batch_instance_count"].(int),
+						MaxBatchInstancePercent:     manualUpgradeRaw["max_batch_instance_percent"].(int),
+						MaxUnhealthyInstancePercent: manualUpgradeRaw["max_unhealthy_instance_percent"].(int),
+						PauseBetweenBatches:         time.Duration(manualUpgradeRaw["pause_between_batches_in_seconds"].(int)) * time.Second,
+					}
+				}
+			}
 			if v, ok := scaleSetRaw["scale_to_zero_before_deletion"]; ok {
 				featuresMap.VirtualMachineScaleSet.ScaleToZeroOnDelete = v.(bool)
 			}
@@ -571,5 +646,15 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["network_security_group"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 {
+			networkSecurityGroupRaw := items[0].(map[string]interface{})
+			if v, ok := networkSecurityGroupRaw["error_on_conflicting_rules"]; ok {
+				featuresMap.NetworkSecurityGroup.ErrorOnConflictingRules = v.(bool)
+			}
+		}
+	}
+
 	return featuresMap
 }
@@ -6,6 +6,7 @@ package provider
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
@@ -82,6 +83,9 @@ func TestExpandFeatures(t *testing.T) {
 					VMBackupStopProtectionAndRetainDataOnDestroy: false,
 					PurgeProtectedItemsFromVaultOnDestroy:        false,
 				},
+				NetworkSecurityGroup: features.NetworkSecurityGroupFeatures{
+					ErrorOnConflictingRules: false,
+				},
 			},
 		},
 		{
@@ -179,6 +183,11 @@ func TestExpandFeatures(t *testing.T) {
 							"purge_protected_items_from_vault_on_destroy":          true,
 						},
 					},
+					"network_security_group": []interface{}{
+						map[string]interface{}{
+							"error_on_conflicting_rules": true,
+						},
+					},
 				},
 			},
 			Expected: features.UserFeatures{
@@ -243,6 +252,9 @@ func TestExpandFeatures(t *testing.T) {
 					VMBackupStopProtectionAndRetainDataOnDestroy: true,
 					PurgeProtectedItemsFromVaultOnDestroy:        true,
 				},
+				NetworkSecurityGroup: features.NetworkSecurityGroupFeatures{
+					ErrorOnConflictingRules: true,
+				},
 			},
 		},
 		{
@@ -340,6 +352,11 @@ func TestExpandFeatures(t *testing.T) {
 							"purge_protected_items_from_vault_on_destroy":          false,
 						},
 					},
+					"network_security_group": []interface{}{
+						map[string]interface{}{
+							"error_on_conflicting_rules": false,
+						},
+					},
 				},
 			},
 			Expected: features.UserFeatures{
@@ -404,6 +421,9 @@ func TestExpandFeatures(t *testing.T) {
 					VMBackupStopProtectionAndRetainDataOnDestroy: false,
 					PurgeProtectedItemsFromVaultOnDestroy:        false,
 				},
+				NetworkSecurityGroup: features.NetworkSecurityGroupFeatures{
+					ErrorOnConflictingRules: false,
+				},
 			},
 		},
 	}
@@ -1061,6 +1081,45 @@ func TestExpandFeaturesVirtualMachineScaleSet(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Manual Upgrade in Batches",
+			Input: []interface{}{
+				map[string]interface{}{
+					"virtual_machine_scale_set": []interface{}{
+						map[string]interface{}{
+							"force_delete": false,
+							"manual_upgrade": []interface{}{
+								map[string]interface{}{
+									"health_check_enabled":             true,
+									"health_check_timeout_in_minutes":  15,
+									"max_batch_instance_count":         1,
+									"max_batch_instance_percent":       20,
+									"max_unhealthy_instance_percent":   10,
+									"pause_between_batches_in_seconds": 30,
+								},
+							},
+							"roll_instances_when_required": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				VirtualMachineScaleSet: features.VirtualMachineScaleSetFeatures{
+					ForceDelete: false,
+					ManualUpgrade: &features.VirtualMachineScaleSetManualUpgradeFeatures{
+						HealthCheckEnabled:          true,
+						HealthCheckTimeout:          15 * time.Minute,
+						MaxBatchInstanceCount:       1,
+						MaxBatchInstancePercent:     20,
+						MaxUnhealthyInstancePercent: 10,
+						PauseBetweenBatches:         30 * time.Second,
+					},
+					ReimageOnManualUpgrade:    true,
+					RollInstancesWhenRequired: true,
+					ScaleToZeroOnDelete:       true,
+				},
+			},
+		},
 		{
 			Name: "All Fields Disabled",
 			Input: []interface{}{
@@ -1536,3 +1595,68 @@ func TestExpandFeaturesRecoveryService(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandFeaturesNetworkSecurityGroup(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"network_security_group": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				NetworkSecurityGroup: features.NetworkSecurityGroupFeatures{
+					ErrorOnConflictingRules: false,
+				},
+			},
+		},
+		{
+			Name: "Error On Conflicting Rules Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"network_security_group": []interface{}{
+						map[string]interface{}{
+							"error_on_conflicting_rules": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				NetworkSecurityGroup: features.NetworkSecurityGroupFeatures{
+					ErrorOnConflictingRules: true,
+				},
+			},
+		},
+		{
+			Name: "Error On Conflicting Rules Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"network_security_group": []interface{}{
+						map[string]interface{}{
+							"error_on_conflicting_rules": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				NetworkSecurityGroup: features.NetworkSecurityGroupFeatures{
+					ErrorOnConflictingRules: false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.NetworkSecurityGroup, testCase.Expected.NetworkSecurityGroup) {
+			t.Fatalf("Expected %+v but got %+v", result.NetworkSecurityGroup, testCase.Expected.NetworkSecurityGroup)
+		}
+	}
+}
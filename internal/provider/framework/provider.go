@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	frameworkprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	pluginsdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
+)
+
+// ProtoV5ProviderServerFactory returns a Provider Server which muxes the Plugin SDKv2 Provider
+// together with the Plugin Framework Provider, such that both share the same Client.
+func ProtoV5ProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return protoV5ProviderServerFactory(ctx, provider.AzureProvider())
+}
+
+// TestProtoV5ProviderServerFactory is the equivalent of ProtoV5ProviderServerFactory for use
+// in the Acceptance Tests.
+func TestProtoV5ProviderServerFactory(ctx context.Context) (func() tfprotov5.ProviderServer, error) {
+	return protoV5ProviderServerFactory(ctx, provider.TestAzureProvider())
+}
+
+// TestProtoV5ProviderServerFactoryWithCassette is the equivalent of TestProtoV5ProviderServerFactory which
+// records the HTTP Interactions made by the Provider into (or replays them from) the specified Cassette.
+func TestProtoV5ProviderServerFactoryWithCassette(ctx context.Context, cassette common.CassetteRecorder) (func() tfprotov5.ProviderServer, error) {
+	return protoV5ProviderServerFactory(ctx, provider.TestAzureProviderWithCassette(cassette))
+}
+
+func protoV5ProviderServerFactory(ctx context.Context, v2Provider *pluginsdkschema.Provider) (func() tfprotov5.ProviderServer, error) {
+	providers := []func() tfprotov5.ProviderServer{
+		// NOTE: the Plugin SDKv2 Provider must be first, since the mux server configures each
+		// provider in order and the Plugin Framework Provider re-uses the Client it builds
+		v2Provider.GRPCProvider,
+		providerserver.NewProtocol5(NewFrameworkProvider(v2Provider)),
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		return nil, fmt.Errorf("building the mux server: %+v", err)
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+var _ frameworkprovider.ProviderWithFunctions = &azureRmFrameworkProvider{}
+
+type azureRmFrameworkProvider struct {
+	v2Provider *pluginsdkschema.Provider
+
+	schemaLock sync.Mutex
+	schema     *schema.Schema
+}
+
+// NewFrameworkProvider returns the Plugin Framework Provider, which exposes the Data Sources
+// and Resources defined in the Framework Service Registrations.
+func NewFrameworkProvider(v2Provider *pluginsdkschema.Provider) frameworkprovider.Provider {
+	return &azureRmFrameworkProvider{
+		v2Provider: v2Provider,
+	}
+}
+
+func (p *azureRmFrameworkProvider) Metadata(_ context.Context, _ frameworkprovider.MetadataRequest, resp *frameworkprovider.MetadataResponse) {
+	resp.TypeName = "azurerm"
+}
+
+func (p *azureRmFrameworkProvider) Schema(ctx context.Context, _ frameworkprovider.SchemaRequest, resp *frameworkprovider.SchemaResponse) {
+	p.schemaLock.Lock()
+	defer p.schemaLock.Unlock()
+
+	if p.schema == nil {
+		s, err := providerSchemaFromPluginSdk(ctx, p.v2Provider)
+		if err != nil {
+			resp.Diagnostics.AddError("Building the Provider Schema", err.Error())
+			return
+		}
+		p.schema = s
+	}
+
+	resp.Schema = *p.schema
+}
+
+func (p *azureRmFrameworkProvider) Configure(_ context.Context, _ frameworkprovider.ConfigureRequest, resp *frameworkprovider.ConfigureResponse) {
+	// the Plugin SDKv2 Provider has already been configured by the mux server at this point, so
+	// rather than authenticating a second time the Client it's built is shared
+	client, ok := p.v2Provider.Meta().(*clients.Client)
+	if !ok || client == nil {
+		resp.Diagnostics.AddError("Configuring the Provider", "the Plugin SDKv2 Provider hasn't been configured")
+		return
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+func (p *azureRmFrameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	output := make([]func() datasource.DataSource, 0)
+	for _, service := range provider.SupportedFrameworkServices() {
+		output = append(output, service.FrameworkDataSources()...)
+	}
+	return output
+}
+
+func (p *azureRmFrameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	output := make([]func() resource.Resource, 0)
+	for _, service := range provider.SupportedFrameworkServices() {
+		output = append(output, service.FrameworkResources()...)
+	}
+	return output
+}
+
+func (p *azureRmFrameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		providerfunction.NewBuildCloudInitMultipartFunction,
+		providerfunction.NewEvaluatePolicyRuleFunction,
+		providerfunction.NewNormaliseResourceIdFunction,
+		providerfunction.NewParseResourceIdFunction,
+		providerfunction.NewResourceGroupFromIdFunction,
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+func TestProviderSchemasAreIdentical(t *testing.T) {
+	// the mux server returns an error diagnostic when the Provider Schema exposed by the
+	// Plugin SDKv2 Provider and the Plugin Framework Provider differ
+	factories := map[string]func(context.Context) (func() tfprotov5.ProviderServer, error){
+		"production": ProtoV5ProviderServerFactory,
+		"test":       TestProtoV5ProviderServerFactory,
+	}
+	for name, factory := range factories {
+		t.Logf("[DEBUG] Testing the %q Provider Server..", name)
+		ctx := context.TODO()
+		providerServer, err := factory(ctx)
+		if err != nil {
+			t.Fatalf("building the %q Provider Server: %+v", name, err)
+		}
+
+		resp, err := providerServer().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			t.Fatalf("retrieving the Provider Schema for the %q Provider Server: %+v", name, err)
+		}
+
+		for _, d := range resp.Diagnostics {
+			if d.Severity == tfprotov5.DiagnosticSeverityError {
+				t.Fatalf("retrieving the Provider Schema for the %q Provider Server: %s: %s", name, d.Summary, d.Detail)
+			}
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	pluginsdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerSchemaFromPluginSdk builds the Plugin Framework Provider Schema from the Plugin SDKv2 Provider.
+//
+// The mux server requires that the Provider block is identical across all of the underlying providers, as
+// such rather than maintaining two copies of the (large) Provider Schema we cross-compile the Schema
+// that the Plugin SDKv2 Provider exposes over the wire into it's Plugin Framework equivalent.
+func providerSchemaFromPluginSdk(ctx context.Context, v2Provider *pluginsdkschema.Provider) (*schema.Schema, error) {
+	resp, err := v2Provider.GRPCProvider().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the Plugin SDKv2 Provider Schema: %+v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d != nil && d.Severity == tfprotov5.DiagnosticSeverityError {
+			return nil, fmt.Errorf("retrieving the Plugin SDKv2 Provider Schema: %s: %s", d.Summary, d.Detail)
+		}
+	}
+	if resp.Provider == nil || resp.Provider.Block == nil {
+		return nil, fmt.Errorf("the Plugin SDKv2 Provider returned an empty Provider Schema")
+	}
+
+	attributes, blocks, err := expandBlockContents(resp.Provider.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.Schema{
+		Attributes:          attributes,
+		Blocks:              blocks,
+		Description:         descriptionOf(resp.Provider.Block.Description, resp.Provider.Block.DescriptionKind),
+		MarkdownDescription: markdownDescriptionOf(resp.Provider.Block.Description, resp.Provider.Block.DescriptionKind),
+		DeprecationMessage:  deprecationMessageOf(resp.Provider.Block.Deprecated),
+	}, nil
+}
+
+func expandBlockContents(input *tfprotov5.SchemaBlock) (map[string]schema.Attribute, map[string]schema.Block, error) {
+	attributes := make(map[string]schema.Attribute)
+	for _, v := range input.Attributes {
+		attribute, err := expandAttribute(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting attribute %q: %+v", v.Name, err)
+		}
+		attributes[v.Name] = attribute
+	}
+
+	blocks := make(map[string]schema.Block)
+	for _, v := range input.BlockTypes {
+		block, err := expandNestedBlock(v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("converting block %q: %+v", v.TypeName, err)
+		}
+		blocks[v.TypeName] = block
+	}
+
+	return attributes, blocks, nil
+}
+
+func expandAttribute(input *tfprotov5.SchemaAttribute) (schema.Attribute, error) {
+	description := descriptionOf(input.Description, input.DescriptionKind)
+	markdownDescription := markdownDescriptionOf(input.Description, input.DescriptionKind)
+	deprecationMessage := deprecationMessageOf(input.Deprecated)
+
+	switch {
+	case input.Type.Is(tftypes.String):
+		return schema.StringAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Bool):
+		return schema.BoolAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Number):
+		return schema.NumberAttribute{
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.List{}):
+		elementType, err := expandType(input.Type.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.ListAttribute{
+			ElementType:         elementType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Set{}):
+		elementType, err := expandType(input.Type.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.SetAttribute{
+			ElementType:         elementType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case input.Type.Is(tftypes.Map{}):
+		elementType, err := expandType(input.Type.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return schema.MapAttribute{
+			ElementType:         elementType,
+			Required:            input.Required,
+			Optional:            input.Optional,
+			Sensitive:           input.Sensitive,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", input.Type)
+}
+
+func expandNestedBlock(input *tfprotov5.SchemaNestedBlock) (schema.Block, error) {
+	if input.Block == nil {
+		return nil, fmt.Errorf("the nested block has no schema")
+	}
+
+	attributes, blocks, err := expandBlockContents(input.Block)
+	if err != nil {
+		return nil, err
+	}
+	nestedObject := schema.NestedBlockObject{
+		Attributes: attributes,
+		Blocks:     blocks,
+	}
+	description := descriptionOf(input.Block.Description, input.Block.DescriptionKind)
+	markdownDescription := markdownDescriptionOf(input.Block.Description, input.Block.DescriptionKind)
+	deprecationMessage := deprecationMessageOf(input.Block.Deprecated)
+
+	switch input.Nesting {
+	case tfprotov5.SchemaNestedBlockNestingModeList:
+		return schema.ListNestedBlock{
+			NestedObject:        nestedObject,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case tfprotov5.SchemaNestedBlockNestingModeSet:
+		return schema.SetNestedBlock{
+			NestedObject:        nestedObject,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+
+	case tfprotov5.SchemaNestedBlockNestingModeSingle:
+		return schema.SingleNestedBlock{
+			Attributes:          attributes,
+			Blocks:              blocks,
+			Description:         description,
+			MarkdownDescription: markdownDescription,
+			DeprecationMessage:  deprecationMessage,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported nesting mode %s", input.Nesting)
+}
+
+func expandType(input tftypes.Type) (attr.Type, error) {
+	switch {
+	case input.Is(tftypes.String):
+		return types.StringType, nil
+
+	case input.Is(tftypes.Bool):
+		return types.BoolType, nil
+
+	case input.Is(tftypes.Number):
+		return types.NumberType, nil
+
+	case input.Is(tftypes.List{}):
+		elementType, err := expandType(input.(tftypes.List).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.ListType{ElemType: elementType}, nil
+
+	case input.Is(tftypes.Set{}):
+		elementType, err := expandType(input.(tftypes.Set).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.SetType{ElemType: elementType}, nil
+
+	case input.Is(tftypes.Map{}):
+		elementType, err := expandType(input.(tftypes.Map).ElementType)
+		if err != nil {
+			return nil, err
+		}
+		return types.MapType{ElemType: elementType}, nil
+
+	case input.Is(tftypes.Object{}):
+		attributeTypes := make(map[string]attr.Type)
+		for k, v := range input.(tftypes.Object).AttributeTypes {
+			attributeType, err := expandType(v)
+			if err != nil {
+				return nil, err
+			}
+			attributeTypes[k] = attributeType
+		}
+		return types.ObjectType{AttrTypes: attributeTypes}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", input)
+}
+
+func descriptionOf(description string, kind tfprotov5.StringKind) string {
+	if kind == tfprotov5.StringKindMarkdown {
+		return ""
+	}
+	return description
+}
+
+func markdownDescriptionOf(description string, kind tfprotov5.StringKind) string {
+	if kind == tfprotov5.StringKindMarkdown {
+		return description
+	}
+	return ""
+}
+
+func deprecationMessageOf(deprecated bool) string {
+	// the wire format only exposes whether an attribute is deprecated, not the message itself
+	if deprecated {
+		return "Deprecated"
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/cloudinit"
+)
+
+var _ function.Function = BuildCloudInitMultipartFunction{}
+
+var buildCloudInitMultipartPartAttributeTypes = map[string]attr.Type{
+	"content_type": types.StringType,
+	"content":      types.StringType,
+}
+
+type buildCloudInitMultipartPart struct {
+	ContentType string `tfsdk:"content_type"`
+	Content     string `tfsdk:"content"`
+}
+
+type BuildCloudInitMultipartFunction struct{}
+
+func NewBuildCloudInitMultipartFunction() function.Function {
+	return &BuildCloudInitMultipartFunction{}
+}
+
+func (b BuildCloudInitMultipartFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_cloud_init_multipart"
+}
+
+func (b BuildCloudInitMultipartFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Builds a multipart cloud-init payload for use as the `custom_data` of a Virtual Machine",
+		Description:         "Composes cloud-config, shell script and boothook parts into a multipart MIME cloud-init payload, which is returned gzipped and base64 encoded for use as the `custom_data` of a Virtual Machine or Virtual Machine Scale Set.",
+		MarkdownDescription: "Composes cloud-config, shell script and boothook parts into a multipart MIME cloud-init payload, which is returned gzipped and base64 encoded for use as the `custom_data` of a Virtual Machine or Virtual Machine Scale Set.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "parts",
+				Description:         "A list of objects containing the `content_type` (either `text/cloud-config`, `text/x-shellscript` or `text/cloud-boothook`) and the `content` for each part.",
+				MarkdownDescription: "A list of objects containing the `content_type` (either `text/cloud-config`, `text/x-shellscript` or `text/cloud-boothook`) and the `content` for each part.",
+				ElementType: types.ObjectType{
+					AttrTypes: buildCloudInitMultipartPartAttributeTypes,
+				},
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (b BuildCloudInitMultipartFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input []buildCloudInitMultipartPart
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	parts := make([]cloudinit.Part, 0)
+	for _, v := range input {
+		parts = append(parts, cloudinit.Part{
+			ContentType: v.ContentType,
+			Content:     v.Content,
+		})
+	}
+
+	result, err := cloudinit.Build(parts)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, *result))
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
+)
+
+func TestBuildCloudInitMultipartFunction(t *testing.T) {
+	partType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"content_type": types.StringType,
+			"content":      types.StringType,
+		},
+	}
+
+	testData := []struct {
+		name  string
+		parts map[string]string
+		error bool
+	}{
+		{
+			name: "cloud-config",
+			parts: map[string]string{
+				"text/cloud-config": "#cloud-config\npackages:\n  - nginx\n",
+			},
+		},
+		{
+			name: "shell script",
+			parts: map[string]string{
+				"x-shellscript": "#!/bin/bash\necho hello\n",
+			},
+		},
+		{
+			name: "invalid cloud-config",
+			parts: map[string]string{
+				"text/cloud-config": "packages: [nginx\n",
+			},
+			error: true,
+		},
+		{
+			name:  "no parts",
+			parts: map[string]string{},
+			error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		parts := make([]attr.Value, 0)
+		for contentType, content := range v.parts {
+			parts = append(parts, types.ObjectValueMust(partType.AttrTypes, map[string]attr.Value{
+				"content_type": types.StringValue(contentType),
+				"content":      types.StringValue(content),
+			}))
+		}
+
+		resp := function.RunResponse{
+			Result: function.NewResultData(types.StringUnknown()),
+		}
+		providerfunction.NewBuildCloudInitMultipartFunction().Run(context.TODO(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.ListValueMust(partType, parts)}),
+		}, &resp)
+
+		if v.error {
+			if resp.Error == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if resp.Error != nil {
+			t.Fatalf("unexpected error: %+v", resp.Error)
+		}
+
+		result, ok := resp.Result.Value().(types.String)
+		if !ok {
+			t.Fatalf("expected the result to be a string but got %T", resp.Result.Value())
+		}
+		if _, err := base64.StdEncoding.DecodeString(result.ValueString()); err != nil {
+			t.Fatalf("expected the result to be base64 encoded: %+v", err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/policy/evaluate"
+)
+
+var _ function.Function = EvaluatePolicyRuleFunction{}
+
+var evaluatePolicyRuleReturnAttributeTypes = map[string]attr.Type{
+	"matched": types.BoolType,
+	"effect":  types.StringType,
+}
+
+type EvaluatePolicyRuleFunction struct{}
+
+func NewEvaluatePolicyRuleFunction() function.Function {
+	return &EvaluatePolicyRuleFunction{}
+}
+
+func (e EvaluatePolicyRuleFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "evaluate_policy_rule"
+}
+
+func (e EvaluatePolicyRuleFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Evaluates an Azure Policy Rule against the JSON representation of a Resource",
+		Description:         "Evaluates an Azure Policy Rule against the JSON representation of a Resource offline, returning whether the `if` condition matches the Resource and the resulting effect.",
+		MarkdownDescription: "Evaluates an Azure Policy Rule against the JSON representation of a Resource offline, returning whether the `if` condition matches the Resource and the resulting effect.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "policy_rule",
+				Description:         "The JSON representation of the Policy Rule.",
+				MarkdownDescription: "The JSON representation of the Policy Rule.",
+			},
+			function.StringParameter{
+				Name:                "resource",
+				Description:         "The JSON representation of the Resource, as returned by Azure Resource Manager.",
+				MarkdownDescription: "The JSON representation of the Resource, as returned by Azure Resource Manager.",
+			},
+			function.StringParameter{
+				Name:                "parameters",
+				Description:         "A JSON object containing the value for each parameter used within the Policy Rule.",
+				MarkdownDescription: "A JSON object containing the value for each parameter used within the Policy Rule.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: evaluatePolicyRuleReturnAttributeTypes,
+		},
+	}
+}
+
+func (e EvaluatePolicyRuleFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var policyRule, resource, parameters string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &policyRule, &resource, &parameters))
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := evaluate.PolicyRuleFromJSON(policyRule, resource, parameters)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	value, diags := types.ObjectValue(evaluatePolicyRuleReturnAttributeTypes, map[string]attr.Value{
+		"matched": types.BoolValue(result.Matched),
+		"effect":  types.StringValue(result.Effect),
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, value))
+}
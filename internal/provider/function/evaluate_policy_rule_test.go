@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
+)
+
+func TestEvaluatePolicyRuleFunction(t *testing.T) {
+	rule := `{"if": {"field": "location", "notIn": "[parameters('allowedLocations')]"}, "then": {"effect": "deny"}}`
+
+	testData := []struct {
+		resource   string
+		parameters string
+		matched    bool
+		error      bool
+	}{
+		{
+			resource:   `{"type": "Microsoft.Storage/storageAccounts", "location": "eastus"}`,
+			parameters: `{"allowedLocations": {"value": ["westeurope", "northeurope"]}}`,
+			matched:    true,
+		},
+		{
+			resource:   `{"type": "Microsoft.Storage/storageAccounts", "location": "westeurope"}`,
+			parameters: `{"allowedLocations": ["westeurope", "northeurope"]}`,
+			matched:    false,
+		},
+		{
+			// the parameter is required
+			resource:   `{"type": "Microsoft.Storage/storageAccounts", "location": "westeurope"}`,
+			parameters: "",
+			error:      true,
+		},
+		{
+			resource:   `not json`,
+			parameters: "",
+			error:      true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.resource)
+
+		resp := function.RunResponse{
+			Result: function.NewResultData(types.ObjectUnknown(map[string]attr.Type{
+				"matched": types.BoolType,
+				"effect":  types.StringType,
+			})),
+		}
+		providerfunction.NewEvaluatePolicyRuleFunction().Run(context.TODO(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{
+				types.StringValue(rule),
+				types.StringValue(v.resource),
+				types.StringValue(v.parameters),
+			}),
+		}, &resp)
+
+		if v.error {
+			if resp.Error == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if resp.Error != nil {
+			t.Fatalf("unexpected error: %+v", resp.Error)
+		}
+
+		attributes := resp.Result.Value().(types.Object).Attributes()
+		if actual := attributes["matched"].(types.Bool).ValueBool(); actual != v.matched {
+			t.Fatalf("expected `matched` to be %t but got %t", v.matched, actual)
+		}
+		if actual := attributes["effect"].(types.String).ValueString(); actual != "deny" {
+			t.Fatalf("expected `effect` to be %q but got %q", "deny", actual)
+		}
+	}
+}
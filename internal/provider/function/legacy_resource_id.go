@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+//go:generate go run ../../tools/generator-resource-id-registry/main.go -path=../../../ -output=./legacy_resource_ids_gen.go
+
+// legacyResourceId is a Resource ID type from one of the `internal/services/*/parse` packages, which (unlike the
+// Resource ID types within go-azure-sdk) aren't registered with the recaser
+type legacyResourceId interface {
+	ID() string
+}
+
+type legacyResourceIdParser struct {
+	// name is the name of the Service Package and Resource ID type, e.g. `network.Subnet`
+	name  string
+	parse func(input string) (legacyResourceId, error)
+}
+
+// parseLegacyResourceId matches the input against the Resource ID parsers within the `internal/services/*/parse`
+// packages, returning false when none of these match.
+func parseLegacyResourceId(input string) (*parsedResourceId, bool) {
+	for _, parser := range legacyResourceIdParsers {
+		id, err := parser.parse(input)
+		if err != nil {
+			continue
+		}
+
+		// these parsers only check the keys of the segments which they require, as such the formatted Resource ID
+		// must match the input to ensure that the Resource Provider (and any additional segments) match
+		normalisedId := id.ID()
+		if !strings.EqualFold(normalisedId, input) {
+			continue
+		}
+
+		return &parsedResourceId{
+			normalisedId: normalisedId,
+			segments:     segmentsForLegacyResourceId(id),
+		}, true
+	}
+
+	return nil, false
+}
+
+// segmentsForLegacyResourceId returns the values of the fields within the Resource ID type, using the same
+// names as the segments of the Resource ID types within go-azure-sdk (e.g. `resourceGroupName`)
+func segmentsForLegacyResourceId(id legacyResourceId) map[string]string {
+	output := make(map[string]string)
+
+	value := reflect.Indirect(reflect.ValueOf(id))
+	if value.Kind() != reflect.Struct {
+		return output
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() || field.Type.Kind() != reflect.String {
+			continue
+		}
+
+		name := field.Name
+		if name == "ResourceGroup" {
+			name = "ResourceGroupName"
+		}
+		runes := []rune(name)
+		runes[0] = unicode.ToLower(runes[0])
+
+		output[string(runes)] = value.Field(i).String()
+	}
+
+	return output
+}
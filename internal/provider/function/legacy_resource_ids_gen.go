@@ -0,0 +1,4150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+// NOTE: this file is generated via 'go:generate' - manual changes will be overwritten
+
+import (
+	apimanagementParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/apimanagement/parse"
+	appconfigurationParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/appconfiguration/parse"
+	applicationinsightsParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/applicationinsights/parse"
+	appserviceParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/appservice/parse"
+	authorizationParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/authorization/parse"
+	batchParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/batch/parse"
+	billingParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/billing/parse"
+	botParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/bot/parse"
+	cdnParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/cdn/parse"
+	computeParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/parse"
+	containerappsParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/containerapps/parse"
+	containersParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
+	cosmosParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/cosmos/parse"
+	costmanagementParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/costmanagement/parse"
+	datafactoryParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/datafactory/parse"
+	dataprotectionParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/dataprotection/parse"
+	desktopvirtualizationParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/desktopvirtualization/parse"
+	disksParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/disks/parse"
+	dnsParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/dns/parse"
+	domainservicesParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/domainservices/parse"
+	firewallParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/firewall/parse"
+	frontdoorParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/frontdoor/parse"
+	iotcentralParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/iotcentral/parse"
+	iothubParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/iothub/parse"
+	keyvaultParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
+	kustoParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/kusto/parse"
+	loadbalancerParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/loadbalancer/parse"
+	logicParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/logic/parse"
+	monitorParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/monitor/parse"
+	mssqlParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/mssql/parse"
+	mssqlmanagedinstanceParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/mssqlmanagedinstance/parse"
+	mysqlParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/mysql/parse"
+	networkParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/network/parse"
+	policyParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/policy/parse"
+	portalParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/portal/parse"
+	postgresParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/postgres/parse"
+	recoveryservicesParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/recoveryservices/parse"
+	resourceParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/parse"
+	securitycenterParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/securitycenter/parse"
+	sentinelParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/sentinel/parse"
+	springcloudParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/springcloud/parse"
+	sqlParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/sql/parse"
+	storageParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/parse"
+	storagecacheParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/storagecache/parse"
+	streamanalyticsParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/streamanalytics/parse"
+	subscriptionParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/subscription/parse"
+	synapseParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/synapse/parse"
+	webParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/web/parse"
+)
+
+var legacyResourceIdParsers = []legacyResourceIdParser{
+	{
+		name: "apimanagement.ApiDiagnosticId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ApiDiagnosticID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ApiId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ApiIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ApiManagementId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ApiManagementID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ApiOperationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ApiOperationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ApiOperationPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ApiOperationPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ApiPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ApiPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ApiReleaseId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ApiReleaseID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ApiSchemaId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ApiSchemaID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ApiTagDescriptionsId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ApiTagDescriptionsID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ApiTagId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ApiTagID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ApiVersionSetId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ApiVersionSetID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.AuthorizationServerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.AuthorizationServerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.BackendId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.BackendID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.CertificateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.CertificateID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.CustomDomainId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.CustomDomainID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.DiagnosticId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.DiagnosticID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.EmailTemplateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.EmailTemplateID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.GatewayApiId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.GatewayApiID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.GatewayCertificateAuthorityId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.GatewayCertificateAuthorityID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.GatewayHostNameConfigurationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.GatewayHostNameConfigurationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.GatewayId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.GatewayID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.GlobalSchemaId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.GlobalSchemaID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.GroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.GroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.GroupUserId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.GroupUserID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.IdentityProviderId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.IdentityProviderID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.LoggerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.LoggerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.NamedValueId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.NamedValueID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.NotificationRecipientEmailId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.NotificationRecipientEmailID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.NotificationRecipientUserId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.NotificationRecipientUserID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.OpenIDConnectProviderId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.OpenIDConnectProviderID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.OperationTagId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.OperationTagID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.PolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.PolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ProductApiId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ProductApiID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ProductGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ProductGroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ProductId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ProductIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ProductPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ProductPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.ProductTagId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.ProductTagID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.PropertyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.PropertyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.RedisCacheId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.RedisCacheID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.SubscriptionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.SubscriptionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.TagId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.TagID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "apimanagement.UserId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := apimanagementParse.UserID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "appconfiguration.NestedItemId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := appconfigurationParse.ParseNestedItemID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "applicationinsights.AnalyticsSharedItemId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := applicationinsightsParse.AnalyticsSharedItemID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "applicationinsights.AnalyticsUserItemId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := applicationinsightsParse.AnalyticsUserItemID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "applicationinsights.SmartDetectionRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := applicationinsightsParse.SmartDetectionRuleIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "appservice.AppServiceEnvironmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := appserviceParse.AppServiceEnvironmentIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "appservice.AppServiceSourceControlTokenId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := appserviceParse.AppServiceSourceControlTokenID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "authorization.ExclusiveRoleAssignmentsId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := authorizationParse.ExclusiveRoleAssignmentsID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "authorization.PimRoleAssignmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := authorizationParse.PimRoleAssignmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "authorization.RoleAssignmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := authorizationParse.RoleAssignmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "authorization.ScopedRoleAssignmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := authorizationParse.ScopedRoleAssignmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "batch.JobId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := batchParse.JobID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "billing.EnrollmentBillingScopeId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := billingParse.EnrollmentBillingScopeID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "billing.EnrollmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := billingParse.EnrollmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "billing.MicrosoftCustomerAccountBillingScopeId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := billingParse.MicrosoftCustomerAccountBillingScopeID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "billing.MicrosoftPartnerAccountBillingScopeId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := billingParse.MicrosoftPartnerAccountBillingScopeID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "bot.BotChannelId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := botParse.BotChannelID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "bot.BotConnectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := botParse.BotConnectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "bot.BotHealthbotId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := botParse.BotHealthbotID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "bot.BotServiceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := botParse.BotServiceID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.CustomDomainId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.CustomDomainID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.EndpointId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.EndpointIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorCustomDomainAssociationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorCustomDomainAssociationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorCustomDomainId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorCustomDomainIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorEndpointId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorEndpointIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorFirewallPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorFirewallPolicyIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorOriginGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorOriginGroupIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorOriginId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorOriginIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorProfileId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorProfileIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorRouteDisableLinkToDefaultDomainId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorRouteDisableLinkToDefaultDomainID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorRouteId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorRouteIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorRuleIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorRuleSetId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorRuleSetIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorSecretId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorSecretIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.FrontDoorSecurityPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.FrontDoorSecurityPolicyIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cdn.ProfileId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cdnParse.ProfileIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.CommunityGalleryImageId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.CommunityGalleryImageID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.CommunityGalleryImageVersionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.CommunityGalleryImageVersionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.DataDiskId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.DataDiskID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.DiskEncryptionSetId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.DiskEncryptionSetID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.HostGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.HostGroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.HybridMachineId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.HybridMachineID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.PlanId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.PlanID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.SSHPublicKeyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.SSHPublicKeyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.SharedGalleryImageId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.SharedGalleryImageID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.SharedGalleryImageVersionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.SharedGalleryImageVersionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.SharedImageId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.SharedImageID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.SharedImageVersionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.SharedImageVersionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.VMSSInstanceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.VMSSInstanceIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.VirtualMachineExtensionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.VirtualMachineExtensionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "compute.VirtualMachineScaleSetExtensionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := computeParse.VirtualMachineScaleSetExtensionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "containerapps.ContainerAppCustomDomainId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := containerappsParse.ContainerAppCustomDomainIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "containers.ClusterId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := containersParse.ClusterID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "containers.ContainerRegistryTaskScheduleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := containersParse.ContainerRegistryTaskScheduleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "containers.ContainerRegistryTokenPasswordId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := containersParse.ContainerRegistryTokenPasswordID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "containers.NodePoolId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := containersParse.NodePoolID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "containers.ServiceVersionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := containersParse.ServiceVersionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.CassandraClusterId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.CassandraClusterID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.CassandraKeyspaceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.CassandraKeyspaceID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.CassandraTableId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.CassandraTableID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.DatabaseAccountId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.DatabaseAccountID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.GremlinDatabaseId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.GremlinDatabaseID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.GremlinGraphId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.GremlinGraphID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.MongodbCollectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.MongodbCollectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.MongodbDatabaseId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.MongodbDatabaseID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.NotebookWorkspaceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.NotebookWorkspaceID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.RestorableDatabaseAccountId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.RestorableDatabaseAccountID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.SqlContainerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.SqlContainerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.SqlDatabaseId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.SqlDatabaseID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.SqlFunctionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.SqlFunctionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.SqlRoleAssignmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.SqlRoleAssignmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.SqlRoleDefinitionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.SqlRoleDefinitionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.SqlStoredProcedureId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.SqlStoredProcedureID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.SqlTriggerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.SqlTriggerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "cosmos.TableId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := cosmosParse.TableID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "costmanagement.AnomalyAlertViewId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := costmanagementParse.AnomalyAlertViewID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "costmanagement.BillingAccountCostManagementExportId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := costmanagementParse.BillingAccountCostManagementExportID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "costmanagement.CostManagementExportId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := costmanagementParse.CostManagementExportID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "costmanagement.ResourceGroupCostManagementExportId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := costmanagementParse.ResourceGroupCostManagementExportID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "costmanagement.ResourceGroupCostManagementViewId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := costmanagementParse.ResourceGroupCostManagementViewID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "costmanagement.SubscriptionCostManagementExportId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := costmanagementParse.SubscriptionCostManagementExportID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "costmanagement.SubscriptionCostManagementViewId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := costmanagementParse.SubscriptionCostManagementViewID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "datafactory.DataFlowId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := datafactoryParse.DataFlowID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "datafactory.DataSetId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := datafactoryParse.DataSetID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "datafactory.IntegrationRuntimeId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := datafactoryParse.IntegrationRuntimeID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "datafactory.LinkedServiceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := datafactoryParse.LinkedServiceID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "datafactory.ManagedPrivateEndpointId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := datafactoryParse.ManagedPrivateEndpointID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "datafactory.PipelineId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := datafactoryParse.PipelineID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "datafactory.TriggerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := datafactoryParse.TriggerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "dataprotection.BackupVaultId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := dataprotectionParse.BackupVaultID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "desktopvirtualization.HostPoolRegistrationInfoId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := desktopvirtualizationParse.HostPoolRegistrationInfoIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "desktopvirtualization.WorkspaceApplicationGroupAssociationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := desktopvirtualizationParse.WorkspaceApplicationGroupAssociationIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "disks.DiskPoolIscsiTargetLunId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := disksParse.IscsiTargetLunID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "disks.DiskPoolManagedDiskAttachmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := disksParse.DiskPoolManagedDiskAttachmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "dns.SoaRecordId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := dnsParse.SoaRecordID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "domainservices.DomainServiceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := domainservicesParse.DomainServiceID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "domainservices.DomainServiceReplicaSetId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := domainservicesParse.DomainServiceReplicaSetID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "domainservices.DomainServiceTrustId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := domainservicesParse.DomainServiceTrustID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "firewall.FirewallApplicationRuleCollectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := firewallParse.FirewallApplicationRuleCollectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "firewall.FirewallNatRuleCollectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := firewallParse.FirewallNatRuleCollectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "firewall.FirewallNetworkRuleCollectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := firewallParse.FirewallNetworkRuleCollectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "frontdoor.BackendPoolId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := frontdoorParse.BackendPoolIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "frontdoor.CustomHttpsConfigurationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := frontdoorParse.CustomHttpsConfigurationIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "frontdoor.FrontDoorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := frontdoorParse.FrontDoorIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "frontdoor.FrontendEndpointId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := frontdoorParse.FrontendEndpointIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "frontdoor.HealthProbeId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := frontdoorParse.HealthProbeIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "frontdoor.LoadBalancingId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := frontdoorParse.LoadBalancingIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "frontdoor.LoadBalancingRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := frontdoorParse.LoadBalancingRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "frontdoor.RoutingRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := frontdoorParse.RoutingRuleIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "frontdoor.RulesEngineId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := frontdoorParse.RulesEngineIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "frontdoor.WebApplicationFirewallPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := frontdoorParse.WebApplicationFirewallPolicyIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iotcentral.OrganizationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iotcentralParse.OrganizationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.ConsumerGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.ConsumerGroupIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.EndpointCosmosDBAccountId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.EndpointCosmosDBAccountIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.EndpointEventhubId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.EndpointEventhubIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.EndpointServiceBusQueueId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.EndpointServiceBusQueueIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.EndpointServiceBusTopicId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.EndpointServiceBusTopicIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.EndpointStorageContainerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.EndpointStorageContainerIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.EnrichmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.EnrichmentIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.FallbackRouteId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.FallbackRouteIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.IotHubCertificateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.IotHubCertificateIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.IotHubId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.IotHubIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.RouteId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.RouteIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "iothub.SharedAccessPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := iothubParse.SharedAccessPolicyIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "keyvault.AccessPolicyApplicationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := keyvaultParse.AccessPolicyApplicationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "keyvault.AccessPolicyObjectId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := keyvaultParse.AccessPolicyObjectID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "keyvault.CertificateContactsId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := keyvaultParse.CertificateContactsID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "keyvault.CertificateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := keyvaultParse.CertificateID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "keyvault.CertificateVersionlessId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := keyvaultParse.CertificateVersionlessID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "keyvault.KeyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := keyvaultParse.KeyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "keyvault.KeyVersionlessId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := keyvaultParse.KeyVersionlessID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "keyvault.NestedItemId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := keyvaultParse.ParseNestedItemID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "keyvault.SecretId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := keyvaultParse.SecretID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "keyvault.SecretVersionlessId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := keyvaultParse.SecretVersionlessID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "kusto.DatabasePrincipalId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := kustoParse.DatabasePrincipalID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "loadbalancer.BackendAddressPoolAddressId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := loadbalancerParse.BackendAddressPoolAddressID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "loadbalancer.LoadBalancerBackendAddressPoolId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := loadbalancerParse.LoadBalancerBackendAddressPoolIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "loadbalancer.LoadBalancerFrontendIpConfigurationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := loadbalancerParse.LoadBalancerFrontendIpConfigurationIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "loadbalancer.LoadBalancerInboundNatPoolId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := loadbalancerParse.LoadBalancerInboundNatPoolID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "loadbalancer.LoadBalancerInboundNatRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := loadbalancerParse.LoadBalancerInboundNatRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "logic.ActionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := logicParse.ActionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "logic.LogicAppStandardId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := logicParse.LogicAppStandardID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "monitor.ActionGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := monitorParse.ActionGroupIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "monitor.LogProfileId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := monitorParse.LogProfileID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.DatabaseExtendedAuditingPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.DatabaseExtendedAuditingPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.DatabaseVulnerabilityAssessmentRuleBaselineId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.DatabaseVulnerabilityAssessmentRuleBaselineID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.ElasticPoolId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.ElasticPoolID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.EncryptionProtectorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.EncryptionProtectorID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.FailoverGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.FailoverGroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.FirewallRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.FirewallRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.JobAgentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.JobAgentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.JobCredentialId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.JobCredentialID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.ManagedInstancesSecurityAlertPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.ManagedInstancesSecurityAlertPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.OutboundFirewallRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.OutboundFirewallRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.RecoverableDatabaseId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.RecoverableDatabaseID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.ServerDNSAliasId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.ServerDNSAliasID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.ServerExtendedAuditingPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.ServerExtendedAuditingPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.ServerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.ServerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.ServerMicrosoftSupportAuditingPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.ServerMicrosoftSupportAuditingPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.ServerSecurityAlertPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.ServerSecurityAlertPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.ServerVulnerabilityAssessmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.ServerVulnerabilityAssessmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.SqlVirtualMachineId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.SqlVirtualMachineID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssql.VirtualNetworkRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlParse.VirtualNetworkRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssqlmanagedinstance.ManagedDatabaseId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlmanagedinstanceParse.ManagedDatabaseID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssqlmanagedinstance.ManagedInstanceAzureActiveDirectoryAdministratorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlmanagedinstanceParse.ManagedInstanceAzureActiveDirectoryAdministratorID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssqlmanagedinstance.ManagedInstanceEncryptionProtectorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlmanagedinstanceParse.ManagedInstanceEncryptionProtectorID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssqlmanagedinstance.ManagedInstanceFailoverGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlmanagedinstanceParse.ManagedInstanceFailoverGroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssqlmanagedinstance.ManagedInstanceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlmanagedinstanceParse.ManagedInstanceID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssqlmanagedinstance.ManagedInstanceVulnerabilityAssessmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlmanagedinstanceParse.ManagedInstanceVulnerabilityAssessmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mssqlmanagedinstance.ManagedInstancesSecurityAlertPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mssqlmanagedinstanceParse.ManagedInstancesSecurityAlertPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mysql.AzureActiveDirectoryAdministratorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mysqlParse.AzureActiveDirectoryAdministratorID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "mysql.FlexibleServerAzureActiveDirectoryAdministratorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := mysqlParse.FlexibleServerAzureActiveDirectoryAdministratorID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ApplicationGatewayHTTPListenerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ApplicationGatewayHTTPListenerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ApplicationGatewayId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ApplicationGatewayID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ApplicationGatewayPrivateLinkConfigurationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ApplicationGatewayPrivateLinkConfigurationIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ApplicationGatewayURLPathMapPathRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ApplicationGatewayURLPathMapPathRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.AuthenticationCertificateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.AuthenticationCertificateIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.BackendAddressPoolId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.BackendAddressPoolIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.BackendHttpSettingsCollectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.BackendHttpSettingsCollectionIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.BgpConnectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.BgpConnectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.CustomIpPrefixId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.CustomIpPrefixID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.CustomIpv4PrefixId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.CustomIpv4PrefixID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ExpressRouteCircuitAuthorizationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ExpressRouteCircuitAuthorizationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ExpressRouteCircuitConnectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ExpressRouteCircuitConnectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ExpressRouteCircuitId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ExpressRouteCircuitID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ExpressRouteCircuitPeeringId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ExpressRouteCircuitPeeringIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ExpressRouteConnectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ExpressRouteConnectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ExpressRouteGatewayId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ExpressRouteGatewayID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ExpressRoutePortAuthorizationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ExpressRoutePortAuthorizationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ExpressRoutePortId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ExpressRoutePortIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.FrontendIPConfigurationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.FrontendIPConfigurationIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.FrontendPortId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.FrontendPortIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.HttpListenerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.HttpListenerIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.HubRouteTableId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.HubRouteTableIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.HubRouteTableRouteId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.HubRouteTableRouteID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.HubVirtualNetworkConnectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.HubVirtualNetworkConnectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.IpGroupCidrId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.IpGroupCidrID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.IpGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.IpGroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.LocalNetworkGatewayId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.LocalNetworkGatewayID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.NatGatewayId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.NatGatewayID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.NetworkGatewayConnectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.NetworkGatewayConnectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.NetworkInterfaceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.NetworkInterfaceID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.NetworkInterfaceIpConfigurationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.NetworkInterfaceIpConfigurationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.NetworkSecurityGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.NetworkSecurityGroupIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.PointToSiteVpnGatewayId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.PointToSiteVpnGatewayID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.PrivateDnsZoneConfigId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.PrivateDnsZoneConfigID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.PrivateDnsZoneGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.PrivateDnsZoneGroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.PrivateLinkServiceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.PrivateLinkServiceID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.ProbeId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.ProbeIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.PublicIpAddressId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.PublicIpAddressID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.PublicIpPrefixId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.PublicIpPrefixID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.RedirectConfigurationsId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.RedirectConfigurationsIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.RewriteRuleSetId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.RewriteRuleSetIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.RouteFilterId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.RouteFilterID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.RouteId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.RouteID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.RouteMapId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.RouteMapID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.RouteTableId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.RouteTableID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.SecurityPartnerProviderId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.SecurityPartnerProviderID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.SecurityRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.SecurityRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.SslCertificateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.SslCertificateIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.SslProfileId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.SslProfileIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.SubnetId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.SubnetIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.SubnetServiceEndpointStoragePolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.SubnetServiceEndpointStoragePolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.TrustedClientCertificateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.TrustedClientCertificateIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.TrustedRootCertificateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.TrustedRootCertificateIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.UrlPathMapId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.UrlPathMapIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VirtualHubId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VirtualHubID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VirtualHubIpConfigurationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VirtualHubIpConfigurationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VirtualMachineScaleSetPublicIPAddressId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VirtualMachineScaleSetPublicIPAddressID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VirtualNetworkDnsServersId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VirtualNetworkDnsServersIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VirtualNetworkGatewayId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VirtualNetworkGatewayID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VirtualNetworkGatewayIpConfigurationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VirtualNetworkGatewayIpConfigurationIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VirtualNetworkGatewayNatRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VirtualNetworkGatewayNatRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VirtualNetworkGatewayPolicyGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VirtualNetworkGatewayPolicyGroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VirtualNetworkId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VirtualNetworkIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VirtualNetworkPeeringId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VirtualNetworkPeeringID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VirtualWanId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VirtualWanID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VpnConnectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VpnConnectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "network.VpnGatewayNatRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := networkParse.VpnGatewayNatRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "policy.ManagementGroupAssignmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := policyParse.ManagementGroupAssignmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "policy.PolicyAssignmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := policyParse.PolicyAssignmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "policy.ResourceGroupAssignmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := policyParse.ResourceGroupAssignmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "policy.ResourceGroupPolicyExemptionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := policyParse.ResourceGroupPolicyExemptionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "policy.ResourceGroupPolicyRemediationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := policyParse.ResourceGroupPolicyRemediationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "policy.ResourcePolicyExemptionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := policyParse.ResourcePolicyExemptionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "policy.ResourcePolicyRemediationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := policyParse.ResourcePolicyRemediationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "policy.SubscriptionAssignmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := policyParse.SubscriptionAssignmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "policy.SubscriptionPolicyExemptionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := policyParse.SubscriptionPolicyExemptionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "policy.SubscriptionPolicyRemediationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := policyParse.SubscriptionPolicyRemediationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "portal.DashboardId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := portalParse.DashboardID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "portal.PortalTenantConfigurationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := portalParse.PortalTenantConfigurationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "postgres.AzureActiveDirectoryAdministratorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := postgresParse.AzureActiveDirectoryAdministratorID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "recoveryservices.BackupPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := recoveryservicesParse.BackupPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "recoveryservices.ProtectedItemId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := recoveryservicesParse.ProtectedItemID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "recoveryservices.ProtectionContainerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := recoveryservicesParse.ProtectionContainerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "recoveryservices.ReplicationFabricId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := recoveryservicesParse.ReplicationFabricID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "recoveryservices.ReplicationNetworkMappingId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := recoveryservicesParse.ReplicationNetworkMappingID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "recoveryservices.ReplicationPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := recoveryservicesParse.ReplicationPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "recoveryservices.ReplicationProtectedItemId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := recoveryservicesParse.ReplicationProtectedItemID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "recoveryservices.ReplicationProtectionContainerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := recoveryservicesParse.ReplicationProtectionContainerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "recoveryservices.ReplicationProtectionContainerMappingsId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := recoveryservicesParse.ReplicationProtectionContainerMappingsID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "resource.ManagementGroupTemplateDeploymentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := resourceParse.ManagementGroupTemplateDeploymentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "resource.ResourceGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := resourceParse.ResourceGroupIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "resource.ResourceGroupTemplateDeploymentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := resourceParse.ResourceGroupTemplateDeploymentIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "resource.ResourceProviderId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := resourceParse.ResourceProviderID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "resource.SubscriptionTemplateDeploymentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := resourceParse.SubscriptionTemplateDeploymentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "resource.TemplateSpecVersionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := resourceParse.TemplateSpecVersionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "resource.TenantTemplateDeploymentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := resourceParse.TenantTemplateDeploymentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.AdvancedThreatProtectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.AdvancedThreatProtectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.AssessmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.AssessmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.AssessmentMetadataId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.AssessmentMetadataID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.AutoProvisioningSettingId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.AutoProvisioningSettingIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.AutomationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.AutomationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.ContactId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.ContactID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.IotSecurityDeviceGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.IotSecurityDeviceGroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.IotSecuritySolutionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.IotSecuritySolutionIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.PricingId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.PricingID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.SettingId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.SettingID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.VulnerabilityAssessmentVmId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.VulnerabilityAssessmentVmID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.VulnerabilityAssessmentsSettingId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.VulnerabilityAssessmentsSettingID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "securitycenter.WorkspaceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := securitycenterParse.WorkspaceID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sentinel.AutomationRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sentinelParse.AutomationRuleIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sentinel.DataConnectorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sentinelParse.DataConnectorID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sentinel.MLAnalyticsSettingsId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sentinelParse.MLAnalyticsSettingsID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sentinel.SentinelAlertRuleTemplateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sentinelParse.SentinelAlertRuleTemplateIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sentinel.ThreatIntelligenceIndicatorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sentinelParse.ThreatIntelligenceIndicatorID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudAPIPortalCustomDomainId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudAPIPortalCustomDomainIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudAPIPortalId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudAPIPortalIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudAcceleratorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudAcceleratorIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudAppAssociationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudAppAssociationIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudAppId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudAppIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudApplicationLiveViewId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudApplicationLiveViewID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudBuildPackBindingId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudBuildPackBindingIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudBuildServiceBuilderId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudBuildServiceBuilderIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudCertificateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudCertificateIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudConfigurationServiceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudConfigurationServiceIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudContainerRegistryId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudContainerRegistryIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudCustomDomainId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudCustomDomainIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudCustomizedAcceleratorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudCustomizedAcceleratorIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudDeploymentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudDeploymentIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudDevToolPortalId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudDevToolPortalID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudGatewayCustomDomainId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudGatewayCustomDomainIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudGatewayId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudGatewayIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudGatewayRouteConfigId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudGatewayRouteConfigIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudServiceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudServiceIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudServiceRegistryId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudServiceRegistryIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "springcloud.SpringCloudStorageId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := springcloudParse.SpringCloudStorageIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sql.AzureActiveDirectoryAdministratorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sqlParse.AzureActiveDirectoryAdministratorIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sql.DatabaseId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sqlParse.DatabaseID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sql.ElasticPoolId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sqlParse.ElasticPoolID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sql.FailoverGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sqlParse.FailoverGroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sql.FirewallRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sqlParse.FirewallRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sql.InstanceFailoverGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sqlParse.InstanceFailoverGroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sql.ManagedDatabaseId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sqlParse.ManagedDatabaseID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sql.ManagedInstanceAzureActiveDirectoryAdministratorId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sqlParse.ManagedInstanceAzureActiveDirectoryAdministratorID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sql.ManagedInstanceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sqlParse.ManagedInstanceIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sql.ServerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sqlParse.ServerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "sql.VirtualNetworkRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := sqlParse.VirtualNetworkRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "storage.ObjectReplicationId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := storageParse.ObjectReplicationID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "storage.StorageAccountDefaultBlobId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := storageParse.StorageAccountDefaultBlobID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "storage.StorageAccountManagementPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := storageParse.StorageAccountManagementPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "storage.StorageContainerImmutabilityPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := storageParse.StorageContainerImmutabilityPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "storage.StorageQueueResourceManagerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := storageParse.StorageQueueResourceManagerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "storage.StorageShareResourceManagerId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := storageParse.StorageShareResourceManagerID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "storagecache.CacheAccessPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := storagecacheParse.CacheAccessPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "streamanalytics.StreamingJobScheduleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := streamanalyticsParse.StreamingJobScheduleIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "subscription.SubscriptionAliasId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := subscriptionParse.SubscriptionAliasID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.FirewallRuleId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.FirewallRuleID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.IntegrationRuntimeId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.IntegrationRuntimeIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.LinkedServiceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.LinkedServiceIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.ManagedPrivateEndpointId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.ManagedPrivateEndpointID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.PrivateLinkHubId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.PrivateLinkHubID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.RoleAssignmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.RoleAssignmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.SparkPoolId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.SparkPoolIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.SqlPoolExtendedAuditingPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.SqlPoolExtendedAuditingPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.SqlPoolId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.SqlPoolID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.SqlPoolRecoverableDatabaseId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.SqlPoolRecoverableDatabaseID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.SqlPoolSecurityAlertPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.SqlPoolSecurityAlertPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.SqlPoolVulnerabilityAssessmentBaselineId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.SqlPoolVulnerabilityAssessmentBaselineID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.SqlPoolVulnerabilityAssessmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.SqlPoolVulnerabilityAssessmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.SqlPoolWorkloadClassifierId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.SqlPoolWorkloadClassifierID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.SqlPoolWorkloadGroupId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.SqlPoolWorkloadGroupID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.WorkspaceAADAdminId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.WorkspaceAADAdminID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.WorkspaceExtendedAuditingPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.WorkspaceExtendedAuditingPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.WorkspaceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.WorkspaceIDInsensitively(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.WorkspaceKeysId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.WorkspaceKeysID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.WorkspaceSecurityAlertPolicyId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.WorkspaceSecurityAlertPolicyID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.WorkspaceSqlAADAdminId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.WorkspaceSqlAADAdminID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "synapse.WorkspaceVulnerabilityAssessmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := synapseParse.WorkspaceVulnerabilityAssessmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.AppServiceEnvironmentId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.AppServiceEnvironmentID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.AppServiceId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.AppServiceID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.AppServicePlanId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.AppServicePlanID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.AppServiceSlotCustomHostnameBindingId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.AppServiceSlotCustomHostnameBindingID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.AppServiceSlotId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.AppServiceSlotID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.CertificateBindingId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.CertificateBindingID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.CertificateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.CertificateID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.CertificateOrderId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.CertificateOrderID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.CertificateOrderOldId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.CertificateOrderOldID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.FunctionAppId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.FunctionAppID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.FunctionAppSlotId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.FunctionAppSlotID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.HostnameBindingId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.HostnameBindingID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.HybridConnectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.HybridConnectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.ManagedCertificateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.ManagedCertificateID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.PublicCertificateId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.PublicCertificateID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.SlotVirtualNetworkSwiftConnectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.SlotVirtualNetworkSwiftConnectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.StaticSiteCustomDomainId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.StaticSiteCustomDomainID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.StaticSiteId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.StaticSiteID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+	{
+		name: "web.VirtualNetworkSwiftConnectionId",
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := webParse.VirtualNetworkSwiftConnectionID(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = NormaliseResourceIdFunction{}
+
+type NormaliseResourceIdFunction struct{}
+
+func NewNormaliseResourceIdFunction() function.Function {
+	return &NormaliseResourceIdFunction{}
+}
+
+func (n NormaliseResourceIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalise_resource_id"
+}
+
+func (n NormaliseResourceIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Normalises the casing of an Azure Resource Manager ID",
+		Description:         "Corrects the casing of the static segments of an Azure Resource Manager ID, which must match a known Resource ID format, leaving the user-specified values unchanged.",
+		MarkdownDescription: "Corrects the casing of the static segments of an Azure Resource Manager ID, which must match a known Resource ID format, leaving the user-specified values unchanged.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				Description:         "The Azure Resource Manager ID to normalise.",
+				MarkdownDescription: "The Azure Resource Manager ID to normalise.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (n NormaliseResourceIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parseKnownResourceId(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, parsed.normalisedId))
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
+)
+
+func TestNormaliseResourceIdFunction(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected string
+		error    bool
+	}{
+		{
+			input: "",
+			error: true,
+		},
+		{
+			// unknown resource type
+			input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Unknown/things/thing1",
+			error: true,
+		},
+		{
+			// already normalised
+			input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+			expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1",
+		},
+		{
+			// the user-specified segments should retain their casing
+			input:    "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012/RESOURCEGROUPS/Group1/PROVIDERS/MICROSOFT.STORAGE/STORAGEACCOUNTS/Account1",
+			expected: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/Group1/providers/Microsoft.Storage/storageAccounts/Account1",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.input)
+
+		resp := function.RunResponse{
+			Result: function.NewResultData(types.StringUnknown()),
+		}
+		providerfunction.NewNormaliseResourceIdFunction().Run(context.TODO(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(v.input)}),
+		}, &resp)
+
+		if v.error {
+			if resp.Error == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if resp.Error != nil {
+			t.Fatalf("unexpected error: %+v", resp.Error)
+		}
+
+		actual := resp.Result.Value().(types.String).ValueString()
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = ParseResourceIdFunction{}
+
+var parseResourceIdReturnAttributeTypes = map[string]attr.Type{
+	"resource_name":       types.StringType,
+	"resource_provider":   types.StringType,
+	"resource_type":       types.StringType,
+	"full_resource_type":  types.StringType,
+	"resource_group_name": types.StringType,
+	"subscription_id":     types.StringType,
+	"resource_scope":      types.StringType,
+	"parent_resources":    types.MapType{ElemType: types.StringType},
+	"segments":            types.MapType{ElemType: types.StringType},
+}
+
+type ParseResourceIdFunction struct{}
+
+func NewParseResourceIdFunction() function.Function {
+	return &ParseResourceIdFunction{}
+}
+
+func (p ParseResourceIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_resource_id"
+}
+
+func (p ParseResourceIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parses an Azure Resource Manager ID into its components",
+		Description:         "Parses an Azure Resource Manager ID, which must match a known Resource ID format, into an object containing its components.",
+		MarkdownDescription: "Parses an Azure Resource Manager ID, which must match a known Resource ID format, into an object containing its components.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				Description:         "The Azure Resource Manager ID to parse.",
+				MarkdownDescription: "The Azure Resource Manager ID to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parseResourceIdReturnAttributeTypes,
+		},
+	}
+}
+
+func (p ParseResourceIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parseKnownResourceId(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	components, err := componentsFromResourceId(parsed.normalisedId, parsed.scope)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	parentResources, diags := types.MapValueFrom(ctx, types.StringType, components.parentResources)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	segments, diags := types.MapValueFrom(ctx, types.StringType, parsed.segments)
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	result, diags := types.ObjectValue(parseResourceIdReturnAttributeTypes, map[string]attr.Value{
+		"resource_name":       types.StringValue(components.resourceName),
+		"resource_provider":   types.StringValue(components.resourceProvider),
+		"resource_type":       types.StringValue(components.resourceType),
+		"full_resource_type":  types.StringValue(components.fullResourceType),
+		"resource_group_name": types.StringValue(components.resourceGroupName),
+		"subscription_id":     types.StringValue(components.subscriptionId),
+		"resource_scope":      types.StringValue(parsed.scope),
+		"parent_resources":    parentResources,
+		"segments":            segments,
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
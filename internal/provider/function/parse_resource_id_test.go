@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
+)
+
+func TestParseResourceIdFunction(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected map[string]string
+		parents  map[string]string
+		error    bool
+	}{
+		{
+			// empty
+			input: "",
+			error: true,
+		},
+		{
+			// not a resource id
+			input: "hello-world",
+			error: true,
+		},
+		{
+			// unknown resource type
+			input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Unknown/things/thing1",
+			error: true,
+		},
+		{
+			// missing the name of the subnet
+			input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/",
+			error: true,
+		},
+		{
+			input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			expected: map[string]string{
+				"resource_name":       "group1",
+				"resource_provider":   "Microsoft.Resources",
+				"resource_type":       "resourceGroups",
+				"full_resource_type":  "Microsoft.Resources/resourceGroups",
+				"resource_group_name": "group1",
+				"subscription_id":     "12345678-1234-9876-4563-123456789012",
+				"resource_scope":      "",
+			},
+			parents: map[string]string{},
+		},
+		{
+			input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1",
+			expected: map[string]string{
+				"resource_name":       "network1",
+				"resource_provider":   "Microsoft.Network",
+				"resource_type":       "virtualNetworks",
+				"full_resource_type":  "Microsoft.Network/virtualNetworks",
+				"resource_group_name": "group1",
+				"subscription_id":     "12345678-1234-9876-4563-123456789012",
+				"resource_scope":      "",
+			},
+			parents: map[string]string{},
+		},
+		{
+			// incorrect casing
+			input: "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012/resourcegroups/group1/providers/microsoft.network/virtualnetworks/network1/SUBNETS/subnet1",
+			expected: map[string]string{
+				"resource_name":       "subnet1",
+				"resource_provider":   "Microsoft.Network",
+				"resource_type":       "subnets",
+				"full_resource_type":  "Microsoft.Network/virtualNetworks/subnets",
+				"resource_group_name": "group1",
+				"subscription_id":     "12345678-1234-9876-4563-123456789012",
+				"resource_scope":      "",
+			},
+			parents: map[string]string{
+				"virtualNetworks": "network1",
+			},
+		},
+		{
+			// only known to the parsers within `internal/services/*/parse`
+			input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.ApiManagement/service/service1/apis/api1/operations/operation1",
+			expected: map[string]string{
+				"resource_name":       "operation1",
+				"resource_provider":   "Microsoft.ApiManagement",
+				"resource_type":       "operations",
+				"full_resource_type":  "Microsoft.ApiManagement/service/apis/operations",
+				"resource_group_name": "group1",
+				"subscription_id":     "12345678-1234-9876-4563-123456789012",
+				"resource_scope":      "",
+			},
+			parents: map[string]string{
+				"service": "service1",
+				"apis":    "api1",
+			},
+		},
+		{
+			// matches the segments of a parser within `internal/services/*/parse` but for a different Resource Provider
+			input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Unknown/service/service1/apis/api1/operations/operation1",
+			error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.input)
+
+		resp := function.RunResponse{
+			Result: function.NewResultData(types.ObjectUnknown(parseResourceIdAttributeTypes())),
+		}
+		providerfunction.NewParseResourceIdFunction().Run(context.TODO(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(v.input)}),
+		}, &resp)
+
+		if v.error {
+			if resp.Error == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if resp.Error != nil {
+			t.Fatalf("unexpected error: %+v", resp.Error)
+		}
+
+		result, ok := resp.Result.Value().(types.Object)
+		if !ok {
+			t.Fatalf("expected the result to be an Object but got %T", resp.Result.Value())
+		}
+		attributes := result.Attributes()
+		for key, expected := range v.expected {
+			actual := attributes[key].(types.String).ValueString()
+			if actual != expected {
+				t.Fatalf("expected %q to be %q but got %q", key, expected, actual)
+			}
+		}
+
+		parents := attributes["parent_resources"].(types.Map).Elements()
+		if len(parents) != len(v.parents) {
+			t.Fatalf("expected %d parent resources but got %d", len(v.parents), len(parents))
+		}
+		for key, expected := range v.parents {
+			actual, ok := parents[key]
+			if !ok {
+				t.Fatalf("expected the parent resource %q but it wasn't present", key)
+			}
+			if actual.(types.String).ValueString() != expected {
+				t.Fatalf("expected the parent resource %q to be %q but got %q", key, expected, actual.(types.String).ValueString())
+			}
+		}
+	}
+}
+
+func parseResourceIdAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"resource_name":       types.StringType,
+		"resource_provider":   types.StringType,
+		"resource_type":       types.StringType,
+		"full_resource_type":  types.StringType,
+		"resource_group_name": types.StringType,
+		"subscription_id":     types.StringType,
+		"resource_scope":      types.StringType,
+		"parent_resources":    types.MapType{ElemType: types.StringType},
+		"segments":            types.MapType{ElemType: types.StringType},
+	}
+}
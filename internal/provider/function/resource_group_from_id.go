@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+)
+
+var _ function.Function = ResourceGroupFromIdFunction{}
+
+type ResourceGroupFromIdFunction struct{}
+
+func NewResourceGroupFromIdFunction() function.Function {
+	return &ResourceGroupFromIdFunction{}
+}
+
+func (r ResourceGroupFromIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "resource_group_from_id"
+}
+
+func (r ResourceGroupFromIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Returns the name of the Resource Group from an Azure Resource Manager ID",
+		Description:         "Returns the name of the Resource Group from any Azure Resource Manager ID scoped to a Resource Group, raising an error if the Resource ID isn't scoped to a Resource Group.",
+		MarkdownDescription: "Returns the name of the Resource Group from any Azure Resource Manager ID scoped to a Resource Group, raising an error if the Resource ID isn't scoped to a Resource Group.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				Description:         "The Azure Resource Manager ID to return the Resource Group name from.",
+				MarkdownDescription: "The Azure Resource Manager ID to return the Resource Group name from.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (r ResourceGroupFromIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	// this intentionally uses the generic parser, since this is commonly used with Resource IDs
+	// for Resource Types which aren't otherwise supported by the Provider
+	parsed, err := azure.ParseAzureResourceID(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("parsing %q: %+v", id, err))
+		return
+	}
+
+	if parsed.ResourceGroup == "" {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("the Resource ID %q isn't scoped to a Resource Group", id))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, parsed.ResourceGroup))
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
+)
+
+func TestResourceGroupFromIdFunction(t *testing.T) {
+	testData := []struct {
+		input    string
+		expected string
+		error    bool
+	}{
+		{
+			input: "",
+			error: true,
+		},
+		{
+			// subscription
+			input: "/subscriptions/12345678-1234-9876-4563-123456789012",
+			error: true,
+		},
+		{
+			input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			expected: "group1",
+		},
+		{
+			// resource types unknown to the provider are supported
+			input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Unknown/things/thing1",
+			expected: "group1",
+		},
+		{
+			input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/group1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1",
+			expected: "group1",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.input)
+
+		resp := function.RunResponse{
+			Result: function.NewResultData(types.StringUnknown()),
+		}
+		providerfunction.NewResourceGroupFromIdFunction().Run(context.TODO(), function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(v.input)}),
+		}, &resp)
+
+		if v.error {
+			if resp.Error == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if resp.Error != nil {
+			t.Fatalf("unexpected error: %+v", resp.Error)
+		}
+
+		actual := resp.Result.Value().(types.String).ValueString()
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/recaser"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+// parsedResourceId is a Resource ID which has been matched against (and validated using) one of
+// the known Resource ID types registered with the recaser, by the Resource ID parsers for each
+// Azure Resource Manager API used in the Provider - or one of the Resource ID types within the
+// `internal/services/*/parse` packages.
+type parsedResourceId struct {
+	// normalisedId is the Resource ID with the casing of the static segments corrected
+	normalisedId string
+
+	// segments is a map of the user-specified segment names to values (e.g. `virtualNetworkName`)
+	segments map[string]string
+
+	// scope is the value of the Scope segment, for Resource IDs which can be applied to any scope
+	scope string
+}
+
+// parseKnownResourceId matches the input against the known Resource ID types, returning an error
+// if the Resource ID isn't a known type or is invalid for the type it matches.
+func parseKnownResourceId(input string) (*parsedResourceId, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, fmt.Errorf("the Resource ID cannot be empty")
+	}
+
+	idType := recaser.ResourceIdTypeFromResourceId(input)
+	if idType == nil {
+		// fall back to the Resource ID types within the `internal/services/*/parse` packages
+		if parsed, ok := parseLegacyResourceId(input); ok {
+			return parsed, nil
+		}
+		return nil, fmt.Errorf("the Resource ID %q doesn't match any known Azure Resource ID format", input)
+	}
+
+	parser := resourceids.NewParserFromResourceIdType(idType)
+	parsed, err := parser.Parse(input, true)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q: %+v", input, err)
+	}
+
+	output := parsedResourceId{
+		segments: make(map[string]string),
+	}
+	for _, segment := range idType.Segments() {
+		value, ok := parsed.Parsed[segment.Name]
+		if !ok {
+			continue
+		}
+
+		switch segment.Type {
+		case resourceids.ScopeSegmentType:
+			value = recaser.ReCase(value)
+			parsed.Parsed[segment.Name] = value
+			output.scope = value
+			output.segments[segment.Name] = value
+
+		case resourceids.ConstantSegmentType, resourceids.ResourceGroupSegmentType, resourceids.SubscriptionIdSegmentType, resourceids.UserSpecifiedSegmentType:
+			output.segments[segment.Name] = value
+		}
+	}
+
+	if err := idType.FromParseResult(*parsed); err != nil {
+		return nil, fmt.Errorf("populating the Resource ID from %q: %+v", input, err)
+	}
+	output.normalisedId = idType.ID()
+
+	return &output, nil
+}
+
+// resourceIdComponents are the components of an Azure Resource Manager ID, as used in the
+// Resource ID itself (rather than the names of the segments from the Resource ID type).
+type resourceIdComponents struct {
+	subscriptionId    string
+	resourceGroupName string
+	resourceProvider  string
+	resourceType      string
+	resourceName      string
+	fullResourceType  string
+	parentResources   map[string]string
+}
+
+// componentsFromResourceId splits a normalised Resource ID (excluding any Scope prefix) into its components.
+func componentsFromResourceId(id, scope string) (*resourceIdComponents, error) {
+	path := strings.TrimPrefix(id, scope)
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil, fmt.Errorf("the Resource ID %q contained no segments", id)
+	}
+
+	segments := strings.Split(path, "/")
+	if len(segments)%2 != 0 {
+		return nil, fmt.Errorf("the Resource ID %q contained an odd number of segments", id)
+	}
+
+	output := resourceIdComponents{
+		parentResources: make(map[string]string),
+	}
+	types := make([]string, 0)
+	names := make([]string, 0)
+	for i := 0; i < len(segments); i += 2 {
+		key := segments[i]
+		value := segments[i+1]
+
+		switch {
+		case strings.EqualFold(key, "subscriptions") && output.subscriptionId == "" && output.resourceProvider == "":
+			output.subscriptionId = value
+
+		case strings.EqualFold(key, "resourceGroups") && output.resourceGroupName == "" && output.resourceProvider == "":
+			output.resourceGroupName = value
+
+		case strings.EqualFold(key, "providers"):
+			// nested Resource Providers (e.g. extension resources) reset the Resource Type
+			output.resourceProvider = value
+			types = make([]string, 0)
+			names = make([]string, 0)
+
+		default:
+			types = append(types, key)
+			names = append(names, value)
+		}
+	}
+
+	if len(types) == 0 {
+		// Resource Groups and Subscriptions are exposed by the Microsoft.Resources Resource Provider
+		output.resourceProvider = "Microsoft.Resources"
+		if output.resourceGroupName != "" {
+			output.resourceType = "resourceGroups"
+			output.resourceName = output.resourceGroupName
+		} else {
+			output.resourceType = "subscriptions"
+			output.resourceName = output.subscriptionId
+		}
+		output.fullResourceType = fmt.Sprintf("%s/%s", output.resourceProvider, output.resourceType)
+		return &output, nil
+	}
+
+	output.resourceType = types[len(types)-1]
+	output.resourceName = names[len(names)-1]
+	for i := 0; i < len(types)-1; i++ {
+		output.parentResources[types[i]] = names[i]
+	}
+	output.fullResourceType = strings.Join(append([]string{output.resourceProvider}, types...), "/")
+	if output.resourceProvider == "" {
+		output.fullResourceType = strings.Join(types, "/")
+	}
+
+	return &output, nil
+}
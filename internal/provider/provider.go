@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
@@ -30,6 +31,14 @@ func TestAzureProvider() *schema.Provider {
 	return azureProvider(true)
 }
 
+// TestAzureProviderWithCassette returns the Provider used in the Acceptance Tests, configured to
+// record the HTTP Interactions it makes into (or replay them from) the specified Cassette.
+func TestAzureProviderWithCassette(cassette common.CassetteRecorder) *schema.Provider {
+	p := azureProvider(true)
+	p.ConfigureContextFunc = providerConfigureWithCassette(p, cassette)
+	return p
+}
+
 func ValidatePartnerID(i interface{}, k string) ([]string, []error) {
 	// ValidatePartnerID checks if partner_id is any of the following:
 	//  * a valid UUID - will add "pid-" prefix to the ID if it is not already present
@@ -82,6 +91,7 @@ func ValidatePartnerID(i interface{}, k string) ([]string, []error) {
 func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 	dataSources := make(map[string]*schema.Resource)
 	resources := make(map[string]*schema.Resource)
+	untypedResources := make(map[string]*schema.Resource)
 
 	// first handle the typed services
 	for _, service := range SupportedTypedServices() {
@@ -125,6 +135,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				panic(fmt.Sprintf("An existing Data Source exists for %q", k))
 			}
 
+			applyTracing(fmt.Sprintf("data.%s", k), v)
 			dataSources[k] = v
 		}
 
@@ -135,9 +146,19 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 			}
 
 			resources[k] = v
+			untypedResources[k] = v
 		}
 	}
 
+	for _, resource := range resources {
+		applyProviderTags(resource)
+	}
+
+	// applied after the Provider Tags so that the span for each operation includes updating the Tags
+	for k, resource := range untypedResources {
+		applyTracing(k, resource)
+	}
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
@@ -319,6 +340,10 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 
 			"features": schemaFeatures(supportLegacyTestSuite),
 
+			"default_tags": schemaDefaultTags(),
+
+			"ignore_tags": schemaIgnoreTags(),
+
 			// Advanced feature flags
 			"skip_provider_registration": {
 				Type:        schema.TypeBool,
@@ -327,12 +352,98 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "Should the AzureRM Provider skip registering all of the Resource Providers that it supports, if they're not already registered?",
 			},
 
+			"resource_provider_registrations": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_RESOURCE_PROVIDER_REGISTRATIONS", string(resourceproviders.RegistrationSetExtended)),
+				ValidateFunc: validation.StringInSlice(resourceproviders.PossibleValuesForRegistrationSet(), false),
+				Description:  "The set of Resource Providers which should be automatically registered for the Subscription. Possible values are `none`, `core`, `extended` and `all`. Defaults to `extended`.",
+			},
+
+			"resource_providers_to_register": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				Description: "A list of additional Resource Providers which should be registered for the Subscription.",
+			},
+
 			"storage_use_azuread": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_STORAGE_USE_AZUREAD", false),
 				Description: "Should the AzureRM Provider use AzureAD to access the Storage Data Plane API's?",
 			},
+
+			"storage_emulator": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      common.StorageEmulatorDefaultAccountName,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "The name of the Storage Account within the Storage Emulator.",
+						},
+
+						"account_key": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							Default:      common.StorageEmulatorDefaultAccountKey,
+							ValidateFunc: validation.StringIsBase64,
+							Description:  "The Account Key for the Storage Account within the Storage Emulator.",
+						},
+
+						"blob_endpoint": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      common.StorageEmulatorDefaultBlobEndpoint,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Description:  "The endpoint of the Blob service within the Storage Emulator.",
+						},
+
+						"queue_endpoint": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      common.StorageEmulatorDefaultQueueEndpoint,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Description:  "The endpoint of the Queue service within the Storage Emulator.",
+						},
+
+						"table_endpoint": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      common.StorageEmulatorDefaultTableEndpoint,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Description:  "The endpoint of the Table service within the Storage Emulator.",
+						},
+					},
+				},
+				Description: "Configures the Storage Data Plane API's to use a local Storage Emulator (such as Azurite) rather than Azure.",
+			},
+
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The maximum number of concurrent requests which should be made to each Resource Provider. Defaults to `0` (unlimited).",
+			},
+
+			"resource_provider_max_concurrent_requests": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "A mapping of Resource Provider namespaces (e.g. `Microsoft.Network`) to the maximum number of concurrent requests which should be made to that Resource Provider, overriding `max_concurrent_requests`.",
+			},
 		},
 
 		DataSourcesMap: dataSources,
@@ -345,6 +456,10 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 }
 
 func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
+	return providerConfigureWithCassette(p, nil)
+}
+
+func providerConfigureWithCassette(p *schema.Provider, cassette common.CassetteRecorder) schema.ConfigureContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		var auxTenants []string
 		if v, ok := d.Get("auxiliary_tenant_ids").([]interface{}); ok && len(v) > 0 {
@@ -436,12 +551,38 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			EnableAuthenticationUsingGitHubOIDC:        enableOidc,
 		}
 
-		return buildClient(ctx, p, d, authConfig)
+		return buildClientWithCassette(ctx, p, d, authConfig, cassette)
 	}
 }
 
 func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials) (*clients.Client, diag.Diagnostics) {
-	skipProviderRegistration := d.Get("skip_provider_registration").(bool)
+	return buildClientWithCassette(ctx, p, d, authConfig, nil)
+}
+
+func buildClientWithCassette(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials, cassette common.CassetteRecorder) (*clients.Client, diag.Diagnostics) {
+	storageEmulator := expandStorageEmulator(d.Get("storage_emulator").([]interface{}))
+
+	// there are no Resource Providers to register when using a Storage Emulator, since no requests are made to Azure
+	skipProviderRegistration := d.Get("skip_provider_registration").(bool) || storageEmulator != nil
+	registrationSet := resourceproviders.RegistrationSet(d.Get("resource_provider_registrations").(string))
+	additionalResourceProviders := utils.ExpandStringSlice(d.Get("resource_providers_to_register").([]interface{}))
+	var diags diag.Diagnostics
+	if skipProviderRegistration {
+		if len(*additionalResourceProviders) > 0 {
+			reason := "`skip_provider_registration` (or the `ARM_SKIP_PROVIDER_REGISTRATION` Environment Variable) is set to `true`"
+			if storageEmulator != nil {
+				reason = "a Storage Emulator is being used"
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "`resource_providers_to_register` will be ignored",
+				Detail:   fmt.Sprintf("The Resource Providers specified in `resource_providers_to_register` (%s) won't be registered since %s.", strings.Join(*additionalResourceProviders, ", "), reason),
+			})
+		}
+
+		registrationSet = resourceproviders.RegistrationSetNone
+		*additionalResourceProviders = []string{}
+	}
 
 	clientBuilder := clients.ClientBuilder{
 		AuthConfig:                  authConfig,
@@ -455,9 +596,18 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		SubscriptionID:              d.Get("subscription_id").(string),
 		TerraformVersion:            p.TerraformVersion,
 
+		// Resource Providers are registered on-demand unless registration has been disabled entirely
+		RegisterResourceProvidersOnDemand: registrationSet != resourceproviders.RegistrationSetNone,
+
+		MaxConcurrentRequests:                 d.Get("max_concurrent_requests").(int),
+		ResourceProviderMaxConcurrentRequests: expandResourceProviderMaxConcurrentRequests(d.Get("resource_provider_max_concurrent_requests").(map[string]interface{})),
+
 		// this field is intentionally not exposed in the provider block, since it's only used for
 		// platform level tracing
 		CustomCorrelationRequestID: os.Getenv("ARM_CORRELATION_REQUEST_ID"),
+
+		Cassette:        cassette,
+		StorageEmulator: storageEmulator,
 	}
 
 	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
@@ -472,19 +622,72 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 	}
 
 	client.StopContext = stopCtx
+	client.Tags = expandProviderTags(d.Get("default_tags").([]interface{}), d.Get("ignore_tags").([]interface{}))
 
-	if !skipProviderRegistration {
+	if registrationSet != resourceproviders.RegistrationSetNone || len(*additionalResourceProviders) > 0 {
 		subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
-		requiredResourceProviders := resourceproviders.Required()
 		ctx2, cancel := context.WithTimeout(ctx, 30*time.Minute)
 		defer cancel()
 
+		if err := resourceproviders.CacheSupportedProviders(ctx2, client.Resource.ResourceProvidersClient, subscriptionId); err != nil {
+			return nil, diag.Errorf(resourceProviderRegistrationErrorFmt, err)
+		}
+
+		requiredResourceProviders, err := resourceproviders.ForRegistrationSet(registrationSet, *additionalResourceProviders)
+		if err != nil {
+			return nil, diag.Errorf(resourceProviderRegistrationErrorFmt, err)
+		}
+
 		if err := resourceproviders.EnsureRegistered(ctx2, client.Resource.ResourceProvidersClient, subscriptionId, requiredResourceProviders); err != nil {
 			return nil, diag.Errorf(resourceProviderRegistrationErrorFmt, err)
 		}
+
+		client.Account.RegisteredResourceProviders = requiredResourceProviders
+	}
+
+	return client, diags
+}
+
+func expandResourceProviderMaxConcurrentRequests(input map[string]interface{}) map[string]int {
+	output := make(map[string]int)
+	for k, v := range input {
+		output[k] = v.(int)
+	}
+	return output
+}
+
+func expandStorageEmulator(input []interface{}) *common.StorageEmulator {
+	if len(input) == 0 {
+		return nil
+	}
+
+	// an empty `storage_emulator` block uses the defaults for Azurite
+	output := common.StorageEmulator{
+		AccountName:   common.StorageEmulatorDefaultAccountName,
+		AccountKey:    common.StorageEmulatorDefaultAccountKey,
+		BlobEndpoint:  common.StorageEmulatorDefaultBlobEndpoint,
+		QueueEndpoint: common.StorageEmulatorDefaultQueueEndpoint,
+		TableEndpoint: common.StorageEmulatorDefaultTableEndpoint,
+	}
+	if raw, ok := input[0].(map[string]interface{}); ok {
+		if v := raw["account_name"].(string); v != "" {
+			output.AccountName = v
+		}
+		if v := raw["account_key"].(string); v != "" {
+			output.AccountKey = v
+		}
+		if v := raw["blob_endpoint"].(string); v != "" {
+			output.BlobEndpoint = v
+		}
+		if v := raw["queue_endpoint"].(string); v != "" {
+			output.QueueEndpoint = v
+		}
+		if v := raw["table_endpoint"].(string); v != "" {
+			output.TableEndpoint = v
+		}
 	}
 
-	return client, nil
+	return &output
 }
 
 const resourceProviderRegistrationErrorFmt = `Error ensuring Resource Providers are registered.
@@ -493,7 +696,8 @@ Terraform automatically attempts to register the Resource Providers it supports
 ensure it's able to provision resources.
 
 If you don't have permission to register Resource Providers you may wish to use the
-"skip_provider_registration" flag in the Provider block to disable this functionality.
+"resource_provider_registrations" field in the Provider block to register a smaller set
+of Resource Providers (for example "core"), or set it to "none" to disable this functionality.
 
 Please note that if you opt out of Resource Provider Registration and Terraform tries
 to provision a resource from a Resource Provider which is unregistered, then the errors
@@ -504,7 +708,7 @@ may appear misleading - for example:
 Could indicate either that the Resource Provider "Microsoft.Foo" requires registration,
 but this could also indicate that this Azure Region doesn't support this API version.
 
-More information on the "skip_provider_registration" flag can be found here:
-https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs#skip_provider_registration
+More information on the "resource_provider_registrations" field can be found here:
+https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs#resource_provider_registrations
 
 Original Error: %s`
@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -121,6 +123,68 @@ func TestProvider_counts(t *testing.T) {
 	log.Printf("Total:        %d", len(provider.ResourcesMap)+len(provider.DataSourcesMap))
 }
 
+func TestProvider_storageEmulator(t *testing.T) {
+	logging.SetOutput(t)
+
+	provider := TestAzureProvider()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// no requests are made to Azure when using a Storage Emulator, so this doesn't require credentials
+	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"storage_emulator": []interface{}{
+			map[string]interface{}{
+				"blob_endpoint": "http://azurite:10000",
+			},
+		},
+	}))
+	if d != nil && d.HasError() {
+		t.Fatalf("err: %+v", d)
+	}
+
+	client := provider.Meta().(*clients.Client)
+	account, err := client.Storage.FindAccount(ctx, client.Account.SubscriptionId, "devstoreaccount1")
+	if err != nil {
+		t.Fatalf("finding the emulated Storage Account: %+v", err)
+	}
+	if account.StorageAccountId.StorageAccountName != "devstoreaccount1" {
+		t.Fatalf("expected the Storage Account Name to be %q but got %q", "devstoreaccount1", account.StorageAccountId.StorageAccountName)
+	}
+
+	if _, err := client.Storage.FindAccount(ctx, client.Account.SubscriptionId, "other"); err == nil {
+		t.Fatalf("expected an error finding a Storage Account which isn't within the Storage Emulator but didn't get one")
+	}
+
+	// requests to Resource Manager fail, rather than being sent to Azure
+	if _, err := client.Resource.ResourceGroupsClient.Get(ctx, commonids.NewResourceGroupID(client.Account.SubscriptionId, "example")); err == nil {
+		t.Fatalf("expected an error making a request to Resource Manager but didn't get one")
+	}
+}
+
+func TestProvider_resourceProvidersToRegisterIgnoredWarning(t *testing.T) {
+	logging.SetOutput(t)
+
+	provider := TestAzureProvider()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	// Resource Providers aren't registered when using a Storage Emulator, so a warning is expected
+	d := provider.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"resource_providers_to_register": []interface{}{"Microsoft.Kusto"},
+		"storage_emulator": []interface{}{
+			map[string]interface{}{
+				"blob_endpoint": "http://azurite:10000",
+			},
+		},
+	}))
+	if d.HasError() {
+		t.Fatalf("err: %+v", d)
+	}
+	if len(d) != 1 || d[0].Severity != diag.Warning || !strings.Contains(d[0].Detail, "Microsoft.Kusto") {
+		t.Fatalf("expected a warning that `resource_providers_to_register` is ignored but got %+v", d)
+	}
+}
+
 func TestAccProvider_cliAuth(t *testing.T) {
 	if os.Getenv("TF_ACC") == "" {
 		t.Skip("TF_ACC not set")
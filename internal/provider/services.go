@@ -224,6 +224,11 @@ func SupportedTypedServices() []sdk.TypedServiceRegistration {
 	return services
 }
 
+func SupportedFrameworkServices() []sdk.FrameworkServiceRegistration {
+	services := []sdk.FrameworkServiceRegistration{}
+	return services
+}
+
 func SupportedUntypedServices() []sdk.UntypedServiceRegistration {
 	return func() []sdk.UntypedServiceRegistration {
 		out := []sdk.UntypedServiceRegistration{
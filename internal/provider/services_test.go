@@ -40,6 +40,39 @@ func TestTypedResourcesContainValidModelObjects(t *testing.T) {
 	}
 }
 
+func TestTypedResourcesModelObjectsMatchSchema(t *testing.T) {
+	// This test confirms that the `tfschema` struct tags on the Model Object for each Typed Resource match the
+	// Schema (both that the field exists and is of the same type), since otherwise Encode/Decode fail at runtime.
+	//
+	// Fields which are defined in the Schema but not in the Model Object are logged, since these are
+	// intentional in some cases (for example where the field is read from the ResourceData directly)
+	failures := 0
+	for _, service := range SupportedTypedServices() {
+		for _, resource := range service.Resources() {
+			schema := make(map[string]*pluginsdk.Schema)
+			for k, v := range resource.Arguments() {
+				schema[k] = v
+			}
+			for k, v := range resource.Attributes() {
+				schema[k] = v
+			}
+
+			mismatches := sdk.CompareModelObjectWithSchema(resource.ModelObject(), schema)
+			for _, warning := range mismatches.Warnings {
+				t.Logf("[DEBUG] %s: %s", resource.ResourceType(), warning)
+			}
+			for _, err := range mismatches.Errors {
+				t.Errorf("%s: %s", resource.ResourceType(), err)
+				failures++
+			}
+		}
+	}
+
+	if failures > 0 {
+		t.Fatalf("%d fields within the Model Objects don't match the Schema", failures)
+	}
+}
+
 func TestTypedResourcesContainValidIDParsers(t *testing.T) {
 	// This test confirms that all of the Typed Resources return an ID Validation method
 	// which is used to ensure that each of the resources will validate the Resource ID
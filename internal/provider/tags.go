@@ -0,0 +1,321 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	resourceTags "github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/tags"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func schemaDefaultTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"tags": {
+					Type:         pluginsdk.TypeMap,
+					Required:     true,
+					ValidateFunc: tags.Validate,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+					Description: "A mapping of tags which should be assigned to every Resource which supports Tags.",
+				},
+			},
+		},
+		Description: "Configuration for the Tags which should be assigned to every Resource which supports Tags.",
+	}
+}
+
+func schemaIgnoreTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"keys": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+					Description: "A list of Tag keys which should be ignored when reading Resources.",
+				},
+
+				"key_prefixes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+					Description: "A list of Tag key prefixes which should be ignored when reading Resources.",
+				},
+			},
+		},
+		Description: "Configuration for the Tags which should be ignored when reading every Resource which supports Tags.",
+	}
+}
+
+func expandProviderTags(defaultTags []interface{}, ignoreTags []interface{}) tags.ProviderTags {
+	output := tags.ProviderTags{
+		DefaultTags:       make(map[string]string),
+		IgnoreKeys:        make([]string, 0),
+		IgnoreKeyPrefixes: make([]string, 0),
+	}
+
+	if len(defaultTags) > 0 && defaultTags[0] != nil {
+		raw := defaultTags[0].(map[string]interface{})
+		for k, v := range raw["tags"].(map[string]interface{}) {
+			output.DefaultTags[k] = v.(string)
+		}
+	}
+
+	if len(ignoreTags) > 0 && ignoreTags[0] != nil {
+		raw := ignoreTags[0].(map[string]interface{})
+		for _, v := range raw["keys"].(*pluginsdk.Set).List() {
+			output.IgnoreKeys = append(output.IgnoreKeys, v.(string))
+		}
+		for _, v := range raw["key_prefixes"].(*pluginsdk.Set).List() {
+			output.IgnoreKeyPrefixes = append(output.IgnoreKeyPrefixes, v.(string))
+		}
+	}
+
+	return output
+}
+
+// supportsProviderTags returns whether the `default_tags` and `ignore_tags` configured in the Provider block
+// can be applied to this Resource - which requires a top-level `tags` field that can be updated in-place.
+func supportsProviderTags(resource *schema.Resource) bool {
+	v, ok := resource.Schema["tags"]
+	if !ok || v.Type != schema.TypeMap || !v.Optional || v.Computed || v.ForceNew {
+		return false
+	}
+
+	if _, exists := resource.Schema["tags_all"]; exists {
+		return false
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	return resource.Update != nil || resource.UpdateContext != nil //nolint:staticcheck
+}
+
+// applyProviderTags wraps the Create, Read and Update functions of a Resource supporting Tags so that
+// the `default_tags` configured in the Provider block are merged into the Tags sent to Azure, and any
+// Tags matching `ignore_tags` are filtered out when the Resource is read.
+//
+// The effective set of Tags is exposed in the computed `tags_all` field, whilst the `tags` field
+// continues to contain only the Tags defined on the Resource itself.
+func applyProviderTags(resource *schema.Resource) {
+	if !supportsProviderTags(resource) {
+		return
+	}
+
+	resource.Schema["tags_all"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	create := contextFunc(resource.Create, resource.CreateContext) //nolint:staticcheck
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	read := contextFunc(resource.Read, resource.ReadContext) //nolint:staticcheck
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	update := contextFunc(resource.Update, resource.UpdateContext) //nolint:staticcheck
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	resource.Create, resource.Read, resource.Update = nil, nil, nil //nolint:staticcheck
+
+	resource.CreateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		providerTags := meta.(*clients.Client).Tags
+		configuredTags := d.Get("tags").(map[string]interface{})
+		if err := d.Set("tags", providerTags.Merge(configuredTags)); err != nil {
+			return diag.Errorf("setting `tags`: %+v", err)
+		}
+
+		diags := create(ctx, d, meta)
+		if d.Id() != "" {
+			diags = append(diags, flattenProviderTags(d, providerTags, configuredTags)...)
+		}
+		return diags
+	}
+
+	resource.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		providerTags := meta.(*clients.Client).Tags
+		existingTags := d.Get("tags").(map[string]interface{})
+
+		diags := read(ctx, d, meta)
+		if d.Id() != "" && !diags.HasError() {
+			diags = append(diags, flattenProviderTags(d, providerTags, existingTags)...)
+		}
+		return diags
+	}
+
+	resource.UpdateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		providerTags := meta.(*clients.Client).Tags
+		configuredTags := d.Get("tags").(map[string]interface{})
+		if err := d.Set("tags", providerTags.Merge(configuredTags)); err != nil {
+			return diag.Errorf("setting `tags`: %+v", err)
+		}
+
+		diags := update(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+
+		// when only the `default_tags` have changed the Resource won't send the Tags, so update them directly
+		if !d.HasChange("tags") && d.HasChange("tags_all") {
+			if err := updateProviderTags(ctx, d, meta, providerTags); err != nil {
+				return append(diags, diag.FromErr(err)...)
+			}
+
+			diags = append(diags, read(ctx, d, meta)...)
+			if diags.HasError() {
+				return diags
+			}
+		}
+
+		return append(diags, flattenProviderTags(d, providerTags, configuredTags)...)
+	}
+
+	existingCustomizeDiff := resource.CustomizeDiff
+	if existingCustomizeDiff == nil {
+		resource.CustomizeDiff = pluginsdk.CustomizeDiffShim(customizeDiffProviderTags)
+	} else {
+		resource.CustomizeDiff = pluginsdk.CustomDiffInSequence(existingCustomizeDiff, customizeDiffProviderTags)
+	}
+}
+
+func contextFunc(legacy func(*schema.ResourceData, interface{}) error, withContext func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if withContext != nil {
+		return withContext
+	}
+
+	return func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return diag.FromErr(legacy(d, meta))
+	}
+}
+
+// flattenProviderTags sets `tags_all` to the Tags returned from Azure (excluding any ignored Tags which aren't
+// configured), and `tags` to the same set excluding any `default_tags` which aren't defined on the Resource itself.
+func flattenProviderTags(d *schema.ResourceData, providerTags tags.ProviderTags, resourceTags map[string]interface{}) diag.Diagnostics {
+	tagsAll := providerTags.FilterRemote(d.Get("tags").(map[string]interface{}), providerTags.Merge(resourceTags))
+	if err := d.Set("tags_all", tagsAll); err != nil {
+		return diag.Errorf("setting `tags_all`: %+v", err)
+	}
+	if err := d.Set("tags", providerTags.ResourceTags(tagsAll, resourceTags)); err != nil {
+		return diag.Errorf("setting `tags`: %+v", err)
+	}
+	return nil
+}
+
+func customizeDiffProviderTags(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*clients.Client)
+	if !ok || client == nil {
+		return nil
+	}
+
+	// a map which is unknown is only reported as computed via its count, so both are checked
+	if !d.NewValueKnown("tags") || !d.NewValueKnown("tags.%") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	tagsAll := client.Tags.Merge(d.Get("tags").(map[string]interface{}))
+	if d.Id() != "" {
+		if existing, _ := d.GetChange("tags_all"); reflect.DeepEqual(existing, tagsAll) {
+			return nil
+		}
+
+		// when the `tags` defined on the Resource haven't changed the Resource's own Update won't send the Tags, as such
+		// changes to the `default_tags` can only be applied using the Tags API - otherwise these are applied by the
+		// Resource's own Update the next time the `tags` change
+		if !d.HasChange("tags") && !tagsAPISupportedFor(d.Id()) {
+			log.Printf("[DEBUG] Deferring updating the Provider Tags for %q since the Tags API isn't supported for this Resource", d.Id())
+			return nil
+		}
+	}
+
+	return d.SetNew("tags_all", tagsAll)
+}
+
+// tagsAPISupportedFor returns whether the Tags for the Resource can be updated using the Tags API, which is supported
+// for Resource Groups and the top-level Resources within them - but not for every nested (child) Resource
+func tagsAPISupportedFor(id string) bool {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	if len(segments) < 4 || !strings.EqualFold(segments[0], "subscriptions") || !strings.EqualFold(segments[2], "resourceGroups") {
+		return false
+	}
+
+	switch len(segments) {
+	case 4:
+		return true
+	case 8:
+		return strings.EqualFold(segments[4], "providers")
+	}
+
+	return false
+}
+
+// updateProviderTags updates the Tags on the Resource using the Tags API, which is used when the `default_tags`
+// within the Provider block have changed but the `tags` defined on the Resource itself have not.
+func updateProviderTags(ctx context.Context, d *schema.ResourceData, meta interface{}, providerTags tags.ProviderTags) error {
+	if !tagsAPISupportedFor(d.Id()) {
+		log.Printf("[DEBUG] Skipping updating the Provider Tags for %q since the Tags API isn't supported for this Resource", d.Id())
+		return nil
+	}
+
+	client := meta.(*clients.Client).Resource.TagsClient
+	id := commonids.NewScopeID(d.Id())
+
+	oldRaw, newRaw := d.GetChange("tags_all")
+	oldTags := oldRaw.(map[string]interface{})
+	newTags := newRaw.(map[string]interface{})
+
+	if len(newTags) > 0 {
+		payload := resourceTags.TagsPatchResource{
+			Operation: pointer.To(resourceTags.TagsPatchOperationMerge),
+			Properties: &resourceTags.Tags{
+				Tags: pointer.To(tags.ToTypedObject(tags.Expand(newTags))),
+			},
+		}
+		if _, err := client.UpdateAtScope(ctx, id, payload); err != nil {
+			return fmt.Errorf("updating Tags for %s: %+v", id, err)
+		}
+	}
+
+	if removed := providerTags.RemovedKeys(oldTags, newTags); len(removed) > 0 {
+		toRemove := make(map[string]string)
+		for _, k := range removed {
+			toRemove[k] = oldTags[k].(string)
+		}
+		payload := resourceTags.TagsPatchResource{
+			Operation: pointer.To(resourceTags.TagsPatchOperationDelete),
+			Properties: &resourceTags.Tags{
+				Tags: &toRemove,
+			},
+		}
+		if _, err := client.UpdateAtScope(ctx, id, payload); err != nil {
+			return fmt.Errorf("removing Tags from %s: %+v", id, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,528 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+)
+
+const testProviderTagsResourceId = "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Example/things/thing1"
+
+func TestProviderTagsCreate(t *testing.T) {
+	testData := []struct {
+		Name            string
+		ProviderTags    tags.ProviderTags
+		Configured      map[string]interface{}
+		ExpectedSent    map[string]interface{}
+		ExpectedTags    map[string]interface{}
+		ExpectedTagsAll map[string]interface{}
+	}{
+		{
+			Name:         "No Provider Tags",
+			ProviderTags: tags.ProviderTags{},
+			Configured: map[string]interface{}{
+				"environment": "production",
+			},
+			ExpectedSent: map[string]interface{}{
+				"environment": "production",
+			},
+			ExpectedTags: map[string]interface{}{
+				"environment": "production",
+			},
+			ExpectedTagsAll: map[string]interface{}{
+				"environment": "production",
+			},
+		},
+		{
+			Name: "Default Tags are Merged",
+			ProviderTags: tags.ProviderTags{
+				DefaultTags: map[string]string{
+					"cost-center": "12345",
+					"owner":       "platform",
+				},
+			},
+			Configured: map[string]interface{}{
+				"Owner": "networking",
+			},
+			ExpectedSent: map[string]interface{}{
+				"cost-center": "12345",
+				"Owner":       "networking",
+			},
+			ExpectedTags: map[string]interface{}{
+				"Owner": "networking",
+			},
+			ExpectedTagsAll: map[string]interface{}{
+				"cost-center": "12345",
+				"Owner":       "networking",
+			},
+		},
+		{
+			Name: "Configured Tags matching an Ignore Rule are Sent",
+			ProviderTags: tags.ProviderTags{
+				DefaultTags: map[string]string{
+					"cost-center": "12345",
+				},
+				IgnoreKeys:        []string{"created-by"},
+				IgnoreKeyPrefixes: []string{"hidden-"},
+			},
+			Configured: map[string]interface{}{
+				"created-by":   "terraform",
+				"hidden-title": "example",
+			},
+			ExpectedSent: map[string]interface{}{
+				"cost-center":  "12345",
+				"created-by":   "terraform",
+				"hidden-title": "example",
+			},
+			ExpectedTags: map[string]interface{}{
+				"created-by":   "terraform",
+				"hidden-title": "example",
+			},
+			ExpectedTagsAll: map[string]interface{}{
+				"cost-center":  "12345",
+				"created-by":   "terraform",
+				"hidden-title": "example",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		remote := make(map[string]interface{})
+		resource := testProviderTagsResource(&remote)
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+			"name": "example",
+			"tags": v.Configured,
+		})
+
+		if diags := resource.CreateContext(context.TODO(), d, &clients.Client{Tags: v.ProviderTags}); diags.HasError() {
+			t.Fatalf("creating: %+v", diags)
+		}
+
+		if !reflect.DeepEqual(remote, v.ExpectedSent) {
+			t.Fatalf("expected the Tags %+v to be sent but got %+v", v.ExpectedSent, remote)
+		}
+		if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, v.ExpectedTags) {
+			t.Fatalf("expected `tags` to be %+v but got %+v", v.ExpectedTags, actual)
+		}
+		if actual := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(actual, v.ExpectedTagsAll) {
+			t.Fatalf("expected `tags_all` to be %+v but got %+v", v.ExpectedTagsAll, actual)
+		}
+	}
+}
+
+func TestProviderTagsRead(t *testing.T) {
+	providerTags := tags.ProviderTags{
+		DefaultTags: map[string]string{
+			"cost-center": "12345",
+		},
+		IgnoreKeys:        []string{"created-by"},
+		IgnoreKeyPrefixes: []string{"hidden-"},
+	}
+
+	testData := []struct {
+		Name            string
+		StateTags       map[string]string
+		Remote          map[string]interface{}
+		ExpectedTags    map[string]interface{}
+		ExpectedTagsAll map[string]interface{}
+	}{
+		{
+			Name: "Default Tags aren't set into Tags",
+			StateTags: map[string]string{
+				"environment": "production",
+			},
+			Remote: map[string]interface{}{
+				"cost-center": "12345",
+				"environment": "production",
+			},
+			ExpectedTags: map[string]interface{}{
+				"environment": "production",
+			},
+			ExpectedTagsAll: map[string]interface{}{
+				"cost-center": "12345",
+				"environment": "production",
+			},
+		},
+		{
+			Name: "Default Tags overridden on the Resource are set into Tags",
+			StateTags: map[string]string{
+				"cost-center": "67890",
+			},
+			Remote: map[string]interface{}{
+				"cost-center": "67890",
+			},
+			ExpectedTags: map[string]interface{}{
+				"cost-center": "67890",
+			},
+			ExpectedTagsAll: map[string]interface{}{
+				"cost-center": "67890",
+			},
+		},
+		{
+			Name: "Ignored Tags added outside of Terraform are Removed",
+			StateTags: map[string]string{
+				"environment": "production",
+			},
+			Remote: map[string]interface{}{
+				"cost-center":  "12345",
+				"created-by":   "policy",
+				"environment":  "production",
+				"hidden-title": "example",
+			},
+			ExpectedTags: map[string]interface{}{
+				"environment": "production",
+			},
+			ExpectedTagsAll: map[string]interface{}{
+				"cost-center": "12345",
+				"environment": "production",
+			},
+		},
+		{
+			Name: "Ignored Tags defined on the Resource are Retained",
+			StateTags: map[string]string{
+				"hidden-title": "example",
+			},
+			Remote: map[string]interface{}{
+				"cost-center":  "12345",
+				"created-by":   "policy",
+				"hidden-title": "example",
+			},
+			ExpectedTags: map[string]interface{}{
+				"hidden-title": "example",
+			},
+			ExpectedTagsAll: map[string]interface{}{
+				"cost-center":  "12345",
+				"hidden-title": "example",
+			},
+		},
+		{
+			Name: "Tags added outside of Terraform are set into Tags",
+			StateTags: map[string]string{
+				"environment": "production",
+			},
+			Remote: map[string]interface{}{
+				"cost-center": "12345",
+				"environment": "production",
+				"team":        "networking",
+			},
+			ExpectedTags: map[string]interface{}{
+				"environment": "production",
+				"team":        "networking",
+			},
+			ExpectedTagsAll: map[string]interface{}{
+				"cost-center": "12345",
+				"environment": "production",
+				"team":        "networking",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		remote := v.Remote
+		resource := testProviderTagsResource(&remote)
+		d := resource.Data(testProviderTagsState(testProviderTagsResourceId, v.StateTags, nil))
+
+		if diags := resource.ReadContext(context.TODO(), d, &clients.Client{Tags: providerTags}); diags.HasError() {
+			t.Fatalf("reading: %+v", diags)
+		}
+
+		if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, v.ExpectedTags) {
+			t.Fatalf("expected `tags` to be %+v but got %+v", v.ExpectedTags, actual)
+		}
+		if actual := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(actual, v.ExpectedTagsAll) {
+			t.Fatalf("expected `tags_all` to be %+v but got %+v", v.ExpectedTagsAll, actual)
+		}
+	}
+}
+
+func TestProviderTagsCustomizeDiff(t *testing.T) {
+	childResourceId := testProviderTagsResourceId + "/children/child1"
+
+	testData := []struct {
+		Name         string
+		ProviderTags tags.ProviderTags
+		State        *terraform.InstanceState
+		Configured   cty.Value
+
+		// ExpectComputed is whether `tags_all` is expected to be known after apply (via SetNewComputed)
+		ExpectComputed bool
+
+		// ExpectedChanges are the values within `tags_all` which are expected to change (via SetNew)
+		ExpectedChanges map[string]string
+	}{
+		{
+			Name: "New Resource with Known Tags",
+			ProviderTags: tags.ProviderTags{
+				DefaultTags: map[string]string{
+					"cost-center": "12345",
+				},
+			},
+			Configured: cty.MapVal(map[string]cty.Value{
+				"environment": cty.StringVal("production"),
+			}),
+			ExpectedChanges: map[string]string{
+				"cost-center": "12345",
+				"environment": "production",
+			},
+		},
+		{
+			Name: "New Resource with Unknown Tags",
+			ProviderTags: tags.ProviderTags{
+				DefaultTags: map[string]string{
+					"cost-center": "12345",
+				},
+			},
+			Configured:     cty.UnknownVal(cty.Map(cty.String)),
+			ExpectComputed: true,
+		},
+		{
+			Name: "Existing Resource with no Changes",
+			ProviderTags: tags.ProviderTags{
+				DefaultTags: map[string]string{
+					"cost-center": "12345",
+				},
+			},
+			State: testProviderTagsState(testProviderTagsResourceId, map[string]string{
+				"environment": "production",
+			}, map[string]string{
+				"cost-center": "12345",
+				"environment": "production",
+			}),
+			Configured: cty.MapVal(map[string]cty.Value{
+				"environment": cty.StringVal("production"),
+			}),
+		},
+		{
+			Name: "Existing Resource with Changed Default Tags",
+			ProviderTags: tags.ProviderTags{
+				DefaultTags: map[string]string{
+					"cost-center": "67890",
+				},
+			},
+			State: testProviderTagsState(testProviderTagsResourceId, map[string]string{
+				"environment": "production",
+			}, map[string]string{
+				"cost-center": "12345",
+				"environment": "production",
+			}),
+			Configured: cty.MapVal(map[string]cty.Value{
+				"environment": cty.StringVal("production"),
+			}),
+			ExpectedChanges: map[string]string{
+				"cost-center": "67890",
+			},
+		},
+		{
+			Name: "Existing Nested Resource with Changed Default Tags",
+			ProviderTags: tags.ProviderTags{
+				DefaultTags: map[string]string{
+					"cost-center": "67890",
+				},
+			},
+			State: testProviderTagsState(childResourceId, map[string]string{
+				"environment": "production",
+			}, map[string]string{
+				"cost-center": "12345",
+				"environment": "production",
+			}),
+			Configured: cty.MapVal(map[string]cty.Value{
+				"environment": cty.StringVal("production"),
+			}),
+		},
+		{
+			Name: "Existing Nested Resource with Changed Default Tags and Tags",
+			ProviderTags: tags.ProviderTags{
+				DefaultTags: map[string]string{
+					"cost-center": "67890",
+				},
+			},
+			State: testProviderTagsState(childResourceId, map[string]string{
+				"environment": "production",
+			}, map[string]string{
+				"cost-center": "12345",
+				"environment": "production",
+			}),
+			Configured: cty.MapVal(map[string]cty.Value{
+				"environment": cty.StringVal("staging"),
+			}),
+			ExpectedChanges: map[string]string{
+				"cost-center": "67890",
+				"environment": "staging",
+			},
+		},
+		{
+			Name: "Existing Resource with Unknown Tags",
+			ProviderTags: tags.ProviderTags{
+				DefaultTags: map[string]string{
+					"cost-center": "12345",
+				},
+			},
+			State: testProviderTagsState(testProviderTagsResourceId, map[string]string{
+				"environment": "production",
+			}, map[string]string{
+				"cost-center": "12345",
+				"environment": "production",
+			}),
+			Configured:     cty.UnknownVal(cty.Map(cty.String)),
+			ExpectComputed: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		remote := make(map[string]interface{})
+		resource := testProviderTagsResource(&remote)
+		config := terraform.NewResourceConfigShimmed(cty.ObjectVal(map[string]cty.Value{
+			"id":       cty.NullVal(cty.String),
+			"name":     cty.StringVal("example"),
+			"tags":     v.Configured,
+			"tags_all": cty.NullVal(cty.Map(cty.String)),
+		}), resource.CoreConfigSchema())
+
+		diff, err := resource.Diff(context.TODO(), v.State, config, &clients.Client{Tags: v.ProviderTags})
+		if err != nil {
+			t.Fatalf("diffing: %+v", err)
+		}
+
+		computed := false
+		changes := make(map[string]string)
+		if diff != nil {
+			for k, attr := range diff.Attributes {
+				if k == "tags_all.%" {
+					computed = attr.NewComputed
+					continue
+				}
+				if key, ok := strings.CutPrefix(k, "tags_all."); ok && !attr.NewRemoved {
+					changes[key] = attr.New
+				}
+			}
+		}
+
+		if computed != v.ExpectComputed {
+			t.Fatalf("expected `tags_all` to be computed to be %t but got %t", v.ExpectComputed, computed)
+		}
+		if len(changes) != len(v.ExpectedChanges) || (len(changes) > 0 && !reflect.DeepEqual(changes, v.ExpectedChanges)) {
+			t.Fatalf("expected the changes to `tags_all` to be %+v but got %+v", v.ExpectedChanges, changes)
+		}
+	}
+}
+
+func TestTagsAPISupportedFor(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected bool
+	}{
+		{
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012",
+			Expected: false,
+		},
+		{
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			Expected: true,
+		},
+		{
+			Input:    testProviderTagsResourceId,
+			Expected: true,
+		},
+		{
+			Input:    "/SUBSCRIPTIONS/12345678-1234-9876-4563-123456789012/RESOURCEGROUPS/group1/PROVIDERS/Microsoft.Example/things/thing1",
+			Expected: true,
+		},
+		{
+			Input:    testProviderTagsResourceId + "/children/child1",
+			Expected: false,
+		},
+		{
+			Input:    "https://account1.blob.core.windows.net/container1",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		if actual := tagsAPISupportedFor(v.Input); actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+// testProviderTagsResource returns a Resource supporting Tags with the Provider Tags applied, which stores the
+// Tags sent when creating the Resource within remote - and returns the Tags within remote when it's read
+func testProviderTagsResource(remote *map[string]interface{}) *schema.Resource {
+	read := func(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+		return diag.FromErr(d.Set("tags", *remote))
+	}
+
+	resource := &schema.Resource{
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			*remote = d.Get("tags").(map[string]interface{})
+			d.SetId(testProviderTagsResourceId)
+			return read(ctx, d, meta)
+		},
+		ReadContext: read,
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			return read(ctx, d, meta)
+		},
+		DeleteContext: func(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			return nil
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+	applyProviderTags(resource)
+
+	return resource
+}
+
+func testProviderTagsState(id string, resourceTags map[string]string, tagsAll map[string]string) *terraform.InstanceState {
+	attributes := map[string]string{
+		"id":   id,
+		"name": "example",
+	}
+	for prefix, values := range map[string]map[string]string{"tags": resourceTags, "tags_all": tagsAll} {
+		if values == nil {
+			continue
+		}
+		attributes[prefix+".%"] = strconv.Itoa(len(values))
+		for k, v := range values {
+			attributes[prefix+"."+k] = v
+		}
+	}
+
+	return &terraform.InstanceState{
+		ID:         id,
+		Attributes: attributes,
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
+)
+
+// applyTracing creates a span for each Create, Read, Update and Delete operation of an untyped Resource or
+// Data Source - the Typed Resources and Data Sources create these spans within the SDK.
+func applyTracing(resourceType string, resource *schema.Resource) {
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	create, read, update, del := resource.Create, resource.Read, resource.Update, resource.Delete //nolint:staticcheck
+
+	if create != nil || resource.CreateContext != nil {
+		resource.CreateContext = tracingFunc(resourceType, tracing.OperationCreate, contextFunc(create, resource.CreateContext))
+	}
+	if read != nil || resource.ReadContext != nil {
+		resource.ReadContext = tracingFunc(resourceType, tracing.OperationRead, contextFunc(read, resource.ReadContext))
+	}
+	if update != nil || resource.UpdateContext != nil {
+		resource.UpdateContext = tracingFunc(resourceType, tracing.OperationUpdate, contextFunc(update, resource.UpdateContext))
+	}
+	if del != nil || resource.DeleteContext != nil {
+		resource.DeleteContext = tracingFunc(resourceType, tracing.OperationDelete, contextFunc(del, resource.DeleteContext))
+	}
+
+	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+	resource.Create, resource.Read, resource.Update, resource.Delete = nil, nil, nil, nil //nolint:staticcheck
+}
+
+func tracingFunc(resourceType, operation string, in func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx, span := tracing.StartOperationSpan(ctx, resourceType, operation, d.Id())
+
+		// the untyped Resources build the context for their API calls from the Provider's StopContext,
+		// so the span is made available to them when the timeout for the operation is applied
+		untrack := tracing.TrackResourceData(ctx, d)
+		defer untrack()
+
+		diags := in(ctx, d, meta)
+		tracing.EndSpan(span, diagnosticsError(diags))
+		return diags
+	}
+}
+
+func diagnosticsError(diags diag.Diagnostics) error {
+	for _, v := range diags {
+		if v.Severity == diag.Error {
+			return errors.New(v.Summary)
+		}
+	}
+
+	return nil
+}
@@ -47,6 +47,24 @@ func populateCache(ctx context.Context, client *providers.ProvidersClient, subsc
 	cacheLock.Lock()
 	defer cacheLock.Unlock()
 
+	return populateCacheLocked(ctx, client, subscriptionId)
+}
+
+// populateCacheIfRequired populates the cache when it hasn't already been populated, checking this whilst holding
+// the cache lock so that concurrent callers only populate the cache once
+func populateCacheIfRequired(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId) error {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	if registeredResourceProviders != nil && unregisteredResourceProviders != nil {
+		return nil
+	}
+
+	return populateCacheLocked(ctx, client, subscriptionId)
+}
+
+// populateCacheLocked populates the cache, the cache lock must be held by the caller
+func populateCacheLocked(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId) error {
 	providers, err := client.ListComplete(ctx, subscriptionId, providers.DefaultListOperationOptions())
 	if err != nil {
 		return fmt.Errorf("listing Resource Providers: %+v", err)
@@ -90,3 +90,73 @@ func registerWithSubscription(ctx context.Context, client *providers.ProvidersCl
 
 	return nil
 }
+
+// registrationLocks ensures that each Resource Provider is only registered once when registering on-demand
+var registrationLocks = map[string]*sync.Mutex{}
+
+// RegisterIfRequired registers the specified Resource Provider on-demand (for example, the first time that a Resource
+// from this Resource Provider is created) when it's known to be unregistered in the Subscription
+func RegisterIfRequired(ctx context.Context, client *providers.ProvidersClient, subscriptionId commonids.SubscriptionId, providerName string) error {
+	if err := populateCacheIfRequired(ctx, client, subscriptionId); err != nil {
+		return fmt.Errorf("populating Resource Provider cache: %+v", err)
+	}
+
+	name, requiresRegistration := unregisteredResourceProvider(providerName)
+	if !requiresRegistration {
+		return nil
+	}
+
+	lock := registrationLockFor(name)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// the Resource Provider may have been registered whilst waiting for the lock
+	if _, requiresRegistration = unregisteredResourceProvider(name); !requiresRegistration {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Registering Resource Provider %q on-demand", name)
+	if err := registerWithSubscription(ctx, client, subscriptionId, name); err != nil {
+		return err
+	}
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+	if registeredResourceProviders != nil && unregisteredResourceProviders != nil {
+		delete(*unregisteredResourceProviders, name)
+		(*registeredResourceProviders)[name] = struct{}{}
+	}
+
+	return nil
+}
+
+// unregisteredResourceProvider returns the name of the Resource Provider (as returned from the API) and
+// whether the cache indicates that it's unregistered, Resource Provider names are compared case-insensitively
+func unregisteredResourceProvider(providerName string) (string, bool) {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	if unregisteredResourceProviders == nil {
+		return providerName, false
+	}
+
+	for name := range *unregisteredResourceProviders {
+		if strings.EqualFold(name, providerName) {
+			return name, true
+		}
+	}
+
+	return providerName, false
+}
+
+func registrationLockFor(providerName string) *sync.Mutex {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	lock, ok := registrationLocks[providerName]
+	if !ok {
+		lock = &sync.Mutex{}
+		registrationLocks[providerName] = lock
+	}
+	return lock
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"fmt"
+)
+
+// RegistrationSet defines which of the Resource Providers supported by the AzureRM Provider
+// should be automatically registered when the Provider is configured
+type RegistrationSet string
+
+const (
+	// RegistrationSetNone registers no Resource Providers
+	RegistrationSetNone RegistrationSet = "none"
+
+	// RegistrationSetCore registers the Resource Providers required by the most commonly used Resources
+	RegistrationSetCore RegistrationSet = "core"
+
+	// RegistrationSetExtended registers all of the Resource Providers returned by Required
+	RegistrationSetExtended RegistrationSet = "extended"
+
+	// RegistrationSetAll registers every Resource Provider available in the Subscription
+	RegistrationSetAll RegistrationSet = "all"
+)
+
+func PossibleValuesForRegistrationSet() []string {
+	return []string{
+		string(RegistrationSetNone),
+		string(RegistrationSetCore),
+		string(RegistrationSetExtended),
+		string(RegistrationSetAll),
+	}
+}
+
+// Core returns the Resource Providers required by the most commonly used Resources
+func Core() map[string]struct{} {
+	// NOTE: Resource Providers in this list are case sensitive
+	return map[string]struct{}{
+		"Microsoft.Authorization":       {},
+		"Microsoft.Compute":             {},
+		"Microsoft.CostManagement":      {},
+		"Microsoft.KeyVault":            {},
+		"Microsoft.ManagedIdentity":     {},
+		"Microsoft.MarketplaceOrdering": {},
+		"Microsoft.Network":             {},
+		"Microsoft.OperationalInsights": {},
+		"Microsoft.PolicyInsights":      {},
+		"Microsoft.Resources":           {},
+		"Microsoft.Storage":             {},
+		"microsoft.insights":            {},
+	}
+}
+
+// ForRegistrationSet returns the Resource Providers which should be registered for the specified
+// Registration Set, including the additional Resource Providers specified.
+//
+// NOTE: the Resource Provider cache must be populated (via CacheSupportedProviders) when using RegistrationSetAll
+func ForRegistrationSet(set RegistrationSet, additional []string) (map[string]struct{}, error) {
+	output := make(map[string]struct{})
+
+	switch set {
+	case RegistrationSetNone:
+		// nothing to do
+
+	case RegistrationSetCore:
+		output = Core()
+
+	case RegistrationSetExtended:
+		output = Required()
+
+	case RegistrationSetAll:
+		cacheLock.Lock()
+		available := cachedResourceProviders
+		cacheLock.Unlock()
+		if available == nil {
+			return nil, fmt.Errorf("internal-error: the Resource Provider cache isn't populated")
+		}
+
+		output = Required()
+		for _, v := range *available {
+			output[v] = struct{}{}
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported Resource Provider Registration Set %q", string(set))
+	}
+
+	for _, v := range additional {
+		output[v] = struct{}{}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"testing"
+)
+
+func TestForRegistrationSet(t *testing.T) {
+	defer ClearCache()
+
+	testData := []struct {
+		Set        RegistrationSet
+		Additional []string
+		Cached     *[]string
+		Expected   []string
+		Unexpected []string
+		Error      bool
+	}{
+		{
+			Set:        RegistrationSetNone,
+			Additional: []string{"Microsoft.Example"},
+			Expected:   []string{"Microsoft.Example"},
+			Unexpected: []string{"Microsoft.Compute"},
+		},
+		{
+			Set:        RegistrationSetCore,
+			Expected:   []string{"Microsoft.Compute", "Microsoft.Network"},
+			Unexpected: []string{"Microsoft.Kusto"},
+		},
+		{
+			Set:      RegistrationSetExtended,
+			Expected: []string{"Microsoft.Compute", "Microsoft.Kusto"},
+		},
+		{
+			Set:   RegistrationSetAll,
+			Error: true,
+		},
+		{
+			Set:      RegistrationSetAll,
+			Cached:   &[]string{"Microsoft.Example", "Microsoft.Other"},
+			Expected: []string{"Microsoft.Compute", "Microsoft.Example", "Microsoft.Other"},
+		},
+		{
+			Set:   RegistrationSet("legacy"),
+			Error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", string(v.Set))
+
+		cachedResourceProviders = v.Cached
+		actual, err := ForRegistrationSet(v.Set, v.Additional)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+			t.Fatalf("unexpected error: %+v", err)
+		}
+		if v.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		for _, name := range v.Expected {
+			if _, ok := actual[name]; !ok {
+				t.Fatalf("expected %q to be registered", name)
+			}
+		}
+		for _, name := range v.Unexpected {
+			if _, ok := actual[name]; ok {
+				t.Fatalf("expected %q not to be registered", name)
+			}
+		}
+	}
+}
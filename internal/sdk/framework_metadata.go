@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// FrameworkResourceMetadata is intended to be embedded within Plugin Framework Data Sources
+// and Resources, providing access to the same Client used by the Plugin SDK Data Sources and
+// Resources.
+type FrameworkResourceMetadata struct {
+	// Client is a reference to the Azure Providers Client - providing a typed reference to this object
+	Client *clients.Client
+
+	// SubscriptionId is the Subscription ID which the Provider has been configured to use
+	SubscriptionId string
+}
+
+// Defaults populates the FrameworkResourceMetadata from the `ProviderData` made available to
+// the `Configure` method of a Plugin Framework Data Source or Resource.
+func (m *FrameworkResourceMetadata) Defaults(providerData interface{}, diags *diag.Diagnostics) {
+	// the Provider hasn't been configured yet, for example during validation - in which
+	// case the Configure method will be called again once it's available
+	if providerData == nil {
+		return
+	}
+
+	client, ok := providerData.(*clients.Client)
+	if !ok {
+		diags.AddError("Unexpected Provider Data", fmt.Sprintf("expected a *clients.Client but got %T", providerData))
+		return
+	}
+
+	m.Client = client
+	m.SubscriptionId = client.Account.SubscriptionId
+}
@@ -0,0 +1,297 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// schemaOptionsStructTag is the struct tag used to define the behaviour of a field when deriving
+// the Schema from a Model Object - for example:
+//
+//	Name string `tfschema:"name" tfschemaopts:"required,forcenew,validation=StringIsNotEmpty"`
+//
+// The supported options are `required`, `optional`, `computed`, `forcenew`, `sensitive`, `set`
+// (to use a Set rather than a List for a slice), `maxitems=N`, `minitems=N` and `validation=Name`
+// (where `Name` is a Validation Function registered using RegisterModelValidationFunc).
+const schemaOptionsStructTag = "tfschemaopts"
+
+var modelValidationFuncs = map[string]pluginsdk.SchemaValidateFunc{
+	"IsUUID":           validation.IsUUID,
+	"NoZeroValues":     validation.NoZeroValues,
+	"StringIsJSON":     validation.StringIsJSON,
+	"StringIsNotEmpty": validation.StringIsNotEmpty,
+}
+var modelValidationFuncsLock = &sync.RWMutex{}
+
+// RegisterModelValidationFunc registers a Validation Function which can be referenced by name using
+// `validation=Name` within the `tfschemaopts` struct tag
+func RegisterModelValidationFunc(name string, validateFunc pluginsdk.SchemaValidateFunc) {
+	modelValidationFuncsLock.Lock()
+	defer modelValidationFuncsLock.Unlock()
+	modelValidationFuncs[name] = validateFunc
+}
+
+type schemaOptions struct {
+	required  bool
+	optional  bool
+	computed  bool
+	forceNew  bool
+	sensitive bool
+	set       bool
+	maxItems  int
+	minItems  int
+
+	validateFunc pluginsdk.SchemaValidateFunc
+}
+
+// SchemaFromModelObject derives the Arguments and Attributes for a Typed Resource from the `tfschema`
+// and `tfschemaopts` struct tags defined on the Model Object. Fields which are `required` or `optional`
+// are returned as Arguments, with fields which are only `computed` returned as Attributes.
+func SchemaFromModelObject(input interface{}) (arguments map[string]*pluginsdk.Schema, attributes map[string]*pluginsdk.Schema, err error) {
+	if input == nil {
+		return nil, nil, fmt.Errorf("the model object was nil")
+	}
+
+	objType := reflect.TypeOf(input)
+	if objType.Kind() == reflect.Ptr {
+		objType = objType.Elem()
+	}
+	if objType.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("the model object must be a struct but got %s", objType.Kind())
+	}
+
+	fields, err := schemaFromModelRecursively("", objType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	arguments = make(map[string]*pluginsdk.Schema)
+	attributes = make(map[string]*pluginsdk.Schema)
+	for k, v := range fields {
+		if v.Required || v.Optional {
+			arguments[k] = v
+		} else {
+			attributes[k] = v
+		}
+	}
+
+	return arguments, attributes, nil
+}
+
+// ArgumentsFromModelObject returns the Arguments derived from the Model Object, for use within the `Arguments`
+// method of a Typed Resource - this panics if the Model Object is invalid, which is caught by the unit tests.
+func ArgumentsFromModelObject(input interface{}) map[string]*pluginsdk.Schema {
+	arguments, _, err := SchemaFromModelObject(input)
+	if err != nil {
+		panic(fmt.Sprintf("deriving the schema from the model object: %+v", err))
+	}
+	return arguments
+}
+
+// AttributesFromModelObject returns the Attributes derived from the Model Object, for use within the `Attributes`
+// method of a Typed Resource - this panics if the Model Object is invalid, which is caught by the unit tests.
+func AttributesFromModelObject(input interface{}) map[string]*pluginsdk.Schema {
+	_, attributes, err := SchemaFromModelObject(input)
+	if err != nil {
+		panic(fmt.Sprintf("deriving the schema from the model object: %+v", err))
+	}
+	return attributes
+}
+
+func schemaFromModelRecursively(prefix string, objType reflect.Type) (map[string]*pluginsdk.Schema, error) {
+	output := make(map[string]*pluginsdk.Schema)
+
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		fieldName := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, field.Name), ".")
+
+		structTags, err := parseStructTags(field.Tag)
+		if err != nil {
+			return nil, fmt.Errorf("parsing struct tags for %q: %+v", fieldName, err)
+		}
+		if structTags == nil {
+			return nil, fmt.Errorf("field %q is missing a struct tag for `tfschema`", fieldName)
+		}
+		if !fieldIsAvailable(structTags) {
+			continue
+		}
+
+		options, err := parseSchemaOptions(field.Tag)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the `%s` struct tag for %q: %+v", schemaOptionsStructTag, fieldName, err)
+		}
+
+		schema, err := schemaForType(fieldName, field.Type, *options)
+		if err != nil {
+			return nil, err
+		}
+
+		schema.Required = options.required
+		schema.Optional = options.optional
+		schema.Computed = options.computed
+		schema.ForceNew = options.forceNew
+		schema.Sensitive = options.sensitive
+		schema.ValidateFunc = options.validateFunc
+		output[structTags.hclPath] = schema
+	}
+
+	return output, nil
+}
+
+func schemaForType(fieldName string, fieldType reflect.Type, options schemaOptions) (*pluginsdk.Schema, error) {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if valueType, ok := primitiveValueType(fieldType); ok {
+		return &pluginsdk.Schema{
+			Type: valueType,
+		}, nil
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Map:
+		valueType, ok := primitiveValueType(fieldType.Elem())
+		if fieldType.Key().Kind() != reflect.String || !ok {
+			return nil, fmt.Errorf("field %q must be a map with string keys and primitive values but got %s", fieldName, fieldType)
+		}
+		return &pluginsdk.Schema{
+			Type: pluginsdk.TypeMap,
+			Elem: &pluginsdk.Schema{
+				Type: valueType,
+			},
+		}, nil
+
+	case reflect.Slice:
+		schema := &pluginsdk.Schema{
+			Type:     pluginsdk.TypeList,
+			MaxItems: options.maxItems,
+			MinItems: options.minItems,
+		}
+		if options.set {
+			schema.Type = pluginsdk.TypeSet
+		}
+
+		elemType := fieldType.Elem()
+		if valueType, ok := primitiveValueType(elemType); ok {
+			schema.Elem = &pluginsdk.Schema{
+				Type: valueType,
+			}
+			return schema, nil
+		}
+
+		if elemType.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("field %q must be a slice of primitives or structs but got %s", fieldName, fieldType)
+		}
+
+		nested, err := schemaFromModelRecursively(fieldName, elemType)
+		if err != nil {
+			return nil, err
+		}
+		schema.Elem = &pluginsdk.Resource{
+			Schema: nested,
+		}
+		return schema, nil
+	}
+
+	return nil, fmt.Errorf("field %q has the unsupported type %s", fieldName, fieldType)
+}
+
+func primitiveValueType(input reflect.Type) (pluginsdk.ValueType, bool) {
+	switch input.Kind() {
+	case reflect.Bool:
+		return pluginsdk.TypeBool, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return pluginsdk.TypeInt, true
+	case reflect.Float32, reflect.Float64:
+		return pluginsdk.TypeFloat, true
+	case reflect.String:
+		return pluginsdk.TypeString, true
+	}
+
+	return pluginsdk.TypeInvalid, false
+}
+
+func parseSchemaOptions(input reflect.StructTag) (*schemaOptions, error) {
+	output := &schemaOptions{}
+
+	tag, ok := input.Lookup(schemaOptionsStructTag)
+	if !ok || strings.TrimSpace(tag) == "" {
+		return nil, fmt.Errorf("the `%s` struct tag must specify one of `required`, `optional` or `computed`", schemaOptionsStructTag)
+	}
+
+	for _, item := range strings.Split(tag, ",") {
+		item = strings.TrimSpace(item)
+		key, value, hasValue := strings.Cut(item, "=")
+
+		switch strings.ToLower(key) {
+		case "required":
+			output.required = true
+		case "optional":
+			output.optional = true
+		case "computed":
+			output.computed = true
+		case "forcenew":
+			output.forceNew = true
+		case "sensitive":
+			output.sensitive = true
+		case "set":
+			output.set = true
+
+		case "maxitems", "minitems":
+			i, err := strconv.Atoi(value)
+			if !hasValue || err != nil {
+				return nil, fmt.Errorf("`%s` must be an integer but got %q", key, value)
+			}
+			if strings.EqualFold(key, "maxitems") {
+				output.maxItems = i
+			} else {
+				output.minItems = i
+			}
+
+		case "validation":
+			modelValidationFuncsLock.RLock()
+			validateFunc, exists := modelValidationFuncs[value]
+			modelValidationFuncsLock.RUnlock()
+			if !hasValue || !exists {
+				return nil, fmt.Errorf("the validation function %q has not been registered", value)
+			}
+			output.validateFunc = validateFunc
+
+		default:
+			return nil, fmt.Errorf("the option %q is not supported", item)
+		}
+	}
+
+	switch {
+	case output.required && (output.optional || output.computed):
+		return nil, fmt.Errorf("`required` cannot be combined with `optional` or `computed`")
+	case !output.required && !output.optional && !output.computed:
+		return nil, fmt.Errorf("one of `required`, `optional` or `computed` must be specified")
+	case output.forceNew && !output.required && !output.optional:
+		return nil, fmt.Errorf("`forcenew` can only be specified for `required` or `optional` fields")
+	}
+
+	return output, nil
+}
+
+// fieldIsAvailable returns whether the field is present in the current major version of the Provider
+func fieldIsAvailable(structTags *decodedStructTags) bool {
+	if structTags.removedInNextMajorVersion && features.FourPointOh() {
+		return false
+	}
+	if structTags.addedInNextMajorVersion && !features.FourPointOh() {
+		return false
+	}
+	return true
+}
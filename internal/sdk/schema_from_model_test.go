@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestSchemaFromModelObject(t *testing.T) {
+	type Rule struct {
+		Name     string   `tfschema:"name" tfschemaopts:"required"`
+		Priority int      `tfschema:"priority" tfschemaopts:"optional"`
+		Ports    []string `tfschema:"ports" tfschemaopts:"optional,set"`
+	}
+	type Model struct {
+		Name    string            `tfschema:"name" tfschemaopts:"required,forcenew,validation=StringIsNotEmpty"`
+		Enabled *bool             `tfschema:"enabled" tfschemaopts:"optional"`
+		Weight  float64           `tfschema:"weight" tfschemaopts:"optional,computed"`
+		Rules   []Rule            `tfschema:"rule" tfschemaopts:"optional,maxitems=5"`
+		Tags    map[string]string `tfschema:"tags" tfschemaopts:"optional"`
+		Secret  string            `tfschema:"secret" tfschemaopts:"computed,sensitive"`
+	}
+
+	arguments, attributes, err := SchemaFromModelObject(Model{})
+	if err != nil {
+		t.Fatalf("deriving the schema: %+v", err)
+	}
+
+	if len(arguments) != 5 {
+		t.Fatalf("expected 5 arguments but got %d", len(arguments))
+	}
+	if len(attributes) != 1 {
+		t.Fatalf("expected 1 attribute but got %d", len(attributes))
+	}
+
+	name := arguments["name"]
+	if name.Type != pluginsdk.TypeString || !name.Required || !name.ForceNew || name.ValidateFunc == nil {
+		t.Fatalf("expected `name` to be a Required, ForceNew String with a ValidateFunc but got %+v", name)
+	}
+	if v := arguments["enabled"]; v.Type != pluginsdk.TypeBool || !v.Optional {
+		t.Fatalf("expected `enabled` to be an Optional Bool but got %+v", v)
+	}
+	if v := arguments["weight"]; v.Type != pluginsdk.TypeFloat || !v.Optional || !v.Computed {
+		t.Fatalf("expected `weight` to be an Optional & Computed Float but got %+v", v)
+	}
+	if v := arguments["tags"]; v.Type != pluginsdk.TypeMap {
+		t.Fatalf("expected `tags` to be a Map but got %+v", v)
+	}
+	if v := attributes["secret"]; v.Type != pluginsdk.TypeString || !v.Computed || !v.Sensitive {
+		t.Fatalf("expected `secret` to be a Computed & Sensitive String but got %+v", v)
+	}
+
+	rule := arguments["rule"]
+	if rule.Type != pluginsdk.TypeList || rule.MaxItems != 5 {
+		t.Fatalf("expected `rule` to be a List with a MaxItems of 5 but got %+v", rule)
+	}
+	nested, ok := rule.Elem.(*pluginsdk.Resource)
+	if !ok {
+		t.Fatalf("expected `rule` to be a nested block but got %+v", rule.Elem)
+	}
+	if v := nested.Schema["ports"]; v.Type != pluginsdk.TypeSet {
+		t.Fatalf("expected `rule.ports` to be a Set but got %+v", v)
+	}
+
+	combined, err := combineSchema(arguments, attributes)
+	if err != nil {
+		t.Fatalf("combining the schema: %+v", err)
+	}
+	mismatches := CompareModelObjectWithSchema(Model{}, *combined)
+	if len(mismatches.Errors) > 0 || len(mismatches.Warnings) > 0 {
+		t.Fatalf("expected the derived schema to match the model but got %+v", mismatches)
+	}
+}
+
+func TestSchemaFromModelObjectInvalid(t *testing.T) {
+	testData := []struct {
+		Name  string
+		Model interface{}
+	}{
+		{
+			Name: "Missing Options",
+			Model: struct {
+				Name string `tfschema:"name"`
+			}{},
+		},
+		{
+			Name: "Required and Optional",
+			Model: struct {
+				Name string `tfschema:"name" tfschemaopts:"required,optional"`
+			}{},
+		},
+		{
+			Name: "ForceNew Computed",
+			Model: struct {
+				Name string `tfschema:"name" tfschemaopts:"computed,forcenew"`
+			}{},
+		},
+		{
+			Name: "Unknown Validation",
+			Model: struct {
+				Name string `tfschema:"name" tfschemaopts:"required,validation=DoesNotExist"`
+			}{},
+		},
+		{
+			Name: "Unsupported Type",
+			Model: struct {
+				Values map[string][]string `tfschema:"values" tfschemaopts:"optional"`
+			}{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		if _, _, err := SchemaFromModelObject(v.Model); err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+	}
+}
+
+func TestCompareModelObjectWithSchema(t *testing.T) {
+	type Rule struct {
+		Name string `tfschema:"name"`
+	}
+	type Model struct {
+		Name    string `tfschema:"name"`
+		Count   int    `tfschema:"count"`
+		Rules   []Rule `tfschema:"rule"`
+		Missing string `tfschema:"missing"`
+	}
+
+	schema := map[string]*pluginsdk.Schema{
+		"name": {
+			Type: pluginsdk.TypeString,
+		},
+		"count": {
+			Type: pluginsdk.TypeString,
+		},
+		"rule": {
+			Type: pluginsdk.TypeList,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type: pluginsdk.TypeString,
+					},
+					"extra": {
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
+
+	mismatches := CompareModelObjectWithSchema(Model{}, schema)
+	if len(mismatches.Errors) != 2 {
+		t.Fatalf("expected 2 errors but got %d: %+v", len(mismatches.Errors), mismatches.Errors)
+	}
+	if len(mismatches.Warnings) != 1 || mismatches.Warnings[0] != `"rule.extra" is defined in the schema but not the model` {
+		t.Fatalf("expected a single warning for `rule.extra` but got %+v", mismatches.Warnings)
+	}
+}
@@ -4,6 +4,8 @@
 package sdk
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -41,6 +43,26 @@ type UntypedServiceRegistration interface {
 	SupportedResources() map[string]*pluginsdk.Resource
 }
 
+// FrameworkServiceRegistration is a Service Registration for Data Sources and Resources
+// which are built natively on top of terraform-plugin-framework, rather than the Plugin SDK.
+//
+// A Service can implement this alongside the TypedServiceRegistration and/or the
+// UntypedServiceRegistration (hence the distinct method names) - however a given Data Source
+// or Resource can only be exposed by one of these.
+type FrameworkServiceRegistration interface {
+	// Name is the name of this Service
+	Name() string
+
+	// FrameworkDataSources returns a list of Plugin Framework Data Sources supported by this Service
+	FrameworkDataSources() []func() datasource.DataSource
+
+	// FrameworkResources returns a list of Plugin Framework Resources supported by this Service
+	FrameworkResources() []func() resource.Resource
+
+	// WebsiteCategories returns a list of categories which can be used for the sidebar
+	WebsiteCategories() []string
+}
+
 // TypedServiceRegistrationWithAGitHubLabel is a superset of TypedServiceRegistration allowing
 // a single GitHub Label to be specified that will be automatically applied to any Pull Requests
 // making changes to this package.
@@ -0,0 +1,128 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// ModelSchemaMismatches describes the differences between the Model Object and the Schema for a Typed Resource
+type ModelSchemaMismatches struct {
+	// Errors are mismatches which cause Encode or Decode to fail at runtime - such as a field within the
+	// Model Object which isn't defined in the Schema, or which has a different type to the Schema
+	Errors []string
+
+	// Warnings are fields defined in the Schema which aren't present in the Model Object, which can be
+	// intentional (for example where the field is read from the ResourceData directly)
+	Warnings []string
+}
+
+// CompareModelObjectWithSchema compares the `tfschema` struct tags and field types of the Model Object
+// with the Schema for the Typed Resource, returning any mismatches found
+func CompareModelObjectWithSchema(input interface{}, schema map[string]*pluginsdk.Schema) ModelSchemaMismatches {
+	output := ModelSchemaMismatches{
+		Errors:   make([]string, 0),
+		Warnings: make([]string, 0),
+	}
+	if input == nil {
+		// model not used for this resource
+		return output
+	}
+
+	objType := reflect.TypeOf(input)
+	if objType.Kind() == reflect.Ptr {
+		objType = objType.Elem()
+	}
+	if objType.Kind() != reflect.Struct {
+		output.Errors = append(output.Errors, fmt.Sprintf("the model object must be a struct but got %s", objType.Kind()))
+		return output
+	}
+
+	compareModelObjectWithSchemaRecursively("", objType, schema, &output)
+	sort.Strings(output.Errors)
+	sort.Strings(output.Warnings)
+	return output
+}
+
+func compareModelObjectWithSchemaRecursively(prefix string, objType reflect.Type, schema map[string]*pluginsdk.Schema, output *ModelSchemaMismatches) {
+	inModel := make(map[string]struct{})
+
+	for i := 0; i < objType.NumField(); i++ {
+		field := objType.Field(i)
+		structTags, err := parseStructTags(field.Tag)
+		if err != nil || structTags == nil {
+			// validated by ValidateModelObject
+			continue
+		}
+
+		hclPath := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, structTags.hclPath), ".")
+		inModel[structTags.hclPath] = struct{}{}
+		if !fieldIsAvailable(structTags) {
+			continue
+		}
+
+		v, ok := schema[structTags.hclPath]
+		if !ok {
+			output.Errors = append(output.Errors, fmt.Sprintf("%q (field %q) is defined in the model but not the schema", hclPath, field.Name))
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if valueType, ok := primitiveValueType(fieldType); ok {
+			if v.Type != valueType {
+				output.Errors = append(output.Errors, fmt.Sprintf("%q (field %q) is a %s in the model but a %s in the schema", hclPath, field.Name, fieldType, v.Type))
+			}
+			continue
+		}
+
+		switch fieldType.Kind() {
+		case reflect.Map:
+			if v.Type != pluginsdk.TypeMap {
+				output.Errors = append(output.Errors, fmt.Sprintf("%q (field %q) is a map in the model but a %s in the schema", hclPath, field.Name, v.Type))
+			}
+
+		case reflect.Slice:
+			if v.Type != pluginsdk.TypeList && v.Type != pluginsdk.TypeSet {
+				output.Errors = append(output.Errors, fmt.Sprintf("%q (field %q) is a slice in the model but a %s in the schema", hclPath, field.Name, v.Type))
+				continue
+			}
+
+			elemType := fieldType.Elem()
+			if elemType.Kind() == reflect.Ptr {
+				elemType = elemType.Elem()
+			}
+			if valueType, ok := primitiveValueType(elemType); ok {
+				if elem, ok := v.Elem.(*pluginsdk.Schema); ok && elem.Type != valueType {
+					output.Errors = append(output.Errors, fmt.Sprintf("%q (field %q) is a slice of %s in the model but a list of %s in the schema", hclPath, field.Name, elemType, elem.Type))
+				}
+				continue
+			}
+
+			if elemType.Kind() == reflect.Struct {
+				nested, ok := v.Elem.(*pluginsdk.Resource)
+				if !ok {
+					output.Errors = append(output.Errors, fmt.Sprintf("%q (field %q) is a slice of structs in the model but not a nested block in the schema", hclPath, field.Name))
+					continue
+				}
+				compareModelObjectWithSchemaRecursively(hclPath, elemType, nested.Schema, output)
+			}
+		}
+	}
+
+	for k := range schema {
+		if _, ok := inModel[k]; !ok {
+			hclPath := strings.TrimPrefix(fmt.Sprintf("%s.%s", prefix, k), ".")
+			output.Warnings = append(output.Warnings, fmt.Sprintf("%q is defined in the schema but not the model", hclPath))
+		}
+	}
+}
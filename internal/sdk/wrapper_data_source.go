@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
 )
 
 // DataSourceWrapper is a wrapper for converting a DataSource implementation
@@ -72,5 +73,5 @@ and we recommend using the %[2]q datasource instead.
 }
 
 func (dw *DataSourceWrapper) diagnosticsWrapper(in func(ctx context.Context, d *schema.ResourceData, meta interface{}) error) schema.ReadContextFunc {
-	return diagnosticsWrapper(in, dw.logger)
+	return diagnosticsWrapper(fmt.Sprintf("data.%s", dw.dataSource.ResourceType()), tracing.OperationRead, in, dw.logger)
 }
@@ -258,7 +258,9 @@ func (r FunctionAppFunctionResource) Create() sdk.ResourceFunc {
 				return fmt.Errorf("waiting for %s to be ready", *appId)
 			}
 
-			locks.ByID(appId.ID())
+			if err := locks.ByID(ctx, appId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(appId.ID())
 
 			if err := client.CreateFunctionThenPoll(ctx, id, fnEnvelope); err != nil {
@@ -377,7 +379,9 @@ func (r FunctionAppFunctionResource) Delete() sdk.ResourceFunc {
 				return fmt.Errorf("waiting for %s to be settled", *id)
 			}
 
-			locks.ByID(appId.ID())
+			if err := locks.ByID(ctx, appId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(appId.ID())
 
 			if _, err = client.DeleteFunction(ctx, *id); err != nil {
@@ -458,7 +462,9 @@ func (r FunctionAppFunctionResource) Update() sdk.ResourceFunc {
 				return fmt.Errorf("waiting for %s to be ready", *id)
 			}
 
-			locks.ByID(appId.ID())
+			if err := locks.ByID(ctx, appId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(appId.ID())
 
 			if err := client.CreateFunctionThenPoll(ctx, *id, model); err != nil {
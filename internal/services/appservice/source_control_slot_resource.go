@@ -158,7 +158,9 @@ func (r SourceControlSlotResource) Create() sdk.ResourceFunc {
 			}
 
 			appId := commonids.NewAppServiceID(id.SubscriptionId, id.ResourceGroupName, id.SiteName).ID()
-			locks.ByID(appId)
+			if err := locks.ByID(ctx, appId); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(appId)
 
 			existing, err := client.GetConfigurationSlot(ctx, *id)
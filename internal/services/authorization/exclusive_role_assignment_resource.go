@@ -0,0 +1,437 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authorization
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/authorization/2022-04-01/roleassignments"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/authorization/2022-05-01-preview/roledefinitions"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/authorization/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.ResourceWithUpdate = ExclusiveRoleAssignmentResource{}
+
+// ExclusiveRoleAssignmentResource authoritatively manages the Role Assignments made directly at a Scope, that is
+// any Role Assignment at the Scope which isn't defined (or for an ignored Principal) is removed.
+type ExclusiveRoleAssignmentResource struct{}
+
+type ExclusiveRoleAssignmentModel struct {
+	Scope               string                             `tfschema:"scope"`
+	RoleAssignments     []ExclusiveRoleAssignmentItemModel `tfschema:"role_assignment"`
+	IgnoredPrincipalIds []string                           `tfschema:"ignored_principal_ids"`
+}
+
+type ExclusiveRoleAssignmentItemModel struct {
+	PrincipalId        string `tfschema:"principal_id"`
+	RoleDefinitionId   string `tfschema:"role_definition_id"`
+	RoleDefinitionName string `tfschema:"role_definition_name"`
+}
+
+// exclusiveRoleAssignment is a Role Assignment with the Role Definition Name resolved to its ID
+type exclusiveRoleAssignment struct {
+	config           ExclusiveRoleAssignmentItemModel
+	principalId      string
+	roleDefinitionId string
+}
+
+func (r ExclusiveRoleAssignmentResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"scope": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringStartsWithOneOf("/subscriptions/", "/providers/Microsoft.Management/managementGroups/"),
+		},
+
+		"role_assignment": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"principal_id": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.IsUUID,
+					},
+
+					"role_definition_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"role_definition_name": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+
+		"ignored_principal_ids": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.IsUUID,
+			},
+		},
+	}
+}
+
+func (r ExclusiveRoleAssignmentResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r ExclusiveRoleAssignmentResource) ModelObject() interface{} {
+	return &ExclusiveRoleAssignmentModel{}
+}
+
+func (r ExclusiveRoleAssignmentResource) ResourceType() string {
+	return "azurerm_exclusive_role_assignment"
+}
+
+func (r ExclusiveRoleAssignmentResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return parse.ValidateExclusiveRoleAssignmentsID
+}
+
+func (r ExclusiveRoleAssignmentResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var config ExclusiveRoleAssignmentModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// there's intentionally no requires import check here, since this resource takes ownership of
+			// all of the Role Assignments at the Scope, including any which already exist
+			id := parse.NewExclusiveRoleAssignmentsID(config.Scope)
+
+			if err := r.reconcile(ctx, metadata, id, config); err != nil {
+				return err
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ExclusiveRoleAssignmentResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ExclusiveRoleAssignmentsID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state ExclusiveRoleAssignmentModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the Role Assignments in the state are used to output each Role Assignment in the same format it was
+			// defined in (e.g. using the Role Definition Name rather than ID) - when importing these will be empty
+			configured, err := r.expandRoleAssignments(ctx, metadata, id.Scope, state.RoleAssignments)
+			if err != nil {
+				return err
+			}
+			configuredByKey := make(map[string]ExclusiveRoleAssignmentItemModel)
+			for _, v := range configured {
+				configuredByKey[exclusiveRoleAssignmentKey(v.principalId, v.roleDefinitionId)] = v.config
+			}
+
+			existing, err := r.listRoleAssignmentsAtScope(ctx, metadata, id.Scope)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				log.Printf("[DEBUG] %s was not found - removing from state", id)
+				return metadata.MarkAsGone(id)
+			}
+
+			ignored := exclusiveRoleAssignmentIgnoredPrincipals(state.IgnoredPrincipalIds)
+			roleAssignments := make([]ExclusiveRoleAssignmentItemModel, 0)
+			for _, v := range *existing {
+				props := v.Properties
+
+				// configured Role Assignments take precedence over the ignored Principals, as when reconciling
+				if item, ok := configuredByKey[exclusiveRoleAssignmentKey(props.PrincipalId, props.RoleDefinitionId)]; ok {
+					roleAssignments = append(roleAssignments, item)
+					continue
+				}
+
+				if _, ok := ignored[strings.ToLower(props.PrincipalId)]; ok {
+					continue
+				}
+
+				roleAssignments = append(roleAssignments, ExclusiveRoleAssignmentItemModel{
+					PrincipalId:      props.PrincipalId,
+					RoleDefinitionId: props.RoleDefinitionId,
+				})
+			}
+
+			return metadata.Encode(&ExclusiveRoleAssignmentModel{
+				Scope:               id.Scope,
+				RoleAssignments:     roleAssignments,
+				IgnoredPrincipalIds: state.IgnoredPrincipalIds,
+			})
+		},
+	}
+}
+
+func (r ExclusiveRoleAssignmentResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ExclusiveRoleAssignmentsID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config ExclusiveRoleAssignmentModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			return r.reconcile(ctx, metadata, *id, config)
+		},
+	}
+}
+
+func (r ExclusiveRoleAssignmentResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Authorization.ScopedRoleAssignmentsClient
+
+			id, err := parse.ExclusiveRoleAssignmentsID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state ExclusiveRoleAssignmentModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// only the Role Assignments defined in this resource are removed, anything else at the Scope is left as-is
+			defined, err := r.expandRoleAssignments(ctx, metadata, id.Scope, state.RoleAssignments)
+			if err != nil {
+				return err
+			}
+			definedKeys := make(map[string]struct{})
+			for _, v := range defined {
+				definedKeys[exclusiveRoleAssignmentKey(v.principalId, v.roleDefinitionId)] = struct{}{}
+			}
+
+			existing, err := r.listRoleAssignmentsAtScope(ctx, metadata, id.Scope)
+			if err != nil {
+				return err
+			}
+			if existing == nil {
+				return nil
+			}
+
+			for _, v := range *existing {
+				if _, ok := definedKeys[exclusiveRoleAssignmentKey(v.Properties.PrincipalId, v.Properties.RoleDefinitionId)]; !ok {
+					continue
+				}
+
+				roleAssignmentId := roleassignments.NewScopedRoleAssignmentID(id.Scope, pointer.From(v.Name))
+				if resp, err := client.Delete(ctx, roleAssignmentId, roleassignments.DefaultDeleteOperationOptions()); err != nil {
+					if !response.WasNotFound(resp.HttpResponse) {
+						return fmt.Errorf("deleting %s: %+v", roleAssignmentId, err)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// reconcile creates any Role Assignment which is defined but doesn't exist at the Scope, and then removes any
+// Role Assignment made directly at the Scope which isn't defined - Role Assignments inherited from a parent
+// Scope and those for an ignored Principal are left as-is.
+func (r ExclusiveRoleAssignmentResource) reconcile(ctx context.Context, metadata sdk.ResourceMetaData, id parse.ExclusiveRoleAssignmentsId, config ExclusiveRoleAssignmentModel) error {
+	client := metadata.Client.Authorization.ScopedRoleAssignmentsClient
+
+	desired, err := r.expandRoleAssignments(ctx, metadata, id.Scope, config.RoleAssignments)
+	if err != nil {
+		return err
+	}
+
+	existing, err := r.listRoleAssignmentsAtScope(ctx, metadata, id.Scope)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return fmt.Errorf("the scope %q was not found", id.Scope)
+	}
+
+	existingKeys := make(map[string]struct{})
+	for _, v := range *existing {
+		existingKeys[exclusiveRoleAssignmentKey(v.Properties.PrincipalId, v.Properties.RoleDefinitionId)] = struct{}{}
+	}
+
+	desiredKeys := make(map[string]struct{})
+	for _, v := range desired {
+		key := exclusiveRoleAssignmentKey(v.principalId, v.roleDefinitionId)
+		desiredKeys[key] = struct{}{}
+		if _, ok := existingKeys[key]; ok {
+			continue
+		}
+
+		// the new Role Assignments are created prior to removing any, to avoid a Principal temporarily losing access
+		// when its Role Assignment is being replaced
+		name, err := uuid.GenerateUUID()
+		if err != nil {
+			return fmt.Errorf("generating UUID for Role Assignment: %+v", err)
+		}
+		roleAssignmentId := parse.NewScopedRoleAssignmentID(id.Scope, name, "")
+
+		log.Printf("[DEBUG] Creating %s for Principal %q with Role Definition %q..", roleAssignmentId, v.principalId, v.roleDefinitionId)
+		properties := roleassignments.RoleAssignmentCreateParameters{
+			Properties: roleassignments.RoleAssignmentProperties{
+				PrincipalId:      v.principalId,
+				RoleDefinitionId: v.roleDefinitionId,
+			},
+		}
+
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			return fmt.Errorf("could not retrieve context deadline for %s", roleAssignmentId)
+		}
+
+		if err := pluginsdk.Retry(time.Until(deadline), roleAssignmentBaseResource{}.retryRoleAssignmentsClient(ctx, metadata, roleAssignmentId, &properties)); err != nil {
+			return fmt.Errorf("creating %s: %+v", roleAssignmentId, err)
+		}
+	}
+
+	ignored := exclusiveRoleAssignmentIgnoredPrincipals(config.IgnoredPrincipalIds)
+	for _, v := range *existing {
+		props := v.Properties
+		if _, ok := desiredKeys[exclusiveRoleAssignmentKey(props.PrincipalId, props.RoleDefinitionId)]; ok {
+			continue
+		}
+		if _, ok := ignored[strings.ToLower(props.PrincipalId)]; ok {
+			log.Printf("[DEBUG] Skipping the Role Assignment %q for the ignored Principal %q", pointer.From(v.Id), props.PrincipalId)
+			continue
+		}
+
+		roleAssignmentId := roleassignments.NewScopedRoleAssignmentID(id.Scope, pointer.From(v.Name))
+		log.Printf("[DEBUG] Removing the unmanaged %s for Principal %q with Role Definition %q..", roleAssignmentId, props.PrincipalId, props.RoleDefinitionId)
+		if resp, err := client.Delete(ctx, roleAssignmentId, roleassignments.DefaultDeleteOperationOptions()); err != nil {
+			if !response.WasNotFound(resp.HttpResponse) {
+				return fmt.Errorf("removing the unmanaged %s: %+v", roleAssignmentId, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// expandRoleAssignments resolves the Role Definition ID for each of the Role Assignments, looking up any
+// Role Definition Names at the Scope
+func (r ExclusiveRoleAssignmentResource) expandRoleAssignments(ctx context.Context, metadata sdk.ResourceMetaData, scope string, input []ExclusiveRoleAssignmentItemModel) ([]exclusiveRoleAssignment, error) {
+	roleDefinitionsClient := metadata.Client.Authorization.ScopedRoleDefinitionsClient
+
+	output := make([]exclusiveRoleAssignment, 0)
+	roleDefinitionIds := make(map[string]string)
+	for _, v := range input {
+		if (v.RoleDefinitionId == "") == (v.RoleDefinitionName == "") {
+			return nil, fmt.Errorf("exactly one of `role_definition_id` or `role_definition_name` must be specified for the `role_assignment` for the Principal %q", v.PrincipalId)
+		}
+
+		roleDefinitionId := v.RoleDefinitionId
+		if v.RoleDefinitionName != "" {
+			if existing, ok := roleDefinitionIds[v.RoleDefinitionName]; ok {
+				roleDefinitionId = existing
+			} else {
+				roleDefinitions, err := roleDefinitionsClient.List(ctx, commonids.NewScopeID(scope), roledefinitions.ListOperationOptions{Filter: pointer.To(fmt.Sprintf("roleName eq '%s'", v.RoleDefinitionName))})
+				if err != nil {
+					return nil, fmt.Errorf("loading Role Definition List: %+v", err)
+				}
+
+				if roleDefinitions.Model == nil || len(*roleDefinitions.Model) != 1 || (*roleDefinitions.Model)[0].Id == nil {
+					return nil, fmt.Errorf("loading Role Definition List: failed to find role '%s'", v.RoleDefinitionName)
+				}
+
+				roleDefinitionId = *(*roleDefinitions.Model)[0].Id
+				roleDefinitionIds[v.RoleDefinitionName] = roleDefinitionId
+			}
+		}
+
+		output = append(output, exclusiveRoleAssignment{
+			config:           v,
+			principalId:      v.PrincipalId,
+			roleDefinitionId: roleDefinitionId,
+		})
+	}
+
+	return output, nil
+}
+
+// listRoleAssignmentsAtScope returns the Role Assignments made directly at the Scope, excluding any which are
+// inherited from a parent Scope - nil is returned when the Scope doesn't exist
+func (r ExclusiveRoleAssignmentResource) listRoleAssignmentsAtScope(ctx context.Context, metadata sdk.ResourceMetaData, scope string) (*[]roleassignments.RoleAssignment, error) {
+	client := metadata.Client.Authorization.ScopedRoleAssignmentsClient
+
+	resp, err := client.ListForScopeComplete(ctx, commonids.NewScopeID(scope), roleassignments.ListForScopeOperationOptions{
+		Filter: pointer.To("atScope()"),
+	})
+	if err != nil {
+		if response.WasNotFound(resp.LatestHttpResponse) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing the Role Assignments for the scope %q: %+v", scope, err)
+	}
+
+	output := make([]roleassignments.RoleAssignment, 0)
+	for _, v := range resp.Items {
+		if v.Properties == nil || v.Name == nil {
+			continue
+		}
+
+		// `atScope()` also includes the Role Assignments made at any parent Scope
+		if !strings.EqualFold(strings.TrimSuffix(pointer.From(v.Properties.Scope), "/"), scope) {
+			continue
+		}
+
+		output = append(output, v)
+	}
+
+	return &output, nil
+}
+
+// exclusiveRoleAssignmentKey returns a key for comparing Role Assignments - since the same Role Definition can be
+// referenced using an ID at different scopes, only the name of the Role Definition is compared.
+func exclusiveRoleAssignmentKey(principalId, roleDefinitionId string) string {
+	roleDefinitionName := roleDefinitionId[strings.LastIndex(roleDefinitionId, "/")+1:]
+	return strings.ToLower(fmt.Sprintf("%s|%s", principalId, roleDefinitionName))
+}
+
+func exclusiveRoleAssignmentIgnoredPrincipals(input []string) map[string]struct{} {
+	output := make(map[string]struct{})
+	for _, v := range input {
+		output[strings.ToLower(v)] = struct{}{}
+	}
+	return output
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package authorization_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/authorization/2022-04-01/roleassignments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/authorization/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type ExclusiveRoleAssignmentResource struct{}
+
+func TestAccExclusiveRoleAssignment_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_exclusive_role_assignment", "test")
+	r := ExclusiveRoleAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("role_assignment.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("role_assignment"),
+	})
+}
+
+func TestAccExclusiveRoleAssignment_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_exclusive_role_assignment", "test")
+	r := ExclusiveRoleAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("role_assignment.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("role_assignment"),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("role_assignment.#").HasValue("3"),
+			),
+		},
+		data.ImportStep("role_assignment", "ignored_principal_ids"),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("role_assignment.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("role_assignment"),
+	})
+}
+
+func TestAccExclusiveRoleAssignment_removesUnmanaged(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_exclusive_role_assignment", "test")
+	r := ExclusiveRoleAssignmentResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClientForResource(r.createUnmanagedRoleAssignment, "azurerm_user_assigned_identity.other"),
+			),
+			ExpectNonEmptyPlan: true,
+		},
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("role_assignment.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func (ExclusiveRoleAssignmentResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ExclusiveRoleAssignmentsID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Authorization.ScopedRoleAssignmentsClient.ListForScopeComplete(ctx, commonids.NewScopeID(id.Scope), roleassignments.ListForScopeOperationOptions{
+		Filter: pointer.To("atScope()"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing the Role Assignments for %s: %+v", id, err)
+	}
+
+	for _, v := range resp.Items {
+		if v.Properties != nil && strings.EqualFold(pointer.From(v.Properties.Scope), id.Scope) {
+			return pointer.To(true), nil
+		}
+	}
+
+	return pointer.To(false), nil
+}
+
+// createUnmanagedRoleAssignment creates a Role Assignment at the Resource Group outside of Terraform, which
+// should be shown as drift and then removed when the configuration is next applied
+func (ExclusiveRoleAssignmentResource) createUnmanagedRoleAssignment(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
+	resourceGroupId := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", client.Account.SubscriptionId, state.Attributes["resource_group_name"])
+	id := roleassignments.NewScopedRoleAssignmentID(resourceGroupId, "2b8d4d04-4b0e-4d83-8a57-5d8b9b3c2d11")
+
+	payload := roleassignments.RoleAssignmentCreateParameters{
+		Properties: roleassignments.RoleAssignmentProperties{
+			PrincipalId:      state.Attributes["principal_id"],
+			PrincipalType:    pointer.To(roleassignments.PrincipalTypeServicePrincipal),
+			RoleDefinitionId: fmt.Sprintf("/subscriptions/%s/providers/Microsoft.Authorization/roleDefinitions/acdd72a7-3385-48ef-bd42-f606fba81ae7", client.Account.SubscriptionId),
+		},
+	}
+	if _, err := client.Authorization.ScopedRoleAssignmentsClient.Create(ctx, id, payload); err != nil {
+		return fmt.Errorf("creating %s: %+v", id, err)
+	}
+
+	return nil
+}
+
+func (ExclusiveRoleAssignmentResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_user_assigned_identity" "test" {
+  name                = "acctestuai-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_user_assigned_identity" "other" {
+  name                = "acctestuai-other-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r ExclusiveRoleAssignmentResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_exclusive_role_assignment" "test" {
+  scope = azurerm_resource_group.test.id
+
+  role_assignment {
+    principal_id         = azurerm_user_assigned_identity.test.principal_id
+    role_definition_name = "Reader"
+  }
+}
+`, r.template(data))
+}
+
+func (r ExclusiveRoleAssignmentResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_client_config" "current" {}
+
+data "azurerm_role_definition" "contributor" {
+  name  = "Contributor"
+  scope = azurerm_resource_group.test.id
+}
+
+resource "azurerm_exclusive_role_assignment" "test" {
+  scope = azurerm_resource_group.test.id
+
+  role_assignment {
+    principal_id         = azurerm_user_assigned_identity.test.principal_id
+    role_definition_name = "Reader"
+  }
+
+  role_assignment {
+    principal_id       = azurerm_user_assigned_identity.test.principal_id
+    role_definition_id = data.azurerm_role_definition.contributor.id
+  }
+
+  role_assignment {
+    principal_id         = azurerm_user_assigned_identity.other.principal_id
+    role_definition_name = "Reader"
+  }
+
+  ignored_principal_ids = [
+    data.azurerm_client_config.current.object_id,
+  ]
+}
+`, r.template(data))
+}
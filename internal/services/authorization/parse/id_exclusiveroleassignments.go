@@ -0,0 +1,60 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+)
+
+const exclusiveRoleAssignmentsSuffix = "/providers/Microsoft.Authorization/roleAssignments"
+
+// ExclusiveRoleAssignmentsId is the ID for the full set of Role Assignments made directly at a Scope,
+// which is the Scope suffixed with the Role Assignments collection (e.g. `{scope}/providers/Microsoft.Authorization/roleAssignments`)
+type ExclusiveRoleAssignmentsId struct {
+	Scope string
+}
+
+func NewExclusiveRoleAssignmentsID(scope string) ExclusiveRoleAssignmentsId {
+	return ExclusiveRoleAssignmentsId{
+		Scope: scope,
+	}
+}
+
+func ExclusiveRoleAssignmentsID(input string) (*ExclusiveRoleAssignmentsId, error) {
+	if len(input) <= len(exclusiveRoleAssignmentsSuffix) || !strings.EqualFold(input[len(input)-len(exclusiveRoleAssignmentsSuffix):], exclusiveRoleAssignmentsSuffix) {
+		return nil, fmt.Errorf("parsing %q: expected the ID to be in the format `{scope}%s`", input, exclusiveRoleAssignmentsSuffix)
+	}
+
+	scope := input[:len(input)-len(exclusiveRoleAssignmentsSuffix)]
+	if !strings.HasPrefix(scope, "/") || strings.HasSuffix(scope, "/") {
+		return nil, fmt.Errorf("parsing %q: the scope %q must start with a `/` and must not end with a `/`", input, scope)
+	}
+
+	return &ExclusiveRoleAssignmentsId{
+		Scope: scope,
+	}, nil
+}
+
+func ValidateExclusiveRoleAssignmentsID(input interface{}, key string) (warnings []string, errors []error) {
+	v, ok := input.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", key))
+		return
+	}
+
+	if _, err := ExclusiveRoleAssignmentsID(v); err != nil {
+		errors = append(errors, err)
+	}
+
+	return
+}
+
+func (id ExclusiveRoleAssignmentsId) ID() string {
+	return id.Scope + exclusiveRoleAssignmentsSuffix
+}
+
+func (id ExclusiveRoleAssignmentsId) String() string {
+	return fmt.Sprintf("Exclusive Role Assignments (Scope: %q)", id.Scope)
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"testing"
+)
+
+func TestExclusiveRoleAssignmentsID(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Error    bool
+		Expected *ExclusiveRoleAssignmentsId
+	}{
+		{
+			Input: "",
+			Error: true,
+		},
+
+		{
+			Input: "/providers/Microsoft.Authorization/roleAssignments",
+			Error: true,
+		},
+
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			Error: true,
+		},
+
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/Microsoft.Authorization/roleAssignments/23456781-2349-8764-5631-234567890121",
+			Error: true,
+		},
+
+		{
+			Input: "subscriptions/12345678-1234-9876-4563-123456789012/providers/Microsoft.Authorization/roleAssignments",
+			Error: true,
+		},
+
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/providers/Microsoft.Authorization/roleAssignments",
+			Expected: &ExclusiveRoleAssignmentsId{
+				Scope: "/subscriptions/12345678-1234-9876-4563-123456789012",
+			},
+		},
+
+		{
+			Input: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1/providers/microsoft.authorization/roleassignments",
+			Expected: &ExclusiveRoleAssignmentsId{
+				Scope: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/group1",
+			},
+		},
+
+		{
+			Input: "/providers/Microsoft.Management/managementGroups/group1/providers/Microsoft.Authorization/roleAssignments",
+			Expected: &ExclusiveRoleAssignmentsId{
+				Scope: "/providers/Microsoft.Management/managementGroups/group1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual, err := ExclusiveRoleAssignmentsID(v.Input)
+		if err != nil {
+			if v.Error {
+				continue
+			}
+
+			t.Fatalf("expected a value but got an error: %+v", err)
+		}
+
+		if v.Error {
+			t.Fatal("Expect an error but didn't get one")
+		}
+
+		if actual.Scope != v.Expected.Scope {
+			t.Fatalf("Expected %q but got %q for Scope", v.Expected.Scope, actual.Scope)
+		}
+	}
+}
@@ -72,7 +72,9 @@ func resourceCognitiveAccountCustomerManagedKeyCreateUpdate(d *pluginsdk.Resourc
 		return err
 	}
 
-	locks.ByName(id.AccountName, "azurerm_cognitive_account")
+	if err := locks.ByName(ctx, id.AccountName, "azurerm_cognitive_account"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AccountName, "azurerm_cognitive_account")
 
 	resp, err := client.AccountsGet(ctx, *id)
@@ -178,7 +180,9 @@ func resourceCognitiveAccountCustomerManagedKeyDelete(d *pluginsdk.ResourceData,
 		return err
 	}
 
-	locks.ByName(id.AccountName, "azurerm_cognitive_account")
+	if err := locks.ByName(ctx, id.AccountName, "azurerm_cognitive_account"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AccountName, "azurerm_cognitive_account")
 
 	resp, err := client.AccountsGet(ctx, *id)
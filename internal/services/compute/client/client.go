@@ -39,6 +39,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachinescalesetvms"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/marketplaceordering/2015-06-01/agreements"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/skuavailability"
 )
 
 type Client struct {
@@ -62,6 +63,7 @@ type Client struct {
 	MarketplaceAgreementsClient                 *agreements.AgreementsClient
 	ProximityPlacementGroupsClient              *proximityplacementgroups.ProximityPlacementGroupsClient
 	SkusClient                                  *skus.SkusClient
+	SkuCatalogue                                *skuavailability.Catalogue
 	SSHPublicKeysClient                         *sshpublickeys.SshPublicKeysClient
 	SnapshotsClient                             *snapshots.SnapshotsClient
 	VirtualMachinesClient                       *virtualmachines.VirtualMachinesClient
@@ -262,6 +264,7 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		MarketplaceAgreementsClient:                 marketplaceAgreementsClient,
 		ProximityPlacementGroupsClient:              proximityPlacementGroupsClient,
 		SkusClient:                                  skusClient,
+		SkuCatalogue:                                skuavailability.NewCatalogue(skusClient),
 		SSHPublicKeysClient:                         sshPublicKeysClient,
 		SnapshotsClient:                             snapshotsClient,
 		VirtualMachinesClient:                       virtualMachinesClient,
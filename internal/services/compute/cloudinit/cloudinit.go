@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ContentTypeBoothook    = "text/cloud-boothook"
+	ContentTypeCloudConfig = "text/cloud-config"
+	ContentTypeShellScript = "text/x-shellscript"
+
+	// CustomDataMaxLength is the maximum length (in bytes) of the decoded `custom_data` for a Virtual Machine or
+	// Virtual Machine Scale Set
+	CustomDataMaxLength = 65535
+
+	// boundary is fixed so that the same parts always produce the same payload, avoiding a diff on every plan
+	boundary = "MIMEBOUNDARY"
+)
+
+// Part is a single part of a multipart cloud-init payload
+type Part struct {
+	// ContentType is the MIME type of the part, either `text/cloud-config`, `text/x-shellscript` or
+	// `text/cloud-boothook` - the `text/` prefix can be omitted
+	ContentType string
+
+	Content string
+}
+
+// ContentTypes returns the supported content types for a Part
+func ContentTypes() []string {
+	return []string{
+		ContentTypeBoothook,
+		ContentTypeCloudConfig,
+		ContentTypeShellScript,
+	}
+}
+
+// Build validates the parts and composes them into a multipart MIME payload, which is returned gzipped and base64
+// encoded for use as the `custom_data` of a Virtual Machine or Virtual Machine Scale Set
+func Build(parts []Part) (*string, error) {
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("at least one part must be specified")
+	}
+
+	var payload bytes.Buffer
+	payload.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q\r\n", boundary))
+	payload.WriteString("MIME-Version: 1.0\r\n\r\n")
+
+	writer := multipart.NewWriter(&payload)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return nil, fmt.Errorf("setting the MIME boundary: %+v", err)
+	}
+
+	for i, part := range parts {
+		contentType, err := normaliseContentType(part.ContentType)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %+v", i, err)
+		}
+
+		if err := validatePart(contentType, part.Content); err != nil {
+			return nil, fmt.Errorf("part %d: %+v", i, err)
+		}
+		if strings.Contains(part.Content, "--"+boundary) {
+			return nil, fmt.Errorf("part %d: the content must not contain the MIME boundary %q", i, boundary)
+		}
+
+		// the filename must be unique, since cloud-init writes each script to disk using it
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"part-%03d\"", i+1))
+		header.Set("Content-Transfer-Encoding", "7bit")
+		header.Set("Content-Type", fmt.Sprintf("%s; charset=\"utf-8\"", contentType))
+		header.Set("Mime-Version", "1.0")
+
+		w, err := writer.CreatePart(header)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: creating the MIME part: %+v", i, err)
+		}
+		if _, err := w.Write([]byte(part.Content)); err != nil {
+			return nil, fmt.Errorf("part %d: writing the MIME part: %+v", i, err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("closing the MIME payload: %+v", err)
+	}
+
+	var compressed bytes.Buffer
+	gz, err := gzip.NewWriterLevel(&compressed, gzip.BestCompression)
+	if err != nil {
+		return nil, fmt.Errorf("building the gzip writer: %+v", err)
+	}
+	if _, err := gz.Write(payload.Bytes()); err != nil {
+		return nil, fmt.Errorf("compressing the MIME payload: %+v", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("compressing the MIME payload: %+v", err)
+	}
+
+	if compressed.Len() > CustomDataMaxLength {
+		return nil, fmt.Errorf("the compressed payload is %d bytes which exceeds the maximum length of %d bytes for `custom_data`", compressed.Len(), CustomDataMaxLength)
+	}
+
+	output := base64.StdEncoding.EncodeToString(compressed.Bytes())
+	return &output, nil
+}
+
+func normaliseContentType(input string) (string, error) {
+	contentType := strings.ToLower(strings.TrimSpace(input))
+	if !strings.HasPrefix(contentType, "text/") {
+		contentType = "text/" + contentType
+	}
+
+	for _, v := range ContentTypes() {
+		if contentType == v {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("the content type %q is not supported, expected one of %s", input, strings.Join(ContentTypes(), ", "))
+}
+
+func validatePart(contentType, content string) error {
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("the content must not be empty")
+	}
+
+	switch contentType {
+	case ContentTypeCloudConfig:
+		var config interface{}
+		if err := yaml.Unmarshal([]byte(content), &config); err != nil {
+			return fmt.Errorf("the cloud-config is not valid YAML: %+v", err)
+		}
+		if _, ok := config.(map[string]interface{}); !ok {
+			return fmt.Errorf("the cloud-config must be a YAML mapping")
+		}
+
+	case ContentTypeShellScript:
+		if !strings.HasPrefix(content, "#!") {
+			return fmt.Errorf("the shell script must begin with an interpreter directive (e.g. `#!/bin/bash`)")
+		}
+	}
+
+	return nil
+}
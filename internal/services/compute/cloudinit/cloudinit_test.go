@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Input []Part
+		Valid bool
+	}{
+		{
+			Name:  "No Parts",
+			Input: []Part{},
+			Valid: false,
+		},
+		{
+			Name: "Cloud Config",
+			Input: []Part{
+				{
+					ContentType: "text/cloud-config",
+					Content:     "#cloud-config\npackages:\n  - nginx\n",
+				},
+			},
+			Valid: true,
+		},
+		{
+			Name: "Short Content Types",
+			Input: []Part{
+				{
+					ContentType: "cloud-config",
+					Content:     "runcmd:\n  - echo hello\n",
+				},
+				{
+					ContentType: "x-shellscript",
+					Content:     "#!/bin/bash\necho hello\n",
+				},
+				{
+					ContentType: "cloud-boothook",
+					Content:     "echo boothook\n",
+				},
+			},
+			Valid: true,
+		},
+		{
+			Name: "Unsupported Content Type",
+			Input: []Part{
+				{
+					ContentType: "text/plain",
+					Content:     "hello",
+				},
+			},
+			Valid: false,
+		},
+		{
+			Name: "Invalid YAML",
+			Input: []Part{
+				{
+					ContentType: "text/cloud-config",
+					Content:     "packages:\n  - nginx\n - apache2\n",
+				},
+			},
+			Valid: false,
+		},
+		{
+			Name: "Cloud Config isn't a mapping",
+			Input: []Part{
+				{
+					ContentType: "text/cloud-config",
+					Content:     "- nginx\n",
+				},
+			},
+			Valid: false,
+		},
+		{
+			Name: "Shell Script without an interpreter",
+			Input: []Part{
+				{
+					ContentType: "text/x-shellscript",
+					Content:     "echo hello\n",
+				},
+			},
+			Valid: false,
+		},
+		{
+			Name: "Empty Content",
+			Input: []Part{
+				{
+					ContentType: "text/cloud-boothook",
+					Content:     " ",
+				},
+			},
+			Valid: false,
+		},
+		{
+			Name: "Content contains the Boundary",
+			Input: []Part{
+				{
+					ContentType: "text/cloud-boothook",
+					Content:     "--MIMEBOUNDARY\n",
+				},
+			},
+			Valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("[DEBUG] Testing %q", testCase.Name)
+
+		_, err := Build(testCase.Input)
+		valid := err == nil
+		if valid != testCase.Valid {
+			t.Fatalf("expected valid to be %t but got %t: %+v", testCase.Valid, valid, err)
+		}
+	}
+}
+
+func TestBuildRoundTrip(t *testing.T) {
+	parts := []Part{
+		{
+			ContentType: "cloud-config",
+			Content:     "#cloud-config\npackages:\n  - nginx\n",
+		},
+		{
+			ContentType: "text/x-shellscript",
+			Content:     "#!/bin/bash\necho hello\n",
+		},
+	}
+
+	encoded, err := Build(parts)
+	if err != nil {
+		t.Fatalf("building the payload: %+v", err)
+	}
+
+	again, err := Build(parts)
+	if err != nil {
+		t.Fatalf("building the payload: %+v", err)
+	}
+	if *encoded != *again {
+		t.Fatalf("expected the payload to be the same for the same parts")
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(*encoded)
+	if err != nil {
+		t.Fatalf("decoding the payload: %+v", err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("decompressing the payload: %+v", err)
+	}
+
+	message, err := mail.ReadMessage(reader)
+	if err != nil {
+		t.Fatalf("reading the MIME message: %+v", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatalf("parsing the content type: %+v", err)
+	}
+	if mediaType != "multipart/mixed" {
+		t.Fatalf("expected the content type to be `multipart/mixed` but got %q", mediaType)
+	}
+
+	expectedContentTypes := []string{ContentTypeCloudConfig, ContentTypeShellScript}
+	mr := multipart.NewReader(message.Body, params["boundary"])
+	for i := 0; ; i++ {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			if i != len(parts) {
+				t.Fatalf("expected %d parts but got %d", len(parts), i)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading part %d: %+v", i, err)
+		}
+
+		contentType, _, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatalf("parsing the content type for part %d: %+v", i, err)
+		}
+		if contentType != expectedContentTypes[i] {
+			t.Fatalf("expected the content type for part %d to be %q but got %q", i, expectedContentTypes[i], contentType)
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("reading the content for part %d: %+v", i, err)
+		}
+		if string(content) != parts[i].Content {
+			t.Fatalf("expected the content for part %d to be %q but got %q", i, parts[i].Content, string(content))
+		}
+	}
+}
+
+func TestBuildExceedsMaxLength(t *testing.T) {
+	// random data doesn't compress, so this exceeds the limit once gzipped
+	data := make([]byte, 2*CustomDataMaxLength)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatalf("generating random data: %+v", err)
+	}
+
+	parts := []Part{
+		{
+			ContentType: "text/cloud-boothook",
+			Content:     hex.EncodeToString(data),
+		},
+	}
+
+	_, err := Build(parts)
+	if err == nil || !strings.Contains(err.Error(), "exceeds the maximum length") {
+		t.Fatalf("expected an error that the payload exceeds the maximum length but got: %+v", err)
+	}
+}
@@ -832,7 +832,9 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		}
 		// check instanceView State
 
-		locks.ByName(name, VirtualMachineResourceName)
+		if err := locks.ByName(ctx, name, VirtualMachineResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(name, VirtualMachineResourceName)
 
 		vm, err := virtualMachinesClient.Get(ctx, *virtualMachineId, virtualmachines.DefaultGetOperationOptions())
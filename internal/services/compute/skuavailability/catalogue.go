@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package skuavailability
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+)
+
+const (
+	capabilityAcceleratedNetworking = "AcceleratedNetworkingEnabled"
+	capabilityEphemeralOSDisk       = "EphemeralOSDiskSupported"
+	capabilityPremiumIO             = "PremiumIO"
+)
+
+// Requirements are the properties of a Virtual Machine (or Scale Set/Node Pool) which depend on the Virtual Machine Size
+type Requirements struct {
+	Size string
+
+	// Zones are the Availability Zones the Virtual Machines will be placed in, if any
+	Zones []string
+
+	AcceleratedNetworking bool
+	EphemeralOSDisk       bool
+	PremiumIO             bool
+}
+
+// Catalogue is a cache of the Virtual Machine SKUs available for each Subscription and Location, which is used to
+// validate the Virtual Machine Size at plan time
+type Catalogue struct {
+	client *skus.SkusClient
+
+	lock  sync.Mutex
+	cache map[string][]skus.ResourceSku
+}
+
+func NewCatalogue(client *skus.SkusClient) *Catalogue {
+	return &Catalogue{
+		client: client,
+		cache:  make(map[string][]skus.ResourceSku),
+	}
+}
+
+// Validate confirms that the Virtual Machine Size is available in the Location (and Zones) for the Subscription and supports
+// the required capabilities. Since this is a best-effort check, when the SKUs can't be retrieved a warning is logged
+// and the validation is skipped, leaving the API to return any error during the apply.
+func (c *Catalogue) Validate(ctx context.Context, subscriptionId, loc string, requirements Requirements) error {
+	if requirements.Size == "" || loc == "" {
+		return nil
+	}
+
+	loc = location.Normalize(loc)
+	available, err := c.virtualMachineSkus(ctx, subscriptionId, loc)
+	if err != nil {
+		log.Printf("[WARN] unable to validate the Virtual Machine Size %q in %q: %+v", requirements.Size, loc, err)
+		return nil
+	}
+
+	return validateRequirements(available, loc, requirements)
+}
+
+func (c *Catalogue) virtualMachineSkus(ctx context.Context, subscriptionId, loc string) ([]skus.ResourceSku, error) {
+	// the lock is held whilst retrieving the SKUs so that concurrent plans for the same Location only make a single request
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := fmt.Sprintf("%s/%s", strings.ToLower(subscriptionId), loc)
+	if v, ok := c.cache[key]; ok {
+		return v, nil
+	}
+
+	opts := skus.DefaultResourceSkusListOperationOptions()
+	// by default this API returns every SKU in every Location, so filter to the Location being validated
+	opts.Filter = pointer.To(fmt.Sprintf("location eq '%s'", loc))
+	resp, err := c.client.ResourceSkusListComplete(ctx, commonids.NewSubscriptionID(subscriptionId), opts)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the Resource SKUs for %q: %+v", loc, err)
+	}
+
+	output := make([]skus.ResourceSku, 0)
+	for _, sku := range resp.Items {
+		if sku.ResourceType == nil || !strings.EqualFold(*sku.ResourceType, "virtualMachines") {
+			continue
+		}
+		output = append(output, sku)
+	}
+
+	c.cache[key] = output
+	return output, nil
+}
+
+func validateRequirements(available []skus.ResourceSku, loc string, requirements Requirements) error {
+	var sku *skus.ResourceSku
+	for i, v := range available {
+		if v.Name != nil && strings.EqualFold(*v.Name, requirements.Size) {
+			sku = &available[i]
+			break
+		}
+	}
+	if sku == nil {
+		return fmt.Errorf("the Virtual Machine Size %q is not available in %q", requirements.Size, loc)
+	}
+
+	restrictedZones := make(map[string]struct{})
+	if sku.Restrictions != nil {
+		for _, restriction := range *sku.Restrictions {
+			if restriction.Type == nil {
+				continue
+			}
+
+			reason := ""
+			if restriction.ReasonCode != nil {
+				reason = fmt.Sprintf(" (%s)", string(*restriction.ReasonCode))
+			}
+
+			switch *restriction.Type {
+			case skus.ResourceSkuRestrictionsTypeLocation:
+				for _, v := range pointer.From(restriction.Values) {
+					if location.Normalize(v) == loc {
+						return fmt.Errorf("the Virtual Machine Size %q is restricted in %q for this Subscription%s", requirements.Size, loc, reason)
+					}
+				}
+
+			case skus.ResourceSkuRestrictionsTypeZone:
+				if restriction.RestrictionInfo == nil || restriction.RestrictionInfo.Zones == nil {
+					continue
+				}
+				for _, zone := range *restriction.RestrictionInfo.Zones {
+					restrictedZones[zone] = struct{}{}
+				}
+			}
+		}
+	}
+
+	if len(requirements.Zones) > 0 {
+		availableZones := make(map[string]struct{})
+		for _, info := range pointer.From(sku.LocationInfo) {
+			if info.Location == nil || location.Normalize(*info.Location) != loc || info.Zones == nil {
+				continue
+			}
+			for _, zone := range *info.Zones {
+				if _, restricted := restrictedZones[zone]; !restricted {
+					availableZones[zone] = struct{}{}
+				}
+			}
+		}
+
+		for _, zone := range requirements.Zones {
+			if _, ok := availableZones[zone]; ok {
+				continue
+			}
+
+			zones := make([]string, 0)
+			for v := range availableZones {
+				zones = append(zones, v)
+			}
+			sort.Strings(zones)
+
+			if len(zones) == 0 {
+				return fmt.Errorf("the Virtual Machine Size %q is not available in any Availability Zones in %q for this Subscription", requirements.Size, loc)
+			}
+			return fmt.Errorf("the Virtual Machine Size %q is not available in Availability Zone %q in %q for this Subscription - the available Zones are: %s", requirements.Size, zone, loc, strings.Join(zones, ", "))
+		}
+	}
+
+	capabilities := make(map[string]string)
+	for _, v := range pointer.From(sku.Capabilities) {
+		if v.Name != nil && v.Value != nil {
+			capabilities[strings.ToLower(*v.Name)] = *v.Value
+		}
+	}
+	supports := func(name string) bool {
+		return strings.EqualFold(capabilities[strings.ToLower(name)], "True")
+	}
+
+	if requirements.AcceleratedNetworking && !supports(capabilityAcceleratedNetworking) {
+		return fmt.Errorf("the Virtual Machine Size %q does not support Accelerated Networking", requirements.Size)
+	}
+	if requirements.EphemeralOSDisk && !supports(capabilityEphemeralOSDisk) {
+		return fmt.Errorf("the Virtual Machine Size %q does not support Ephemeral OS Disks", requirements.Size)
+	}
+	if requirements.PremiumIO && !supports(capabilityPremiumIO) {
+		return fmt.Errorf("the Virtual Machine Size %q does not support Premium Storage", requirements.Size)
+	}
+
+	return nil
+}
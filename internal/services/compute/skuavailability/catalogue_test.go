@@ -0,0 +1,135 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package skuavailability
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+)
+
+func TestValidateRequirements(t *testing.T) {
+	available := []skus.ResourceSku{
+		{
+			Name: pointer.To("Standard_D2s_v3"),
+			Capabilities: &[]skus.ResourceSkuCapabilities{
+				{Name: pointer.To("AcceleratedNetworkingEnabled"), Value: pointer.To("True")},
+				{Name: pointer.To("EphemeralOSDiskSupported"), Value: pointer.To("True")},
+				{Name: pointer.To("PremiumIO"), Value: pointer.To("True")},
+			},
+			LocationInfo: &[]skus.ResourceSkuLocationInfo{
+				{
+					Location: pointer.To("westeurope"),
+					Zones:    &[]string{"3", "1", "2"},
+				},
+			},
+			Restrictions: &[]skus.ResourceSkuRestrictions{
+				{
+					Type:       pointer.To(skus.ResourceSkuRestrictionsTypeZone),
+					ReasonCode: pointer.To(skus.ResourceSkuRestrictionsReasonCodeNotAvailableForSubscription),
+					Values:     &[]string{"westeurope"},
+					RestrictionInfo: &skus.ResourceSkuRestrictionInfo{
+						Locations: &[]string{"westeurope"},
+						Zones:     &[]string{"3"},
+					},
+				},
+			},
+		},
+		{
+			Name: pointer.To("Standard_A1_v2"),
+			Capabilities: &[]skus.ResourceSkuCapabilities{
+				{Name: pointer.To("AcceleratedNetworkingEnabled"), Value: pointer.To("False")},
+				{Name: pointer.To("PremiumIO"), Value: pointer.To("False")},
+			},
+			LocationInfo: &[]skus.ResourceSkuLocationInfo{
+				{
+					Location: pointer.To("westeurope"),
+				},
+			},
+		},
+		{
+			Name: pointer.To("Standard_M416ms_v2"),
+			Restrictions: &[]skus.ResourceSkuRestrictions{
+				{
+					Type:       pointer.To(skus.ResourceSkuRestrictionsTypeLocation),
+					ReasonCode: pointer.To(skus.ResourceSkuRestrictionsReasonCodeNotAvailableForSubscription),
+					Values:     &[]string{"westeurope"},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		Name  string
+		Input Requirements
+		Valid bool
+	}{
+		{
+			Name:  "Available",
+			Input: Requirements{Size: "Standard_D2s_v3"},
+			Valid: true,
+		},
+		{
+			Name:  "Available with different casing",
+			Input: Requirements{Size: "standard_d2s_V3"},
+			Valid: true,
+		},
+		{
+			Name:  "Not Available",
+			Input: Requirements{Size: "Standard_Z1"},
+			Valid: false,
+		},
+		{
+			Name:  "Restricted in the Location",
+			Input: Requirements{Size: "Standard_M416ms_v2"},
+			Valid: false,
+		},
+		{
+			Name:  "Available Zones",
+			Input: Requirements{Size: "Standard_D2s_v3", Zones: []string{"1", "2"}},
+			Valid: true,
+		},
+		{
+			Name:  "Restricted Zone",
+			Input: Requirements{Size: "Standard_D2s_v3", Zones: []string{"1", "3"}},
+			Valid: false,
+		},
+		{
+			Name:  "No Zones",
+			Input: Requirements{Size: "Standard_A1_v2", Zones: []string{"1"}},
+			Valid: false,
+		},
+		{
+			Name:  "Supported Capabilities",
+			Input: Requirements{Size: "Standard_D2s_v3", AcceleratedNetworking: true, EphemeralOSDisk: true, PremiumIO: true},
+			Valid: true,
+		},
+		{
+			Name:  "Accelerated Networking Unsupported",
+			Input: Requirements{Size: "Standard_A1_v2", AcceleratedNetworking: true},
+			Valid: false,
+		},
+		{
+			Name:  "Ephemeral OS Disk Unsupported",
+			Input: Requirements{Size: "Standard_A1_v2", EphemeralOSDisk: true},
+			Valid: false,
+		},
+		{
+			Name:  "Premium IO Unsupported",
+			Input: Requirements{Size: "Standard_A1_v2", PremiumIO: true},
+			Valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("[DEBUG] Testing %q", testCase.Name)
+
+		err := validateRequirements(available, "westeurope", testCase.Input)
+		valid := err == nil
+		if valid != testCase.Valid {
+			t.Fatalf("expected valid to be %t but got %t: %+v", testCase.Valid, valid, err)
+		}
+	}
+}
@@ -7,13 +7,18 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachinescalesetrollingupgrades"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachinescalesets"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachinescalesetvms"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 type virtualMachineScaleSetUpdateMetaData struct {
@@ -26,6 +31,9 @@ type virtualMachineScaleSetUpdateMetaData struct {
 	// can we roll instances if we need too? this is a feature toggle
 	CanRollInstancesWhenRequired bool
 
+	// how should the instances be rolled when `upgrade_mode` is set to `Manual`? when nil each instance is rolled one at a time
+	ManualUpgrade *features.VirtualMachineScaleSetManualUpgradeFeatures
+
 	// do we need to roll the instances in this scale set?
 	UpdateInstances bool
 
@@ -134,34 +142,48 @@ func (metadata virtualMachineScaleSetUpdateMetaData) upgradeInstancesForManualUp
 		props := item.Properties
 		if props != nil && item.InstanceId != nil {
 			latestModel := props.LatestModelApplied
-			if latestModel != nil || !*latestModel {
+			if latestModel == nil || !*latestModel {
 				instanceIdsToRoll = append(instanceIdsToRoll, *item.InstanceId)
 			}
 		}
 	}
 
-	// TODO: there's a performance enhancement to do batches here, but this is fine for a first pass
-	for _, instanceId := range instanceIdsToRoll {
-		instanceIds := []string{instanceId}
+	batches := virtualMachineScaleSetManualUpgradeBatches(instanceIdsToRoll, len(instances.Items), metadata.ManualUpgrade)
+	for i, instanceIds := range batches {
+		if i > 0 && metadata.ManualUpgrade != nil && metadata.ManualUpgrade.PauseBetweenBatches > 0 {
+			log.Printf("[DEBUG] Pausing for %s before rolling the next batch of Instances..", metadata.ManualUpgrade.PauseBetweenBatches)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("waiting to roll the next batch of Instances for %s %s: %+v", metadata.OSType, id, ctx.Err())
+			case <-time.After(metadata.ManualUpgrade.PauseBetweenBatches):
+			}
+		}
 
-		log.Printf("[DEBUG] Updating Instance %q to the Latest Configuration..", instanceId)
+		instanceIdsDescription := strings.Join(instanceIds, ", ")
+		log.Printf("[DEBUG] Updating Instances %q (batch %d of %d) to the Latest Configuration..", instanceIdsDescription, i+1, len(batches))
 		ids := virtualmachinescalesets.VirtualMachineScaleSetVMInstanceRequiredIDs{
 			InstanceIds: instanceIds,
 		}
 		if err := client.UpdateInstancesThenPoll(ctx, *id, ids); err != nil {
-			return fmt.Errorf("updating Instance %q (%s %s) to the Latest Configuration: %+v", instanceId, metadata.OSType, id, err)
+			return fmt.Errorf("updating Instances %q (%s %s) to the Latest Configuration: %+v", instanceIdsDescription, metadata.OSType, id, err)
 		}
-		log.Printf("[DEBUG] Updated Instance %q to the Latest Configuration.", instanceId)
+		log.Printf("[DEBUG] Updated Instances %q to the Latest Configuration.", instanceIdsDescription)
 
 		if metadata.CanReimageOnManualUpgrade {
-			log.Printf("[DEBUG] Reimaging Instance %q..", instanceId)
+			log.Printf("[DEBUG] Reimaging Instances %q..", instanceIdsDescription)
 			reImageInput := virtualmachinescalesets.VirtualMachineScaleSetReimageParameters{
-				InstanceIds: &instanceIds,
+				InstanceIds: pointer.To(instanceIds),
 			}
 			if err := client.ReimageThenPoll(ctx, *id, reImageInput); err != nil {
-				return fmt.Errorf("reimaging Instance %q (%s %s): %+v", instanceId, metadata.OSType, id, err)
+				return fmt.Errorf("reimaging Instances %q (%s %s): %+v", instanceIdsDescription, metadata.OSType, id, err)
+			}
+			log.Printf("[DEBUG] Reimaged Instances %q..", instanceIdsDescription)
+		}
+
+		if metadata.ManualUpgrade != nil && metadata.ManualUpgrade.HealthCheckEnabled {
+			if err := metadata.waitForHealthyInstances(ctx, instanceIds); err != nil {
+				return err
 			}
-			log.Printf("[DEBUG] Reimaged Instance %q..", instanceId)
 		}
 	}
 
@@ -169,6 +191,161 @@ func (metadata virtualMachineScaleSetUpdateMetaData) upgradeInstancesForManualUp
 	return nil
 }
 
+// waitForHealthyInstances waits for the Instances which have just been rolled to become healthy, then checks the
+// number of unhealthy Instances within the Scale Set doesn't exceed the `max_unhealthy_instance_percent`
+func (metadata virtualMachineScaleSetUpdateMetaData) waitForHealthyInstances(ctx context.Context, instanceIds []string) error {
+	id := metadata.ID
+
+	var unhealthy map[string]string
+	var totalInstances int
+	var refreshErr error
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{"Unhealthy"},
+		Target:  []string{"Healthy"},
+		Refresh: func() (interface{}, string, error) {
+			unhealthy, totalInstances, refreshErr = metadata.unhealthyInstances(ctx)
+			if refreshErr != nil {
+				return nil, "", refreshErr
+			}
+
+			for _, instanceId := range instanceIds {
+				if _, ok := unhealthy[instanceId]; ok {
+					return unhealthy, "Unhealthy", nil
+				}
+			}
+			return unhealthy, "Healthy", nil
+		},
+		MinTimeout: 15 * time.Second,
+		Timeout:    metadata.ManualUpgrade.HealthCheckTimeout,
+	}
+
+	log.Printf("[DEBUG] Waiting for Instances %q to become healthy..", strings.Join(instanceIds, ", "))
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		if refreshErr != nil || unhealthy == nil || ctx.Err() != nil {
+			return fmt.Errorf("waiting for the Instances %q (%s %s) to become healthy: %+v", strings.Join(instanceIds, ", "), metadata.OSType, id, err)
+		}
+		log.Printf("[DEBUG] Timed out waiting for Instances %q to become healthy: %+v", strings.Join(instanceIds, ", "), err)
+	}
+
+	if len(unhealthy) == 0 {
+		return nil
+	}
+
+	unhealthyIds := make([]string, 0)
+	for instanceId := range unhealthy {
+		unhealthyIds = append(unhealthyIds, instanceId)
+	}
+	sort.Strings(unhealthyIds)
+
+	reasons := make([]string, 0)
+	for _, instanceId := range unhealthyIds {
+		reasons = append(reasons, fmt.Sprintf("* Instance %q: %s", instanceId, unhealthy[instanceId]))
+	}
+
+	unhealthyPercent := len(unhealthy) * 100 / totalInstances
+	if unhealthyPercent > metadata.ManualUpgrade.MaxUnhealthyInstancePercent {
+		return fmt.Errorf("aborting rolling the Instances for %s %s since %d of %d Instances (%d%%) are unhealthy, which exceeds the `max_unhealthy_instance_percent` of %d%%:\n\n%s", metadata.OSType, id, len(unhealthy), totalInstances, unhealthyPercent, metadata.ManualUpgrade.MaxUnhealthyInstancePercent, strings.Join(reasons, "\n"))
+	}
+
+	log.Printf("[WARN] %d of %d Instances (%d%%) for %s %s are unhealthy, which is within the `max_unhealthy_instance_percent` of %d%% - continuing:\n\n%s", len(unhealthy), totalInstances, unhealthyPercent, metadata.OSType, id, metadata.ManualUpgrade.MaxUnhealthyInstancePercent, strings.Join(reasons, "\n"))
+	return nil
+}
+
+// unhealthyInstances returns the reason each unhealthy Instance in the Scale Set is unhealthy, keyed by Instance ID,
+// alongside the total number of Instances in the Scale Set
+func (metadata virtualMachineScaleSetUpdateMetaData) unhealthyInstances(ctx context.Context) (map[string]string, int, error) {
+	id := metadata.ID
+	instancesClient := metadata.Client.VirtualMachineScaleSetVMsClient
+	virtualMachineScaleSetId := virtualmachinescalesetvms.NewVirtualMachineScaleSetID(id.SubscriptionId, id.ResourceGroupName, id.VirtualMachineScaleSetName)
+
+	options := virtualmachinescalesetvms.DefaultListOperationOptions()
+	options.Expand = pointer.To("instanceView")
+	instances, err := instancesClient.ListComplete(ctx, virtualMachineScaleSetId, options)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing VM Instances for %s %s: %+v", metadata.OSType, id, err)
+	}
+
+	unhealthy := make(map[string]string)
+	for _, item := range instances.Items {
+		if item.InstanceId == nil {
+			continue
+		}
+
+		if healthy, reason := virtualMachineScaleSetInstanceIsHealthy(item); !healthy {
+			unhealthy[*item.InstanceId] = reason
+		}
+	}
+
+	return unhealthy, len(instances.Items), nil
+}
+
+// virtualMachineScaleSetManualUpgradeBatches splits the Instances to roll into batches - when `max_batch_instance_percent`
+// is specified this is a percentage of all of the Instances within the Scale Set, else `max_batch_instance_count` is used
+func virtualMachineScaleSetManualUpgradeBatches(instanceIds []string, totalInstances int, input *features.VirtualMachineScaleSetManualUpgradeFeatures) [][]string {
+	batchSize := 1
+	if input != nil {
+		batchSize = input.MaxBatchInstanceCount
+		if input.MaxBatchInstancePercent > 0 {
+			batchSize = int(math.Ceil(float64(totalInstances*input.MaxBatchInstancePercent) / 100))
+		}
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	batches := make([][]string, 0)
+	for start := 0; start < len(instanceIds); start += batchSize {
+		end := start + batchSize
+		if end > len(instanceIds) {
+			end = len(instanceIds)
+		}
+		batches = append(batches, instanceIds[start:end])
+	}
+
+	return batches
+}
+
+// virtualMachineScaleSetInstanceIsHealthy determines whether the Instance is healthy from its Instance View, using
+// the Application Health Extension (or Load Balancer Health Probe) state where this is available
+func virtualMachineScaleSetInstanceIsHealthy(input virtualmachinescalesetvms.VirtualMachineScaleSetVM) (bool, string) {
+	props := input.Properties
+	if props == nil {
+		return false, "the Instance has no properties"
+	}
+
+	if provisioningState := pointer.From(props.ProvisioningState); !strings.EqualFold(provisioningState, "Succeeded") {
+		return false, fmt.Sprintf("the Provisioning State is %q", provisioningState)
+	}
+
+	instanceView := props.InstanceView
+	if instanceView == nil {
+		return false, "the Instance View isn't available"
+	}
+
+	powerState := ""
+	if instanceView.Statuses != nil {
+		for _, status := range *instanceView.Statuses {
+			if code := pointer.From(status.Code); strings.HasPrefix(strings.ToLower(code), "powerstate/") {
+				powerState = code
+			}
+		}
+	}
+	if !strings.EqualFold(powerState, "PowerState/running") {
+		if powerState == "" {
+			return false, "the Power State is unknown"
+		}
+		return false, fmt.Sprintf("the Power State is %q", powerState)
+	}
+
+	if instanceView.VMHealth != nil && instanceView.VMHealth.Status != nil {
+		if healthState := pointer.From(instanceView.VMHealth.Status.Code); !strings.EqualFold(healthState, "HealthState/healthy") {
+			return false, fmt.Sprintf("the Health State is %q", healthState)
+		}
+	}
+
+	return true, ""
+}
+
 func isUsingLatestImage(update virtualmachinescalesets.VirtualMachineScaleSetUpdate) bool {
 	if update.Properties.VirtualMachineProfile.StorageProfile == nil ||
 		update.Properties.VirtualMachineProfile.StorageProfile.ImageReference == nil ||
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compute
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachinescalesetvms"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+func TestVirtualMachineScaleSetManualUpgradeBatches(t *testing.T) {
+	instanceIds := []string{"0", "1", "2", "3", "4"}
+
+	testCases := []struct {
+		Name           string
+		TotalInstances int
+		Input          *features.VirtualMachineScaleSetManualUpgradeFeatures
+		Expected       [][]string
+	}{
+		{
+			Name:           "Not Configured",
+			TotalInstances: 5,
+			Input:          nil,
+			Expected:       [][]string{{"0"}, {"1"}, {"2"}, {"3"}, {"4"}},
+		},
+		{
+			Name:           "Instance Count",
+			TotalInstances: 5,
+			Input: &features.VirtualMachineScaleSetManualUpgradeFeatures{
+				MaxBatchInstanceCount: 2,
+			},
+			Expected: [][]string{{"0", "1"}, {"2", "3"}, {"4"}},
+		},
+		{
+			Name:           "Instance Count larger than the Scale Set",
+			TotalInstances: 5,
+			Input: &features.VirtualMachineScaleSetManualUpgradeFeatures{
+				MaxBatchInstanceCount: 10,
+			},
+			Expected: [][]string{{"0", "1", "2", "3", "4"}},
+		},
+		{
+			Name:           "Instance Percent of the Scale Set",
+			TotalInstances: 10,
+			Input: &features.VirtualMachineScaleSetManualUpgradeFeatures{
+				MaxBatchInstanceCount:   1,
+				MaxBatchInstancePercent: 20,
+			},
+			Expected: [][]string{{"0", "1"}, {"2", "3"}, {"4"}},
+		},
+		{
+			Name:           "Instance Percent is rounded up",
+			TotalInstances: 5,
+			Input: &features.VirtualMachineScaleSetManualUpgradeFeatures{
+				MaxBatchInstanceCount:   1,
+				MaxBatchInstancePercent: 10,
+			},
+			Expected: [][]string{{"0"}, {"1"}, {"2"}, {"3"}, {"4"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("[DEBUG] Testing %q", testCase.Name)
+
+		actual := virtualMachineScaleSetManualUpgradeBatches(instanceIds, testCase.TotalInstances, testCase.Input)
+		if !reflect.DeepEqual(actual, testCase.Expected) {
+			t.Fatalf("expected %+v but got %+v", testCase.Expected, actual)
+		}
+	}
+}
+
+func TestVirtualMachineScaleSetInstanceIsHealthy(t *testing.T) {
+	buildInstance := func(provisioningState string, healthState *string, statuses ...string) virtualmachinescalesetvms.VirtualMachineScaleSetVM {
+		results := make([]virtualmachinescalesetvms.InstanceViewStatus, 0)
+		for _, v := range statuses {
+			results = append(results, virtualmachinescalesetvms.InstanceViewStatus{
+				Code: pointer.To(v),
+			})
+		}
+
+		instanceView := &virtualmachinescalesetvms.VirtualMachineScaleSetVMInstanceView{
+			Statuses: &results,
+		}
+		if healthState != nil {
+			instanceView.VMHealth = &virtualmachinescalesetvms.VirtualMachineHealthStatus{
+				Status: &virtualmachinescalesetvms.InstanceViewStatus{
+					Code: healthState,
+				},
+			}
+		}
+
+		return virtualmachinescalesetvms.VirtualMachineScaleSetVM{
+			Properties: &virtualmachinescalesetvms.VirtualMachineScaleSetVMProperties{
+				InstanceView:      instanceView,
+				ProvisioningState: pointer.To(provisioningState),
+			},
+		}
+	}
+
+	testCases := []struct {
+		Name     string
+		Input    virtualmachinescalesetvms.VirtualMachineScaleSetVM
+		Expected bool
+	}{
+		{
+			Name:     "No Properties",
+			Input:    virtualmachinescalesetvms.VirtualMachineScaleSetVM{},
+			Expected: false,
+		},
+		{
+			Name:     "Updating",
+			Input:    buildInstance("Updating", nil, "ProvisioningState/updating", "PowerState/running"),
+			Expected: false,
+		},
+		{
+			Name:     "No Power State",
+			Input:    buildInstance("Succeeded", nil, "ProvisioningState/succeeded"),
+			Expected: false,
+		},
+		{
+			Name:     "Stopped",
+			Input:    buildInstance("Succeeded", nil, "ProvisioningState/succeeded", "PowerState/stopped"),
+			Expected: false,
+		},
+		{
+			Name:     "Running without a Health Extension",
+			Input:    buildInstance("Succeeded", nil, "ProvisioningState/succeeded", "PowerState/running"),
+			Expected: true,
+		},
+		{
+			Name:     "Running and Healthy",
+			Input:    buildInstance("Succeeded", pointer.To("HealthState/healthy"), "ProvisioningState/succeeded", "PowerState/running"),
+			Expected: true,
+		},
+		{
+			Name:     "Running and Unhealthy",
+			Input:    buildInstance("Succeeded", pointer.To("HealthState/unhealthy"), "ProvisioningState/succeeded", "PowerState/running"),
+			Expected: false,
+		},
+		{
+			Name:     "Running and Health Unknown",
+			Input:    buildInstance("Succeeded", pointer.To("HealthState/unknown"), "ProvisioningState/succeeded", "PowerState/running"),
+			Expected: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("[DEBUG] Testing %q", testCase.Name)
+
+		actual, reason := virtualMachineScaleSetInstanceIsHealthy(testCase.Input)
+		if actual != testCase.Expected {
+			t.Fatalf("expected %t but got %t (%s)", testCase.Expected, actual, reason)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compute
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/skuavailability"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// virtualMachineSizeCustomizeDiff validates that the `size` of a Linux/Windows Virtual Machine is available in the
+// Location (and Zone) and supports the OS Disk configuration, so that this is surfaced at plan time rather than during the apply
+func virtualMachineSizeCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("size", "zone", "os_disk") {
+		return nil
+	}
+	if !d.NewValueKnown("size") || !d.NewValueKnown("location") || !d.NewValueKnown("zone") {
+		return nil
+	}
+
+	requirements := skuavailability.Requirements{
+		Size: d.Get("size").(string),
+	}
+	if zone := d.Get("zone").(string); zone != "" {
+		requirements.Zones = []string{zone}
+	}
+	if osDisk := d.Get("os_disk").([]interface{}); len(osDisk) > 0 && osDisk[0] != nil {
+		raw := osDisk[0].(map[string]interface{})
+		requirements.PremiumIO = virtualMachineSizeStorageAccountTypeIsPremium(raw["storage_account_type"].(string))
+		requirements.EphemeralOSDisk = len(raw["diff_disk_settings"].([]interface{})) > 0
+	}
+
+	client := meta.(*clients.Client)
+	return client.Compute.SkuCatalogue.Validate(ctx, client.Account.SubscriptionId, d.Get("location").(string), requirements)
+}
+
+// virtualMachineScaleSetSizeCustomizeDiff validates that the `sku` of a Linux/Windows Virtual Machine Scale Set is available
+// in the Location (and Zones) and supports the OS Disk and Network Interface configuration
+func virtualMachineScaleSetSizeCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("sku", "zones", "os_disk", "network_interface") {
+		return nil
+	}
+	if !d.NewValueKnown("sku") || !d.NewValueKnown("location") || !d.NewValueKnown("zones") {
+		return nil
+	}
+
+	requirements := skuavailability.Requirements{
+		Size: d.Get("sku").(string),
+	}
+	for _, v := range d.Get("zones").(*pluginsdk.Set).List() {
+		requirements.Zones = append(requirements.Zones, v.(string))
+	}
+	if osDisk := d.Get("os_disk").([]interface{}); len(osDisk) > 0 && osDisk[0] != nil {
+		raw := osDisk[0].(map[string]interface{})
+		requirements.PremiumIO = virtualMachineSizeStorageAccountTypeIsPremium(raw["storage_account_type"].(string))
+		requirements.EphemeralOSDisk = len(raw["diff_disk_settings"].([]interface{})) > 0
+	}
+	for _, v := range d.Get("network_interface").([]interface{}) {
+		if raw, ok := v.(map[string]interface{}); ok && raw["enable_accelerated_networking"].(bool) {
+			requirements.AcceleratedNetworking = true
+		}
+	}
+
+	client := meta.(*clients.Client)
+	return client.Compute.SkuCatalogue.Validate(ctx, client.Account.SubscriptionId, d.Get("location").(string), requirements)
+}
+
+func virtualMachineSizeStorageAccountTypeIsPremium(input string) bool {
+	for _, v := range []virtualmachines.StorageAccountTypes{
+		virtualmachines.StorageAccountTypesPremiumLRS,
+		virtualmachines.StorageAccountTypesPremiumZRS,
+	} {
+		if strings.EqualFold(input, string(v)) {
+			return true
+		}
+	}
+	return false
+}
@@ -102,7 +102,9 @@ func (a ContainerAppCustomDomainResource) Create() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(containerAppId.ID())
+			if err := locks.ByID(ctx, containerAppId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(containerAppId.ID())
 
 			id := parse.NewContainerAppCustomDomainId(containerAppId.SubscriptionId, containerAppId.ResourceGroupName, containerAppId.ContainerAppName, model.Name)
@@ -246,7 +248,9 @@ func (a ContainerAppCustomDomainResource) Delete() sdk.ResourceFunc {
 			// attempt to lock the cert if we have the ID
 			if certIdRaw := metadata.ResourceData.Get("container_app_environment_certificate_id").(string); certIdRaw != "" {
 				if certId, err := managedenvironments.ParseCertificateID(certIdRaw); err == nil {
-					locks.ByID(certId.ID())
+					if err := locks.ByID(ctx, certId.ID()); err != nil {
+						return err
+					}
 					defer locks.UnlockByID(certId.ID())
 				}
 			}
@@ -0,0 +1,140 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ExecAPIVersion is the version of the client authentication API used by the exec credential plugin
+	ExecAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+	// ExecCommand is the exec credential plugin used to authenticate to an AKS cluster using Entra ID
+	ExecCommand = "kubelogin"
+
+	// aksServerApplicationId is the Application ID of the Entra ID Application used by the AKS API Server, which is
+	// the same in all clouds
+	aksServerApplicationId = "6dae42f8-4368-4678-94ff-3960e28e3630"
+)
+
+type userItemExec struct {
+	Name string   `yaml:"name"`
+	User userExec `yaml:"user"`
+}
+
+type userExec struct {
+	Exec execConfig `yaml:"exec"`
+}
+
+type execConfig struct {
+	APIVersion      string   `yaml:"apiVersion"`
+	Command         string   `yaml:"command"`
+	Args            []string `yaml:"args"`
+	InteractiveMode string   `yaml:"interactiveMode"`
+}
+
+type KubeConfigExec struct {
+	KubeConfigBase `yaml:",inline"`
+	Users          []userItemExec `yaml:"users"`
+}
+
+// ExecLoginOptions configures how `kubelogin` obtains a token for the AKS cluster
+type ExecLoginOptions struct {
+	// LoginMode is the `kubelogin` login mode, e.g. `azurecli`, `msi`, `spn` or `workloadidentity`
+	LoginMode string
+
+	// Environment is the name of the Azure Environment as known by `kubelogin`, e.g. `AzurePublicCloud`
+	Environment string
+
+	TenantId string
+
+	// ClientId is the Client ID of the Service Principal or Managed Identity, which isn't used by the `azurecli` login mode
+	ClientId string
+}
+
+// ExecInteractiveMode returns the `interactiveMode` of the exec credential plugin for the login mode, since the
+// `devicecode` and `interactive` login modes prompt the user - and so require access to stdin when it's available
+func ExecInteractiveMode(loginMode string) string {
+	switch loginMode {
+	case "devicecode", "interactive":
+		return "IfAvailable"
+	}
+
+	return "Never"
+}
+
+// ExecArgs returns the arguments for `kubelogin` to obtain a token using the specified options
+func ExecArgs(options ExecLoginOptions) []string {
+	args := []string{
+		"get-token",
+		"--login", options.LoginMode,
+		"--server-id", aksServerApplicationId,
+		"--environment", options.Environment,
+		"--tenant-id", options.TenantId,
+	}
+
+	if options.LoginMode != "azurecli" && options.ClientId != "" {
+		args = append(args, "--client-id", options.ClientId)
+	}
+
+	return args
+}
+
+// NewKubeConfigExec builds a kubeconfig which uses `kubelogin` to authenticate to the cluster, which contains no credentials
+func NewKubeConfigExec(name, server, clusterAuthorityData string, options ExecLoginOptions) (*string, error) {
+	if server == "" {
+		return nil, fmt.Errorf("the server for the cluster %q was empty", name)
+	}
+
+	userName := fmt.Sprintf("clusterUser_%s", name)
+	config := KubeConfigExec{
+		KubeConfigBase: KubeConfigBase{
+			APIVersion: "v1",
+			Clusters: []clusterItem{
+				{
+					Name: name,
+					Cluster: cluster{
+						ClusterAuthorityData: clusterAuthorityData,
+						Server:               server,
+					},
+				},
+			},
+			Contexts: []contextItem{
+				{
+					Name: name,
+					Context: context{
+						Cluster: name,
+						User:    userName,
+					},
+				},
+			},
+			CurrentContext: name,
+			Kind:           "Config",
+		},
+		Users: []userItemExec{
+			{
+				Name: userName,
+				User: userExec{
+					Exec: execConfig{
+						APIVersion:      ExecAPIVersion,
+						Command:         ExecCommand,
+						Args:            ExecArgs(options),
+						InteractiveMode: ExecInteractiveMode(options.LoginMode),
+					},
+				},
+			},
+		},
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling the kubeconfig: %+v", err)
+	}
+
+	result := string(out)
+	return &result, nil
+}
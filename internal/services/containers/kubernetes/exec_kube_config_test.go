@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestExecArgs(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Input    ExecLoginOptions
+		Expected []string
+	}{
+		{
+			Name: "Azure CLI",
+			Input: ExecLoginOptions{
+				LoginMode:   "azurecli",
+				Environment: "AzurePublicCloud",
+				TenantId:    "00000000-0000-0000-0000-000000000000",
+				ClientId:    "04b07795-8ddb-461a-bbee-02f9e1bf7b46",
+			},
+			Expected: []string{"get-token", "--login", "azurecli", "--server-id", "6dae42f8-4368-4678-94ff-3960e28e3630", "--environment", "AzurePublicCloud", "--tenant-id", "00000000-0000-0000-0000-000000000000"},
+		},
+		{
+			Name: "Workload Identity",
+			Input: ExecLoginOptions{
+				LoginMode:   "workloadidentity",
+				Environment: "AzureChinaCloud",
+				TenantId:    "00000000-0000-0000-0000-000000000000",
+				ClientId:    "11111111-1111-1111-1111-111111111111",
+			},
+			Expected: []string{"get-token", "--login", "workloadidentity", "--server-id", "6dae42f8-4368-4678-94ff-3960e28e3630", "--environment", "AzureChinaCloud", "--tenant-id", "00000000-0000-0000-0000-000000000000", "--client-id", "11111111-1111-1111-1111-111111111111"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("[DEBUG] Testing %q", testCase.Name)
+
+		actual := ExecArgs(testCase.Input)
+		if !reflect.DeepEqual(actual, testCase.Expected) {
+			t.Fatalf("expected %+v but got %+v", testCase.Expected, actual)
+		}
+	}
+}
+
+func TestNewKubeConfigExec(t *testing.T) {
+	options := ExecLoginOptions{
+		LoginMode:   "spn",
+		Environment: "AzurePublicCloud",
+		TenantId:    "00000000-0000-0000-0000-000000000000",
+		ClientId:    "11111111-1111-1111-1111-111111111111",
+	}
+
+	if _, err := NewKubeConfigExec("example", "", "Y2VydA==", options); err == nil {
+		t.Fatalf("expected an error when the server is empty but didn't get one")
+	}
+
+	raw, err := NewKubeConfigExec("example", "https://example.hcp.westeurope.azmk8s.io:443", "Y2VydA==", options)
+	if err != nil {
+		t.Fatalf("building the kubeconfig: %+v", err)
+	}
+
+	for _, v := range []string{"token:", "client-key-data:", "client-certificate-data:", "password:"} {
+		if strings.Contains(*raw, v) {
+			t.Fatalf("expected the kubeconfig not to contain %q but got:\n\n%s", v, *raw)
+		}
+	}
+
+	var config KubeConfigExec
+	if err := yaml.Unmarshal([]byte(*raw), &config); err != nil {
+		t.Fatalf("unmarshaling the kubeconfig: %+v", err)
+	}
+
+	if len(config.Clusters) != 1 || config.Clusters[0].Cluster.Server != "https://example.hcp.westeurope.azmk8s.io:443" || config.Clusters[0].Cluster.ClusterAuthorityData != "Y2VydA==" {
+		t.Fatalf("unexpected clusters: %+v", config.Clusters)
+	}
+	if len(config.Users) != 1 || config.Users[0].Name != config.Contexts[0].Context.User {
+		t.Fatalf("unexpected users: %+v", config.Users)
+	}
+
+	exec := config.Users[0].User.Exec
+	if exec.Command != ExecCommand || exec.APIVersion != ExecAPIVersion {
+		t.Fatalf("unexpected exec configuration: %+v", exec)
+	}
+	if !reflect.DeepEqual(exec.Args, ExecArgs(options)) {
+		t.Fatalf("expected the args %+v but got %+v", ExecArgs(options), exec.Args)
+	}
+	if exec.InteractiveMode != "Never" {
+		t.Fatalf("expected the interactive mode to be %q but got %q", "Never", exec.InteractiveMode)
+	}
+}
+
+func TestExecInteractiveMode(t *testing.T) {
+	testCases := map[string]string{
+		"azd":              "Never",
+		"azurecli":         "Never",
+		"devicecode":       "IfAvailable",
+		"interactive":      "IfAvailable",
+		"msi":              "Never",
+		"spn":              "Never",
+		"workloadidentity": "Never",
+	}
+
+	for loginMode, expected := range testCases {
+		t.Logf("[DEBUG] Testing %q", loginMode)
+
+		if actual := ExecInteractiveMode(loginMode); actual != expected {
+			t.Fatalf("expected %q but got %q", expected, actual)
+		}
+	}
+}
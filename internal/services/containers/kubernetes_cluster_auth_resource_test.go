@@ -260,6 +260,33 @@ func TestAccKubernetesCluster_roleBasedAccessControlAADManagedWithLocalAccountDi
 	})
 }
 
+func TestAccKubernetesCluster_roleBasedAccessControlAADManagedWithCredentialsDisabled(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
+	r := KubernetesClusterResource{}
+	clientData := data.Client()
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.roleBasedAccessControlAADManagedConfigWithCredentialsDisabled(data, clientData.TenantID),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("kube_config_raw").IsEmpty(),
+				check.That(data.ResourceName).Key("kube_config.0.host").IsSet(),
+				check.That(data.ResourceName).Key("kube_config.0.client_key").IsEmpty(),
+				check.That(data.ResourceName).Key("kube_admin_config.#").HasValue("0"),
+				check.That(data.ResourceName).Key("kube_admin_config_raw").IsEmpty(),
+				check.That(data.ResourceName).Key("exec_kube_config.0.host").IsSet(),
+				check.That(data.ResourceName).Key("exec_kube_config.0.cluster_ca_certificate").IsSet(),
+				check.That(data.ResourceName).Key("exec_kube_config.0.command").HasValue("kubelogin"),
+				check.That(data.ResourceName).Key("exec_kube_config.0.args.2").HasValue("azurecli"),
+				check.That(data.ResourceName).Key("exec_kube_config_raw").IsSet(),
+			),
+		},
+		// the credentials are retrieved when importing, since `kube_config_credentials_disabled` defaults to `false`
+		data.ImportStep("azure_active_directory_role_based_access_control.0.server_app_secret", "kube_config", "kube_admin_config"),
+	})
+}
+
 func TestAccKubernetesCluster_roleBasedAccessControlAADManagedWithLocalAccountDisabledUpdated(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster", "test")
 	r := KubernetesClusterResource{}
@@ -941,6 +968,46 @@ resource "azurerm_kubernetes_cluster" "test" {
 `, tenantId, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger, data.RandomInteger)
 }
 
+func (KubernetesClusterResource) roleBasedAccessControlAADManagedConfigWithCredentialsDisabled(data acceptance.TestData, tenantId string) string {
+	return fmt.Sprintf(`
+variable "tenant_id" {
+  default = "%s"
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-aks-%d"
+  location = "%s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                             = "acctestaks%d"
+  location                         = "${azurerm_resource_group.test.location}"
+  resource_group_name              = "${azurerm_resource_group.test.name}"
+  dns_prefix                       = "acctestaks%d"
+  kube_config_credentials_disabled = true
+
+  default_node_pool {
+    name       = "default"
+    node_count = 1
+    vm_size    = "Standard_DS2_v2"
+    upgrade_settings {
+      max_surge = "10%%"
+    }
+  }
+
+  identity {
+    type = "SystemAssigned"
+  }
+
+  azure_active_directory_role_based_access_control {
+    tenant_id          = var.tenant_id
+    managed            = true
+    azure_rbac_enabled = true
+  }
+}
+`, tenantId, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
+}
+
 func (KubernetesClusterResource) roleBasedAccessControlAADManagedConfigScale(data acceptance.TestData, tenantId string) string {
 	return fmt.Sprintf(`
 variable "tenant_id" {
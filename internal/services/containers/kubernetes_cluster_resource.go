@@ -26,6 +26,7 @@ import (
 	dnsValidate "github.com/hashicorp/go-azure-sdk/resource-manager/dns/2018-05-01/zones"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/operationalinsights/2020-08-01/workspaces"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/privatedns/2020-06-01/privatezones"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
@@ -33,6 +34,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	computeValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/kubernetes"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/migration"
 	containerValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
 	keyVaultClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/client"
@@ -113,6 +115,16 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 			pluginsdk.ForceNewIfChange("custom_ca_trust_certificates_base64", func(ctx context.Context, old, new, meta interface{}) bool {
 				return len(old.([]interface{})) > 0 && len(new.([]interface{})) == 0
 			}),
+			func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if !d.Get("kube_config_credentials_disabled").(bool) {
+					return nil
+				}
+				if len(d.Get("azure_active_directory_role_based_access_control").([]interface{})) == 0 {
+					return fmt.Errorf("`kube_config_credentials_disabled` can only be enabled when `azure_active_directory_role_based_access_control` is specified")
+				}
+				return nil
+			},
+			kubernetesClusterDefaultNodePoolSizeCustomizeDiff,
 		),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
@@ -371,6 +383,64 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 				Optional:   true,
 			},
 
+			"exec_kube_config": {
+				Type:     pluginsdk.TypeList,
+				Computed: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"host": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"cluster_ca_certificate": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"api_version": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"command": {
+							Type:     pluginsdk.TypeString,
+							Computed: true,
+						},
+						"args": {
+							Type:     pluginsdk.TypeList,
+							Computed: true,
+							Elem: &pluginsdk.Schema{
+								Type: pluginsdk.TypeString,
+							},
+						},
+					},
+				},
+			},
+
+			"exec_kube_config_raw": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"exec_kube_config_login_mode": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				Default:  "azurecli",
+				ValidateFunc: validation.StringInSlice([]string{
+					"azd",
+					"azurecli",
+					"devicecode",
+					"interactive",
+					"msi",
+					"spn",
+					"workloadidentity",
+				}, false),
+			},
+
+			"exec_kube_config_client_id": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+			},
+
 			"fqdn": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -544,6 +614,12 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 				},
 			},
 
+			"kube_config_credentials_disabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"kube_config_raw": {
 				Type:      pluginsdk.TypeString,
 				Computed:  true,
@@ -2700,6 +2776,9 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("retrieving User Credentials for %s: payload is empty", id)
 	}
 
+	// the credentials are omitted from the state when disabled, the exec kubeconfig should be used instead
+	credentialsDisabled := d.Get("kube_config_credentials_disabled").(bool)
+
 	d.Set("name", id.ManagedClusterName)
 	d.Set("resource_group_name", id.ResourceGroupName)
 
@@ -2942,7 +3021,7 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 			// adminProfile is only available for RBAC enabled clusters with AAD and local account is not disabled
 			var adminKubeConfigRaw *string
 			adminKubeConfig := make([]interface{}, 0)
-			if props.AadProfile != nil && (props.DisableLocalAccounts == nil || !*props.DisableLocalAccounts) {
+			if props.AadProfile != nil && (props.DisableLocalAccounts == nil || !*props.DisableLocalAccounts) && !credentialsDisabled {
 				adminCredentials, err := client.ListClusterAdminCredentials(ctx, *id, managedclusters.ListClusterAdminCredentialsOperationOptions{})
 				if err != nil {
 					return fmt.Errorf("retrieving Admin Credentials for %s: %+v", id, err)
@@ -2967,6 +3046,20 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 		}
 
 		kubeConfigRaw, kubeConfig := flattenKubernetesClusterCredentials(credentials.Model, "clusterUser")
+
+		execKubeConfigRaw, execKubeConfig, err := flattenKubernetesClusterExecKubeConfig(id.ManagedClusterName, kubeConfig, model.Properties, expandKubernetesClusterExecLoginOptions(d, meta.(*clients.Client).Account))
+		if err != nil {
+			return fmt.Errorf("building the exec kubeconfig for %s: %+v", id, err)
+		}
+		d.Set("exec_kube_config_raw", execKubeConfigRaw)
+		if err := d.Set("exec_kube_config", execKubeConfig); err != nil {
+			return fmt.Errorf("setting `exec_kube_config`: %+v", err)
+		}
+
+		if credentialsDisabled {
+			kubeConfigRaw = nil
+			kubeConfig = flattenKubernetesClusterKubeConfigWithoutCredentials(kubeConfig)
+		}
 		d.Set("kube_config_raw", kubeConfigRaw)
 		if err := d.Set("kube_config", kubeConfig); err != nil {
 			return fmt.Errorf("setting `kube_config`: %+v", err)
@@ -4809,3 +4902,91 @@ func convertCustomCaTrustCertsInput(input []interface{}) *[]string {
 	return &customCaTrustCertList
 
 }
+
+// flattenKubernetesClusterKubeConfigWithoutCredentials returns the `kube_config` block containing only the host and
+// the certificate authority for the cluster, omitting any credentials
+func flattenKubernetesClusterKubeConfigWithoutCredentials(input []interface{}) []interface{} {
+	output := make([]interface{}, 0)
+	for _, item := range input {
+		v, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		output = append(output, map[string]interface{}{
+			"host":                   v["host"],
+			"username":               "",
+			"password":               "",
+			"client_certificate":     "",
+			"client_key":             "",
+			"cluster_ca_certificate": v["cluster_ca_certificate"],
+		})
+	}
+
+	return output
+}
+
+// flattenKubernetesClusterExecKubeConfig builds a kubeconfig which uses `kubelogin` to authenticate to the cluster using
+// the specified login mode - which is only possible for clusters integrated with Azure Active Directory
+func flattenKubernetesClusterExecKubeConfig(name string, kubeConfig []interface{}, props *managedclusters.ManagedClusterProperties, options kubernetes.ExecLoginOptions) (*string, []interface{}, error) {
+	if props == nil || props.AadProfile == nil || len(kubeConfig) == 0 {
+		return nil, []interface{}{}, nil
+	}
+
+	config, ok := kubeConfig[0].(map[string]interface{})
+	if !ok {
+		return nil, []interface{}{}, nil
+	}
+	host, _ := config["host"].(string)
+	clusterCaCertificate, _ := config["cluster_ca_certificate"].(string)
+	if host == "" {
+		return nil, []interface{}{}, nil
+	}
+
+	if v := props.AadProfile.TenantID; v != nil && *v != "" {
+		options.TenantId = *v
+	}
+
+	raw, err := kubernetes.NewKubeConfigExec(name, host, clusterCaCertificate, options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args := make([]interface{}, 0)
+	for _, v := range kubernetes.ExecArgs(options) {
+		args = append(args, v)
+	}
+
+	return raw, []interface{}{
+		map[string]interface{}{
+			"host":                   host,
+			"cluster_ca_certificate": clusterCaCertificate,
+			"api_version":            kubernetes.ExecAPIVersion,
+			"command":                kubernetes.ExecCommand,
+			"args":                   args,
+		},
+	}, nil
+}
+
+// expandKubernetesClusterExecLoginOptions returns the options used to build the exec kubeconfig, which are taken from
+// the configuration rather than the authentication method used by the Provider, so that these don't change depending
+// on where the cluster was last refreshed
+func expandKubernetesClusterExecLoginOptions(d *pluginsdk.ResourceData, account *clients.ResourceManagerAccount) kubernetes.ExecLoginOptions {
+	options := kubernetes.ExecLoginOptions{
+		LoginMode:   d.Get("exec_kube_config_login_mode").(string),
+		Environment: "AzurePublicCloud",
+		ClientId:    d.Get("exec_kube_config_client_id").(string),
+	}
+
+	if account != nil {
+		switch account.Environment.Name {
+		case environments.AzureChinaCloud:
+			options.Environment = "AzureChinaCloud"
+		case environments.AzureUSGovernmentCloud:
+			options.Environment = "AzureUSGovernmentCloud"
+		}
+		options.TenantId = account.TenantId
+	}
+
+	return options
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-06-02-preview/agentpools"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/skuavailability"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// kubernetesClusterDefaultNodePoolSizeCustomizeDiff validates that the `vm_size` of the Default Node Pool is available in
+// the Location (and Zones) and supports the OS Disk Type, so that this is surfaced at plan time rather than during the apply
+func kubernetesClusterDefaultNodePoolSizeCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("default_node_pool.0.vm_size", "default_node_pool.0.zones", "default_node_pool.0.os_disk_type") {
+		return nil
+	}
+	if !d.NewValueKnown("default_node_pool.0.vm_size") || !d.NewValueKnown("default_node_pool.0.zones") || !d.NewValueKnown("location") {
+		return nil
+	}
+
+	requirements := expandKubernetesNodePoolSizeRequirements(d.Get("default_node_pool.0.vm_size").(string), d.Get("default_node_pool.0.zones").(*pluginsdk.Set).List(), d.Get("default_node_pool.0.os_disk_type").(string))
+
+	client := meta.(*clients.Client)
+	return client.Compute.SkuCatalogue.Validate(ctx, client.Account.SubscriptionId, d.Get("location").(string), requirements)
+}
+
+// kubernetesClusterNodePoolSizeCustomizeDiff validates that the `vm_size` of the Node Pool is available in the Location of
+// the Kubernetes Cluster (and Zones) and supports the OS Disk Type
+func kubernetesClusterNodePoolSizeCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChanges("vm_size", "zones", "os_disk_type") {
+		return nil
+	}
+	if !d.NewValueKnown("vm_size") || !d.NewValueKnown("zones") || !d.NewValueKnown("kubernetes_cluster_id") {
+		return nil
+	}
+
+	clusterId, err := commonids.ParseKubernetesClusterID(d.Get("kubernetes_cluster_id").(string))
+	if err != nil {
+		// this is validated by the schema
+		return nil
+	}
+
+	// the Node Pool doesn't expose the Location, so this is retrieved from the Kubernetes Cluster
+	client := meta.(*clients.Client)
+	cluster, err := client.Containers.KubernetesClustersClient.Get(ctx, *clusterId)
+	if err != nil || cluster.Model == nil {
+		log.Printf("[WARN] unable to retrieve %s to validate the Node Pool `vm_size`: %+v", *clusterId, err)
+		return nil
+	}
+
+	requirements := expandKubernetesNodePoolSizeRequirements(d.Get("vm_size").(string), d.Get("zones").(*pluginsdk.Set).List(), d.Get("os_disk_type").(string))
+	return client.Compute.SkuCatalogue.Validate(ctx, client.Account.SubscriptionId, cluster.Model.Location, requirements)
+}
+
+func expandKubernetesNodePoolSizeRequirements(vmSize string, zones []interface{}, osDiskType string) skuavailability.Requirements {
+	requirements := skuavailability.Requirements{
+		Size:            vmSize,
+		EphemeralOSDisk: strings.EqualFold(osDiskType, string(agentpools.OSDiskTypeEphemeral)),
+	}
+	for _, v := range zones {
+		requirements.Zones = append(requirements.Zones, v.(string))
+	}
+
+	return requirements
+}
@@ -83,7 +83,9 @@ func (r CosmosDbPostgreSQLCoordinatorConfigurationResource) Create() sdk.Resourc
 
 			id := configurations.NewCoordinatorConfigurationID(clusterId.SubscriptionId, clusterId.ResourceGroupName, clusterId.ServerGroupsv2Name, model.Name)
 
-			locks.ByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
+			if err := locks.ByName(ctx, id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
 
 			parameters := configurations.ServerConfiguration{
@@ -112,7 +114,9 @@ func (r CosmosDbPostgreSQLCoordinatorConfigurationResource) Update() sdk.Resourc
 				return err
 			}
 
-			locks.ByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
+			if err := locks.ByName(ctx, id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
 
 			var model CosmosDbPostgreSQLCoordinatorConfigurationModel
@@ -184,7 +188,9 @@ func (r CosmosDbPostgreSQLCoordinatorConfigurationResource) Delete() sdk.Resourc
 				return err
 			}
 
-			locks.ByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
+			if err := locks.ByName(ctx, id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
 
 			resp, err := client.GetCoordinator(ctx, *id)
@@ -103,7 +103,9 @@ func resourceCosmosDbSQLRoleAssignmentCreate(d *pluginsdk.ResourceData, meta int
 
 	id := parse.NewSqlRoleAssignmentID(subscriptionId, resourceGroup, accountName, name)
 
-	locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+	if err := locks.ByName(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 	existing, err := client.GetSQLRoleAssignment(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName)
@@ -181,7 +183,9 @@ func resourceCosmosDbSQLRoleAssignmentUpdate(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+	if err := locks.ByName(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 	parameters := documentdb.SQLRoleAssignmentCreateUpdateParameters{
@@ -215,7 +219,9 @@ func resourceCosmosDbSQLRoleAssignmentDelete(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+	if err := locks.ByName(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 	future, err := client.DeleteSQLRoleAssignment(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName)
@@ -851,7 +851,9 @@ func resourceIotHubCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	id := parse.NewIotHubID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, IothubResourceName)
+	if err := locks.ByName(ctx, id.Name, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, IothubResourceName)
 
 	if d.IsNewResource() {
@@ -996,7 +998,9 @@ func resourceIotHubUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return fmt.Errorf("parsing %s: %+v", id, err)
 	}
 
-	locks.ByName(id.Name, IothubResourceName)
+	if err := locks.ByName(ctx, id.Name, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, IothubResourceName)
 
 	iothub, err := client.Get(ctx, id.ResourceGroup, id.Name)
@@ -1318,7 +1322,9 @@ func resourceIotHubDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	locks.ByName(id.Name, IothubResourceName)
+	if err := locks.ByName(ctx, id.Name, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, IothubResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
@@ -45,6 +45,8 @@ func resourceKeyVaultSecret() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(keyVaultSecretCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -56,9 +58,23 @@ func resourceKeyVaultSecret() *pluginsdk.Resource {
 			"key_vault_id": commonschema.ResourceIDReferenceRequiredForceNew(&commonids.KeyVaultId{}),
 
 			"value": {
-				Type:      pluginsdk.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressKeyVaultSecretValueDiff,
+				ExactlyOneOf:     []string{"value", "value_file_path"},
+			},
+
+			"value_file_path": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"value", "value_file_path"},
+			},
+
+			"store_value_as_hash": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
 			},
 
 			"content_type": {
@@ -78,6 +94,11 @@ func resourceKeyVaultSecret() *pluginsdk.Resource {
 				ValidateFunc: validation.IsRFC3339Time,
 			},
 
+			"value_hash": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"version": {
 				Type:     pluginsdk.TypeString,
 				Computed: true,
@@ -133,7 +154,10 @@ func resourceKeyVaultSecretCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		return tf.ImportAsExistsError("azurerm_key_vault_secret", *existing.ID)
 	}
 
-	value := d.Get("value").(string)
+	value, _, err := keyVaultSecretValueFromConfig(d)
+	if err != nil {
+		return err
+	}
 	contentType := d.Get("content_type").(string)
 	t := d.Get("tags").(map[string]interface{})
 
@@ -242,7 +266,10 @@ func resourceKeyVaultSecretUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		return nil
 	}
 
-	value := d.Get("value").(string)
+	value, _, err := keyVaultSecretValueFromConfig(d)
+	if err != nil {
+		return err
+	}
 	contentType := d.Get("content_type").(string)
 	t := d.Get("tags").(map[string]interface{})
 
@@ -260,7 +287,20 @@ func resourceKeyVaultSecretUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		secretAttributes.Expires = &expirationUnixTime
 	}
 
-	if d.HasChange("value") {
+	valueChanged := d.HasChange("value")
+	if keyVaultSecretStoresValueHash(d) {
+		valueChanged = d.HasChange("value_hash")
+	}
+	if d.HasChanges("store_value_as_hash", "value_file_path") {
+		// the way the value is sourced or stored has changed, so compare against the current value to avoid creating a new version
+		existing, err := client.GetSecret(ctx, id.KeyVaultBaseUrl, id.Name, "")
+		if err != nil {
+			return fmt.Errorf("retrieving Key Vault Secret %q: %+v", id.Name, err)
+		}
+		valueChanged = existing.Value == nil || *existing.Value != value
+	}
+
+	if valueChanged {
 		// for changing the value of the secret we need to create a new version
 		parameters := keyvault.SecretSetParameters{
 			Value:            utils.String(value),
@@ -356,7 +396,22 @@ func resourceKeyVaultSecretRead(d *pluginsdk.ResourceData, meta interface{}) err
 	}
 
 	d.Set("name", respID.Name)
-	d.Set("value", resp.Value)
+	if keyVaultSecretStoresValueHash(d) {
+		// only the hash of the value is persisted into the state, which is compared against the configured value during the plan
+		value := ""
+		if resp.Value != nil {
+			value = *resp.Value
+		}
+		valueHash, err := newKeyVaultSecretValueHash(value, d.Get("value_hash").(string))
+		if err != nil {
+			return fmt.Errorf("hashing the value of Key Vault Secret %q: %+v", id.Name, err)
+		}
+		d.Set("value", "")
+		d.Set("value_hash", valueHash)
+	} else {
+		d.Set("value", resp.Value)
+		d.Set("value_hash", "")
+	}
 	d.Set("version", respID.Version)
 	d.Set("content_type", resp.ContentType)
 	d.Set("versionless_id", id.VersionlessID())
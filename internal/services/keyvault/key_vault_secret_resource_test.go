@@ -6,6 +6,7 @@ package keyvault_test
 import (
 	"context"
 	"fmt"
+	"os"
 	"regexp"
 	"testing"
 
@@ -155,6 +156,88 @@ func TestAccKeyVaultSecret_updatingValueChangedExternally(t *testing.T) {
 	})
 }
 
+func TestAccKeyVaultSecret_storeValueAsHash(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret", "test")
+	r := KeyVaultSecretResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.storeValueAsHash(data, "rick-and-morty"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").IsEmpty(),
+				check.That(data.ResourceName).Key("value_hash").MatchesRegex(regexp.MustCompile(`^sha256:[0-9a-f]{32}:[0-9a-f]{64}$`)),
+				data.CheckWithClient(r.updateSecretValue("mad-scientist")),
+			),
+			ExpectNonEmptyPlan: true,
+		},
+		{
+			Config: r.storeValueAsHash(data, "rick-and-morty"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").IsEmpty(),
+			),
+		},
+		{
+			Config: r.storeValueAsHash(data, "szechuan"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").IsEmpty(),
+			),
+		},
+		{
+			Config: r.basicUpdated(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").HasValue("szechuan"),
+				check.That(data.ResourceName).Key("value_hash").IsEmpty(),
+			),
+		},
+	})
+}
+
+func TestAccKeyVaultSecret_valueFromFile(t *testing.T) {
+	valueFile, err := os.CreateTemp("", "")
+	if err != nil {
+		t.Fatalf("creating the local value file: %+v", err)
+	}
+	defer os.Remove(valueFile.Name())
+
+	if err := os.WriteFile(valueFile.Name(), []byte("rick-and-morty"), 0o600); err != nil {
+		t.Fatalf("writing the local value file: %+v", err)
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret", "test")
+	r := KeyVaultSecretResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.valueFromFile(data, valueFile.Name()),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").IsEmpty(),
+				check.That(data.ResourceName).Key("value_hash").IsNotEmpty(),
+			),
+		},
+		{
+			PreConfig: func() {
+				if err := os.WriteFile(valueFile.Name(), []byte("szechuan"), 0o600); err != nil {
+					t.Fatalf("updating the local value file: %+v", err)
+				}
+			},
+			Config: r.valueFromFile(data, valueFile.Name()),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").IsEmpty(),
+			),
+		},
+		{
+			Config:   r.valueFromFile(data, valueFile.Name()),
+			PlanOnly: true,
+		},
+	})
+}
+
 func TestAccKeyVaultSecret_recovery(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_key_vault_secret", "test")
 	r := KeyVaultSecretResource{}
@@ -399,6 +482,39 @@ resource "azurerm_key_vault_secret" "test" {
 `, r.template(data), data.RandomString)
 }
 
+func (r KeyVaultSecretResource) storeValueAsHash(data acceptance.TestData, value string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_secret" "test" {
+  name                = "secret-%s"
+  value               = "%s"
+  store_value_as_hash = true
+  key_vault_id        = azurerm_key_vault.test.id
+}
+`, r.template(data), data.RandomString, value)
+}
+
+func (r KeyVaultSecretResource) valueFromFile(data acceptance.TestData, fileName string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_secret" "test" {
+  name            = "secret-%s"
+  value_file_path = "%s"
+  key_vault_id    = azurerm_key_vault.test.id
+}
+`, r.template(data), data.RandomString, fileName)
+}
+
 func (r KeyVaultSecretResource) softDeleteRecovery(data acceptance.TestData, purge bool, value string) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
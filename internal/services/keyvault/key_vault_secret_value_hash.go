@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+const (
+	keyVaultSecretValueHashPrefix = "sha256"
+	keyVaultSecretValueSaltLength = 16
+)
+
+// keyVaultSecretConfig is implemented by both the ResourceData and the ResourceDiff, since the value of the
+// Secret is needed both when planning (to detect drift) and when applying.
+type keyVaultSecretConfig interface {
+	Get(key string) interface{}
+	GetRawConfig() cty.Value
+}
+
+// keyVaultSecretStoresValueHash returns whether only the hash of the Secret value should be persisted into the
+// state, which is the case when either `store_value_as_hash` is enabled or the value is sourced from a file.
+func keyVaultSecretStoresValueHash(d keyVaultSecretConfig) bool {
+	return d.Get("store_value_as_hash").(bool) || d.Get("value_file_path").(string) != ""
+}
+
+// keyVaultSecretValueFromConfig returns the value of the Secret, either from the `value` field or from the file
+// referenced by the `value_file_path` field. The raw config is used since the diff for `value` is suppressed when
+// only the hash of the value is stored. The returned bool is false when the value isn't known yet.
+func keyVaultSecretValueFromConfig(d keyVaultSecretConfig) (string, bool, error) {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return "", false, nil
+	}
+
+	if path := config.GetAttr("value_file_path"); !path.IsNull() {
+		if !path.IsKnown() {
+			return "", false, nil
+		}
+
+		contents, err := os.ReadFile(path.AsString())
+		if err != nil {
+			return "", false, fmt.Errorf("reading the Secret value from `value_file_path` %q: %+v", path.AsString(), err)
+		}
+
+		return string(contents), true, nil
+	}
+
+	value := config.GetAttr("value")
+	if !value.IsKnown() {
+		return "", false, nil
+	}
+	if value.IsNull() {
+		return "", true, nil
+	}
+
+	return value.AsString(), true, nil
+}
+
+// hashKeyVaultSecretValue returns the salted SHA-256 hash of the Secret value in the format `sha256:{salt}:{hash}`
+// where both the salt and the hash are hex encoded.
+func hashKeyVaultSecretValue(value string, salt []byte) string {
+	hash := sha256.New()
+	hash.Write(salt)
+	hash.Write([]byte(value))
+	return fmt.Sprintf("%s:%s:%s", keyVaultSecretValueHashPrefix, hex.EncodeToString(salt), hex.EncodeToString(hash.Sum(nil)))
+}
+
+// saltFromKeyVaultSecretValueHash returns the salt contained within a hash generated by hashKeyVaultSecretValue.
+func saltFromKeyVaultSecretValueHash(input string) ([]byte, error) {
+	segments := strings.Split(input, ":")
+	if len(segments) != 3 || segments[0] != keyVaultSecretValueHashPrefix {
+		return nil, fmt.Errorf("expected the hash to be in the format `%s:{salt}:{hash}` but got %q", keyVaultSecretValueHashPrefix, input)
+	}
+
+	salt, err := hex.DecodeString(segments[1])
+	if err != nil || len(salt) == 0 {
+		return nil, fmt.Errorf("parsing the salt from the hash %q: %+v", input, err)
+	}
+
+	return salt, nil
+}
+
+// keyVaultSecretValueMatchesHash returns whether the Secret value matches the existing hash, using the salt
+// contained within the existing hash.
+func keyVaultSecretValueMatchesHash(value string, existing string) bool {
+	salt, err := saltFromKeyVaultSecretValueHash(existing)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(hashKeyVaultSecretValue(value, salt)), []byte(existing)) == 1
+}
+
+// newKeyVaultSecretValueHash returns the hash of the Secret value, reusing the salt from the existing hash where
+// possible so that the hash only changes when the value does.
+func newKeyVaultSecretValueHash(value string, existing string) (string, error) {
+	if salt, err := saltFromKeyVaultSecretValueHash(existing); err == nil {
+		return hashKeyVaultSecretValue(value, salt), nil
+	}
+
+	salt := make([]byte, keyVaultSecretValueSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generating a salt: %+v", err)
+	}
+
+	return hashKeyVaultSecretValue(value, salt), nil
+}
+
+// suppressKeyVaultSecretValueDiff suppresses the diff for `value` when only the hash of the value is stored, since
+// the value is then never persisted into the state - changes are instead detected using `value_hash`.
+func suppressKeyVaultSecretValueDiff(_, _, _ string, d *pluginsdk.ResourceData) bool {
+	return d.Id() != "" && keyVaultSecretStoresValueHash(d)
+}
+
+// keyVaultSecretCustomizeDiff detects changes to the Secret value when only the hash of the value is stored, by
+// comparing the hash of the configured value against the hash of the value which was last read from the Key Vault.
+func keyVaultSecretCustomizeDiff(_ context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !keyVaultSecretStoresValueHash(d) {
+		return nil
+	}
+
+	value, known, err := keyVaultSecretValueFromConfig(d)
+	if err != nil {
+		return err
+	}
+	if !known {
+		return d.SetNewComputed("value_hash")
+	}
+
+	existing := d.Get("value_hash").(string)
+	if _, err := saltFromKeyVaultSecretValueHash(existing); err != nil {
+		// the hash isn't available when switching from storing the value, a new salt will be generated during the apply
+		return d.SetNewComputed("value_hash")
+	}
+
+	if !keyVaultSecretValueMatchesHash(value, existing) {
+		newHash, err := newKeyVaultSecretValueHash(value, existing)
+		if err != nil {
+			return err
+		}
+		return d.SetNew("value_hash", newHash)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKeyVaultSecretValueHash(t *testing.T) {
+	salt := []byte("0123456789abcdef")
+	existing := hashKeyVaultSecretValue("rick-and-morty", salt)
+
+	if !strings.HasPrefix(existing, "sha256:30313233343536373839616263646566:") {
+		t.Fatalf("expected the hash to contain the hex encoded salt but got %q", existing)
+	}
+
+	testData := []struct {
+		name     string
+		value    string
+		existing string
+		matches  bool
+	}{
+		{
+			name:     "same value",
+			value:    "rick-and-morty",
+			existing: existing,
+			matches:  true,
+		},
+		{
+			name:     "different value",
+			value:    "mad-scientist",
+			existing: existing,
+			matches:  false,
+		},
+		{
+			name:     "same value with a different salt",
+			value:    "rick-and-morty",
+			existing: hashKeyVaultSecretValue("rick-and-morty", []byte("fedcba9876543210")),
+			matches:  true,
+		},
+		{
+			name:     "no existing hash",
+			value:    "rick-and-morty",
+			existing: "",
+			matches:  false,
+		},
+		{
+			name:     "invalid existing hash",
+			value:    "rick-and-morty",
+			existing: "md5:abc:def",
+			matches:  false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		if actual := keyVaultSecretValueMatchesHash(v.value, v.existing); actual != v.matches {
+			t.Fatalf("expected %t but got %t", v.matches, actual)
+		}
+	}
+}
+
+func TestNewKeyVaultSecretValueHash(t *testing.T) {
+	existing := hashKeyVaultSecretValue("rick-and-morty", []byte("0123456789abcdef"))
+
+	updated, err := newKeyVaultSecretValueHash("mad-scientist", existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if updated == existing {
+		t.Fatalf("expected the hash to change when the value changes")
+	}
+	if strings.Split(updated, ":")[1] != strings.Split(existing, ":")[1] {
+		t.Fatalf("expected the existing salt to be reused but got %q", updated)
+	}
+
+	first, err := newKeyVaultSecretValueHash("rick-and-morty", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	second, err := newKeyVaultSecretValueHash("rick-and-morty", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if first == second {
+		t.Fatalf("expected a new salt to be generated for each hash")
+	}
+	if !keyVaultSecretValueMatchesHash("rick-and-morty", first) {
+		t.Fatalf("expected %q to match the value", first)
+	}
+}
@@ -90,7 +90,9 @@ func resourceMySQLServerKeyCreateUpdate(d *pluginsdk.ResourceData, meta interfac
 		return err
 	}
 
-	locks.ByName(serverID.ServerName, mySQLServerResourceName)
+	if err := locks.ByName(ctx, serverID.ServerName, mySQLServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(serverID.ServerName, mySQLServerResourceName)
 
 	if d.IsNewResource() {
@@ -186,7 +188,9 @@ func resourceMySQLServerKeyDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.ServerName, mySQLServerResourceName)
+	if err := locks.ByName(ctx, id.ServerName, mySQLServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ServerName, mySQLServerResourceName)
 
 	if err = client.DeleteThenPoll(ctx, *id); err != nil {
@@ -172,7 +172,9 @@ func resourceExpressRouteCircuitCreateUpdate(d *pluginsdk.ResourceData, meta int
 
 	id := parse.NewExpressRouteCircuitID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.Name, expressRouteCircuitResourceName)
+	if err := locks.ByName(ctx, id.Name, expressRouteCircuitResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, expressRouteCircuitResourceName)
 
 	if d.IsNewResource() {
@@ -354,7 +356,9 @@ func resourceExpressRouteCircuitDelete(d *pluginsdk.ResourceData, meta interface
 		return fmt.Errorf("parsing Azure Resource ID -: %+v", err)
 	}
 
-	locks.ByName(id.Name, expressRouteCircuitResourceName)
+	if err := locks.ByName(ctx, id.Name, expressRouteCircuitResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, expressRouteCircuitResourceName)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
@@ -237,7 +237,9 @@ func resourceArmExpressRoutePortCreateUpdate(d *pluginsdk.ResourceData, meta int
 	}
 
 	// a lock is needed here for subresource express_route_port_authorization needs a lock.
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	// The link properties can't be specified in first creation. It will result into either error (e.g. setting `adminState`) or being ignored (e.g. setting MACSec)
@@ -334,7 +336,9 @@ func resourceArmExpressRoutePortDelete(d *pluginsdk.ResourceData, meta interface
 	}
 
 	// a lock is needed here for subresource express_route_port_authorization needs a lock.
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
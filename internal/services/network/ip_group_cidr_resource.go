@@ -68,7 +68,9 @@ func resourceIpGroupCidrCreateUpdate(d *pluginsdk.ResourceData, meta interface{}
 	}
 	id := parse.NewIpGroupCidrID(subscriptionId, ipGroupId.ResourceGroup, ipGroupId.Name, cidrName)
 
-	locks.ByID(ipGroupId.ID())
+	if err := locks.ByID(ctx, ipGroupId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(ipGroupId.ID())
 
 	existing, err := client.Get(ctx, ipGroupId.ResourceGroup, ipGroupId.Name, "")
@@ -153,7 +155,9 @@ func resourceIpGroupCidrDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 		return err
 	}
 
-	locks.ByID(ipGroupId.ID())
+	if err := locks.ByID(ctx, ipGroupId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(ipGroupId.ID())
 
 	existing, err := client.Get(ctx, ipGroupId.ResourceGroup, ipGroupId.Name, "")
@@ -98,7 +98,9 @@ func resourceIpGroupCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall ID %q: %+v", fw, err)
 		}
-		locks.ByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
+		if err := locks.ByName(ctx, id.AzureFirewallName, firewall.AzureFirewallResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
 	}
 
@@ -107,13 +109,17 @@ func resourceIpGroupCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall Policy ID %q: %+v", fwpol, err)
 		}
-		locks.ByName(id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
+		if err := locks.ByName(ctx, id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
 	}
 
 	id := parse.NewIpGroupID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	if d.IsNewResource() {
@@ -224,7 +230,9 @@ func resourceIpGroupUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall ID %q: %+v", fw, err)
 		}
-		locks.ByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
+		if err := locks.ByName(ctx, id.AzureFirewallName, firewall.AzureFirewallResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
 	}
 
@@ -233,13 +241,17 @@ func resourceIpGroupUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall Policy ID %q: %+v", fwpol, err)
 		}
-		locks.ByName(id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
+		if err := locks.ByName(ctx, id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
 	}
 
 	id := parse.NewIpGroupID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	exisiting, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -302,7 +314,9 @@ func resourceIpGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByID(id.ID())
+	if err := locks.ByID(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	read, err := client.Get(ctx, id.ResourceGroup, id.Name, "")
@@ -321,7 +335,9 @@ func resourceIpGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall ID %q: %+v", pointer.From(fw.ID), err)
 		}
-		locks.ByName(fwID.AzureFirewallName, firewall.AzureFirewallResourceName)
+		if err := locks.ByName(ctx, fwID.AzureFirewallName, firewall.AzureFirewallResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(fwID.AzureFirewallName, firewall.AzureFirewallResourceName)
 	}
 
@@ -330,7 +346,9 @@ func resourceIpGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall Policy ID %q: %+v", *fwpol.ID, err)
 		}
-		locks.ByName(polID.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
+		if err := locks.ByName(ctx, polID.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(polID.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
 	}
 
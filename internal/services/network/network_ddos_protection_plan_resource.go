@@ -81,9 +81,13 @@ func resourceNetworkDDoSProtectionPlanCreateUpdate(d *pluginsdk.ResourceData, me
 
 	id := ddosprotectionplans.NewDdosProtectionPlanID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
+	if err := locks.ByName(ctx, id.DdosProtectionPlanName, ddosProtectionPlanResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, vnetsToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
 
 	if d.IsNewResource() {
@@ -182,10 +186,14 @@ func resourceNetworkDDoSProtectionPlanDelete(d *pluginsdk.ResourceData, meta int
 		return fmt.Errorf("extracting names of Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
+	if err := locks.ByName(ctx, id.DdosProtectionPlanName, ddosProtectionPlanResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
 
-	locks.MultipleByName(virtualNetworksNamesToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByName(ctx, virtualNetworksNamesToLock, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(virtualNetworksNamesToLock, VirtualNetworkResourceName)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
@@ -79,7 +79,9 @@ func resourceNetworkInterfaceSecurityGroupAssociationCreate(d *pluginsdk.Resourc
 		return err
 	}
 
-	locks.ByName(nicId.Name, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, nicId.Name, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(nicId.Name, networkInterfaceResourceName)
 
 	nsgId, err := parse.NetworkSecurityGroupID(networkSecurityGroupId)
@@ -87,7 +89,9 @@ func resourceNetworkInterfaceSecurityGroupAssociationCreate(d *pluginsdk.Resourc
 		return err
 	}
 
-	locks.ByName(nsgId.Name, networkSecurityGroupResourceName)
+	if err := locks.ByName(ctx, nsgId.Name, networkSecurityGroupResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(nsgId.Name, networkSecurityGroupResourceName)
 
 	read, err := client.Get(ctx, nicId.ResourceGroup, nicId.Name, "")
@@ -188,7 +192,9 @@ func resourceNetworkInterfaceSecurityGroupAssociationDelete(d *pluginsdk.Resourc
 		return err
 	}
 
-	locks.ByName(nicID.Name, networkInterfaceResourceName)
+	if err := locks.ByName(ctx, nicID.Name, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(nicID.Name, networkInterfaceResourceName)
 
 	read, err := client.Get(ctx, nicID.ResourceGroup, nicID.Name, "")
@@ -4,12 +4,14 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
@@ -28,10 +30,11 @@ var networkSecurityGroupResourceName = "azurerm_network_security_group"
 
 func resourceNetworkSecurityGroup() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
-		Create: resourceNetworkSecurityGroupCreateUpdate,
-		Read:   resourceNetworkSecurityGroupRead,
-		Update: resourceNetworkSecurityGroupCreateUpdate,
-		Delete: resourceNetworkSecurityGroupDelete,
+		// any conflicting security rules are returned as warnings once the Network Security Group has been read
+		CreateContext: networkSecurityGroupWithSecurityRuleFindings(resourceNetworkSecurityGroupCreateUpdate),
+		ReadContext:   resourceNetworkSecurityGroupReadWithSecurityRuleFindings,
+		UpdateContext: networkSecurityGroupWithSecurityRuleFindings(resourceNetworkSecurityGroupCreateUpdate),
+		Delete:        resourceNetworkSecurityGroupDelete,
 
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
 			_, err := parse.NetworkSecurityGroupID(id)
@@ -45,6 +48,8 @@ func resourceNetworkSecurityGroup() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(networkSecurityGroupCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:     pluginsdk.TypeString,
@@ -210,7 +215,9 @@ func resourceNetworkSecurityGroupCreateUpdate(d *pluginsdk.ResourceData, meta in
 		return fmt.Errorf("Building list of Network Security Group Rules: %+v", sgErr)
 	}
 
-	locks.ByName(id.Name, networkSecurityGroupResourceName)
+	if err := locks.ByName(ctx, id.Name, networkSecurityGroupResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.Name, networkSecurityGroupResourceName)
 
 	sg := network.SecurityGroup{
@@ -271,6 +278,10 @@ func resourceNetworkSecurityGroupRead(d *pluginsdk.ResourceData, meta interface{
 	return tags.FlattenAndSet(d, resp.Tags)
 }
 
+func resourceNetworkSecurityGroupReadWithSecurityRuleFindings(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+	return networkSecurityGroupWithSecurityRuleFindings(resourceNetworkSecurityGroupRead)(ctx, d, meta)
+}
+
 func resourceNetworkSecurityGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Network.SecurityGroupClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
@@ -0,0 +1,611 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-09-01/securityrules"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// unknownSecurityRuleValue is the placeholder used by the Plugin SDK for values within a Set which aren't known
+// until apply time - rules containing these can't be analysed.
+const unknownSecurityRuleValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+// securityRuleForAnalysis is the subset of a Network Security Rule required to determine whether it conflicts
+// with, or is shadowed by, another rule. Application Security Group IDs are included in the address lists.
+type securityRuleForAnalysis struct {
+	Name                  string
+	Priority              int
+	Direction             string
+	Access                string
+	Protocol              string
+	SourcePortRanges      []string
+	DestinationPortRanges []string
+	SourceAddresses       []string
+	DestinationAddresses  []string
+}
+
+type securityRuleFinding struct {
+	// Rules contains the names of the rules this finding relates to
+	Rules   []string
+	Message string
+}
+
+// analyseSecurityRules returns the findings for rules with duplicate priority/direction pairs, rules which are
+// unreachable since all the traffic they match is matched by a rule with a higher priority, and rules matching
+// overlapping traffic with conflicting access. Rules containing values which can't be analysed are skipped.
+func analyseSecurityRules(input []securityRuleForAnalysis) []securityRuleFinding {
+	rules := make([]parsedSecurityRule, 0)
+	for _, v := range input {
+		if rule, ok := parseSecurityRuleForAnalysis(v); ok {
+			rules = append(rules, *rule)
+		}
+	}
+
+	// Azure evaluates the rules in priority order (lowest first)
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority < rules[j].Priority
+		}
+		return rules[i].Name < rules[j].Name
+	})
+
+	findings := make([]securityRuleFinding, 0)
+	for j, lower := range rules {
+		for _, higher := range rules[:j] {
+			if !strings.EqualFold(higher.Direction, lower.Direction) {
+				continue
+			}
+
+			if higher.Priority == lower.Priority {
+				findings = append(findings, securityRuleFinding{
+					Rules:   []string{higher.Name, lower.Name},
+					Message: fmt.Sprintf("the security rules %q and %q both use the priority %d for %s traffic", higher.Name, lower.Name, lower.Priority, lower.Direction),
+				})
+				continue
+			}
+
+			if higher.covers(lower) {
+				findings = append(findings, securityRuleFinding{
+					Rules:   []string{higher.Name, lower.Name},
+					Message: fmt.Sprintf("the security rule %q (priority %d) is unreachable since all of the %s traffic it matches is matched by the security rule %q (priority %d)", lower.Name, lower.Priority, lower.Direction, higher.Name, higher.Priority),
+				})
+				break
+			}
+
+			// rules matching all traffic are commonly used as a default (e.g. "deny everything else") so are excluded
+			if !strings.EqualFold(higher.Access, lower.Access) && !lower.matchesAllTraffic() && higher.overlaps(lower) {
+				findings = append(findings, securityRuleFinding{
+					Rules:   []string{higher.Name, lower.Name},
+					Message: fmt.Sprintf("the security rules %q (priority %d, %s) and %q (priority %d, %s) match overlapping %s traffic with conflicting access, %q takes precedence for the overlapping traffic", higher.Name, higher.Priority, higher.Access, lower.Name, lower.Priority, lower.Access, lower.Direction, higher.Name),
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// errorOnConflictingSecurityRules returns whether the `error_on_conflicting_rules` feature is enabled, in which case
+// the findings fail the plan - otherwise these are returned as warnings once the resource has been read.
+func errorOnConflictingSecurityRules(meta interface{}) bool {
+	client, ok := meta.(*clients.Client)
+	return ok && client.Features.NetworkSecurityGroup.ErrorOnConflictingRules
+}
+
+// securityRuleFindingsError returns the findings as an error, used when the `error_on_conflicting_rules` feature is enabled.
+func securityRuleFindingsError(resourceName string, findings []securityRuleFinding) error {
+	if len(findings) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(findings))
+	for _, v := range findings {
+		messages = append(messages, fmt.Sprintf("* %s", v.Message))
+	}
+	return fmt.Errorf("conflicting security rules were found for %s:\n\n%s\n\nthis check can be changed to only output warnings by setting `error_on_conflicting_rules` to `false` within the `network_security_group` block of the `features` block", resourceName, strings.Join(messages, "\n"))
+}
+
+// securityRuleFindingsWarnings returns the findings as warnings, which are output by Terraform when planning and applying.
+func securityRuleFindingsWarnings(resourceName string, findings []securityRuleFinding) diag.Diagnostics {
+	diags := make(diag.Diagnostics, 0, len(findings))
+	for _, v := range findings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Conflicting security rules were found for %s", resourceName),
+			Detail:   fmt.Sprintf("%s.\n\nThis check can be changed to fail the plan by setting `error_on_conflicting_rules` to `true` within the `network_security_group` block of the `features` block.", v.Message),
+		})
+	}
+	return diags
+}
+
+func networkSecurityGroupCustomizeDiff(_ context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	// otherwise the findings are returned as warnings when the Network Security Group is read
+	if !errorOnConflictingSecurityRules(meta) || !d.NewValueKnown("security_rule") {
+		return nil
+	}
+
+	resourceName := fmt.Sprintf("Network Security Group %q", d.Get("name").(string))
+	return securityRuleFindingsError(resourceName, analyseSecurityRules(securityRulesForAnalysisFromSet(d.Get("security_rule").(*pluginsdk.Set))))
+}
+
+// networkSecurityGroupWithSecurityRuleFindings wraps a function which reads the Network Security Group, returning any
+// findings for the security rules as warnings - since the Network Security Group is read when refreshing these are
+// output during the plan, in addition to when it's created or updated.
+func networkSecurityGroupWithSecurityRuleFindings(f func(*pluginsdk.ResourceData, interface{}) error) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	return func(_ context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		if err := f(d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+		if d.Id() == "" || errorOnConflictingSecurityRules(meta) {
+			return nil
+		}
+
+		resourceName := fmt.Sprintf("Network Security Group %q", d.Get("name").(string))
+		return securityRuleFindingsWarnings(resourceName, analyseSecurityRules(securityRulesForAnalysisFromSet(d.Get("security_rule").(*pluginsdk.Set))))
+	}
+}
+
+// networkSecurityRuleAnalysedFields are the fields which determine whether a security rule conflicts with another
+var networkSecurityRuleAnalysedFields = []string{
+	"name",
+	"priority",
+	"direction",
+	"access",
+	"protocol",
+	"source_port_range",
+	"source_port_ranges",
+	"destination_port_range",
+	"destination_port_ranges",
+	"source_address_prefix",
+	"source_address_prefixes",
+	"source_application_security_group_ids",
+	"destination_address_prefix",
+	"destination_address_prefixes",
+	"destination_application_security_group_ids",
+}
+
+func networkSecurityRuleCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	// otherwise the findings are returned as warnings when the security rule is created or updated - and since the
+	// other rules within the Network Security Group are retrieved from Azure, this is only done when the rule changes
+	if !errorOnConflictingSecurityRules(meta) || (d.Id() != "" && !d.HasChanges(networkSecurityRuleAnalysedFields...)) {
+		return nil
+	}
+
+	resourceGroupName := d.Get("resource_group_name").(string)
+	networkSecurityGroupName := d.Get("network_security_group_name").(string)
+	if resourceGroupName == "" || networkSecurityGroupName == "" {
+		return nil
+	}
+
+	current := securityRuleForAnalysisFromMap(networkSecurityRuleValues(d.Get))
+	findings, err := networkSecurityRuleFindings(ctx, meta.(*clients.Client), resourceGroupName, networkSecurityGroupName, current)
+	if err != nil {
+		return err
+	}
+
+	resourceName := fmt.Sprintf("Security Rule %q (Network Security Group %q)", current.Name, networkSecurityGroupName)
+	return securityRuleFindingsError(resourceName, findings)
+}
+
+// networkSecurityRuleWithSecurityRuleFindings wraps the function which creates or updates the security rule, returning
+// any findings for the security rule as warnings.
+func networkSecurityRuleWithSecurityRuleFindings(f func(*pluginsdk.ResourceData, interface{}) error) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		if err := f(d, meta); err != nil {
+			return diag.FromErr(err)
+		}
+		if d.Id() == "" || errorOnConflictingSecurityRules(meta) {
+			return nil
+		}
+
+		networkSecurityGroupName := d.Get("network_security_group_name").(string)
+		current := securityRuleForAnalysisFromMap(networkSecurityRuleValues(d.Get))
+		findings, err := networkSecurityRuleFindings(ctx, meta.(*clients.Client), d.Get("resource_group_name").(string), networkSecurityGroupName, current)
+		if err != nil {
+			// the security rule has been created/updated successfully, so this shouldn't fail the apply
+			return diag.Diagnostics{
+				{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("Unable to analyse Security Rule %q for conflicting security rules", current.Name),
+					Detail:   err.Error(),
+				},
+			}
+		}
+
+		resourceName := fmt.Sprintf("Security Rule %q (Network Security Group %q)", current.Name, networkSecurityGroupName)
+		return securityRuleFindingsWarnings(resourceName, findings)
+	}
+}
+
+// networkSecurityRuleFindings returns the findings relating to the current security rule, when compared against the
+// other rules within the Network Security Group (which are retrieved from Azure) - when the Network Security Group
+// doesn't exist yet there's nothing to compare against.
+func networkSecurityRuleFindings(ctx context.Context, client *clients.Client, resourceGroupName, networkSecurityGroupName string, current securityRuleForAnalysis) ([]securityRuleFinding, error) {
+	id := securityrules.NewNetworkSecurityGroupID(client.Account.SubscriptionId, resourceGroupName, networkSecurityGroupName)
+	existing, err := client.Network.SecurityRules.ListComplete(ctx, id)
+	if err != nil {
+		if response.WasNotFound(existing.LatestHttpResponse) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing the Security Rules within %s: %+v", id, err)
+	}
+
+	rules := []securityRuleForAnalysis{current}
+	for _, item := range existing.Items {
+		if item.Name == nil || strings.EqualFold(*item.Name, current.Name) || item.Properties == nil {
+			continue
+		}
+		rules = append(rules, securityRuleForAnalysisFromModel(*item.Name, *item.Properties))
+	}
+
+	// only findings relating to this rule are reported, other rules are reported by their own resource
+	findings := make([]securityRuleFinding, 0)
+	for _, finding := range analyseSecurityRules(rules) {
+		for _, name := range finding.Rules {
+			if name == current.Name {
+				findings = append(findings, finding)
+				break
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// networkSecurityRuleValues returns the values of the analysed fields for the security rule, from either the
+// ResourceData or ResourceDiff
+func networkSecurityRuleValues(get func(string) interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	for _, field := range networkSecurityRuleAnalysedFields {
+		out[field] = get(field)
+	}
+	return out
+}
+
+func securityRulesForAnalysisFromSet(input *pluginsdk.Set) []securityRuleForAnalysis {
+	rules := make([]securityRuleForAnalysis, 0)
+	for _, raw := range input.List() {
+		v, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rules = append(rules, securityRuleForAnalysisFromMap(v))
+	}
+	return rules
+}
+
+func securityRuleForAnalysisFromMap(input map[string]interface{}) securityRuleForAnalysis {
+	stringValue := func(key string) string {
+		v, _ := input[key].(string)
+		return v
+	}
+	listValue := func(keys ...string) []string {
+		out := make([]string, 0)
+		for _, key := range keys {
+			switch v := input[key].(type) {
+			case string:
+				if v != "" {
+					out = append(out, v)
+				}
+			case *pluginsdk.Set:
+				for _, item := range v.List() {
+					if s, ok := item.(string); ok {
+						out = append(out, s)
+					}
+				}
+			}
+		}
+		return out
+	}
+
+	priority, _ := input["priority"].(int)
+	return securityRuleForAnalysis{
+		Name:                  stringValue("name"),
+		Priority:              priority,
+		Direction:             stringValue("direction"),
+		Access:                stringValue("access"),
+		Protocol:              stringValue("protocol"),
+		SourcePortRanges:      listValue("source_port_range", "source_port_ranges"),
+		DestinationPortRanges: listValue("destination_port_range", "destination_port_ranges"),
+		SourceAddresses:       listValue("source_address_prefix", "source_address_prefixes", "source_application_security_group_ids"),
+		DestinationAddresses:  listValue("destination_address_prefix", "destination_address_prefixes", "destination_application_security_group_ids"),
+	}
+}
+
+func securityRuleForAnalysisFromModel(name string, input securityrules.SecurityRulePropertiesFormat) securityRuleForAnalysis {
+	values := func(single *string, multiple *[]string, applicationSecurityGroups *[]securityrules.ApplicationSecurityGroup) []string {
+		out := make([]string, 0)
+		if v := pointer.From(single); v != "" {
+			out = append(out, v)
+		}
+		out = append(out, pointer.From(multiple)...)
+		for _, v := range pointer.From(applicationSecurityGroups) {
+			if v.Id != nil {
+				out = append(out, *v.Id)
+			}
+		}
+		return out
+	}
+
+	return securityRuleForAnalysis{
+		Name:                  name,
+		Priority:              int(input.Priority),
+		Direction:             string(input.Direction),
+		Access:                string(input.Access),
+		Protocol:              string(input.Protocol),
+		SourcePortRanges:      values(input.SourcePortRange, input.SourcePortRanges, nil),
+		DestinationPortRanges: values(input.DestinationPortRange, input.DestinationPortRanges, nil),
+		SourceAddresses:       values(input.SourceAddressPrefix, input.SourceAddressPrefixes, input.SourceApplicationSecurityGroups),
+		DestinationAddresses:  values(input.DestinationAddressPrefix, input.DestinationAddressPrefixes, input.DestinationApplicationSecurityGroups),
+	}
+}
+
+type portRange struct {
+	start int
+	end   int
+}
+
+// securityRuleAddress is either an IP Prefix, or an opaque value (a Service Tag or Application Security Group ID)
+// which can only be compared for equality
+type securityRuleAddress struct {
+	any    bool
+	prefix *netip.Prefix
+	opaque string
+}
+
+type parsedSecurityRule struct {
+	securityRuleForAnalysis
+
+	sourcePorts          []portRange
+	destinationPorts     []portRange
+	sourceAddresses      []securityRuleAddress
+	destinationAddresses []securityRuleAddress
+}
+
+func parseSecurityRuleForAnalysis(input securityRuleForAnalysis) (*parsedSecurityRule, bool) {
+	for _, v := range []string{input.Name, input.Direction, input.Access, input.Protocol} {
+		if v == "" || v == unknownSecurityRuleValue {
+			return nil, false
+		}
+	}
+	if input.Priority == 0 {
+		return nil, false
+	}
+
+	out := parsedSecurityRule{
+		securityRuleForAnalysis: input,
+	}
+
+	var ok bool
+	if out.sourcePorts, ok = parsePortRanges(input.SourcePortRanges); !ok {
+		return nil, false
+	}
+	if out.destinationPorts, ok = parsePortRanges(input.DestinationPortRanges); !ok {
+		return nil, false
+	}
+	if out.sourceAddresses, ok = parseSecurityRuleAddresses(input.SourceAddresses); !ok {
+		return nil, false
+	}
+	if out.destinationAddresses, ok = parseSecurityRuleAddresses(input.DestinationAddresses); !ok {
+		return nil, false
+	}
+
+	return &out, true
+}
+
+func parsePortRanges(input []string) ([]portRange, bool) {
+	if len(input) == 0 {
+		return nil, false
+	}
+
+	out := make([]portRange, 0, len(input))
+	for _, v := range input {
+		v = strings.TrimSpace(v)
+		if v == "*" {
+			out = append(out, portRange{start: 0, end: 65535})
+			continue
+		}
+
+		startRaw, endRaw, isRange := strings.Cut(v, "-")
+		if !isRange {
+			endRaw = startRaw
+		}
+		start, err := strconv.Atoi(strings.TrimSpace(startRaw))
+		if err != nil {
+			return nil, false
+		}
+		end, err := strconv.Atoi(strings.TrimSpace(endRaw))
+		if err != nil || end < start {
+			return nil, false
+		}
+		out = append(out, portRange{start: start, end: end})
+	}
+
+	// merge the ranges, so that adjacent ranges can cover a single larger range
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].start < out[j].start
+	})
+	merged := []portRange{out[0]}
+	for _, v := range out[1:] {
+		last := &merged[len(merged)-1]
+		if v.start <= last.end+1 {
+			if v.end > last.end {
+				last.end = v.end
+			}
+			continue
+		}
+		merged = append(merged, v)
+	}
+
+	return merged, true
+}
+
+func parseSecurityRuleAddresses(input []string) ([]securityRuleAddress, bool) {
+	if len(input) == 0 {
+		return nil, false
+	}
+
+	out := make([]securityRuleAddress, 0, len(input))
+	for _, v := range input {
+		v = strings.TrimSpace(v)
+		if v == "" || v == unknownSecurityRuleValue {
+			return nil, false
+		}
+
+		if v == "*" || strings.EqualFold(v, "Any") {
+			out = append(out, securityRuleAddress{any: true})
+			continue
+		}
+
+		if prefix, err := netip.ParsePrefix(v); err == nil {
+			if prefix.Bits() == 0 {
+				out = append(out, securityRuleAddress{any: true})
+				continue
+			}
+			prefix = prefix.Masked()
+			out = append(out, securityRuleAddress{prefix: &prefix})
+			continue
+		}
+
+		if addr, err := netip.ParseAddr(v); err == nil {
+			prefix := netip.PrefixFrom(addr, addr.BitLen())
+			out = append(out, securityRuleAddress{prefix: &prefix})
+			continue
+		}
+
+		out = append(out, securityRuleAddress{opaque: strings.ToLower(v)})
+	}
+
+	return out, true
+}
+
+func (r parsedSecurityRule) matchesAllTraffic() bool {
+	return r.Protocol == "*" &&
+		portRangesCover(r.sourcePorts, []portRange{{start: 0, end: 65535}}) &&
+		portRangesCover(r.destinationPorts, []portRange{{start: 0, end: 65535}}) &&
+		addressesMatchAny(r.sourceAddresses) &&
+		addressesMatchAny(r.destinationAddresses)
+}
+
+// covers returns whether all the traffic matched by other is also matched by this rule
+func (r parsedSecurityRule) covers(other parsedSecurityRule) bool {
+	return (r.Protocol == "*" || strings.EqualFold(r.Protocol, other.Protocol)) &&
+		portRangesCover(r.sourcePorts, other.sourcePorts) &&
+		portRangesCover(r.destinationPorts, other.destinationPorts) &&
+		addressesCover(r.sourceAddresses, other.sourceAddresses) &&
+		addressesCover(r.destinationAddresses, other.destinationAddresses)
+}
+
+// overlaps returns whether some of the traffic matched by other is also matched by this rule
+func (r parsedSecurityRule) overlaps(other parsedSecurityRule) bool {
+	return (r.Protocol == "*" || other.Protocol == "*" || strings.EqualFold(r.Protocol, other.Protocol)) &&
+		portRangesOverlap(r.sourcePorts, other.sourcePorts) &&
+		portRangesOverlap(r.destinationPorts, other.destinationPorts) &&
+		addressesOverlap(r.sourceAddresses, other.sourceAddresses) &&
+		addressesOverlap(r.destinationAddresses, other.destinationAddresses)
+}
+
+func portRangesCover(outer []portRange, inner []portRange) bool {
+	for _, i := range inner {
+		covered := false
+		for _, o := range outer {
+			if o.start <= i.start && i.end <= o.end {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func portRangesOverlap(first []portRange, second []portRange) bool {
+	for _, f := range first {
+		for _, s := range second {
+			if f.start <= s.end && s.start <= f.end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func addressesMatchAny(input []securityRuleAddress) bool {
+	for _, v := range input {
+		if v.any {
+			return true
+		}
+	}
+	return false
+}
+
+func addressesCover(outer []securityRuleAddress, inner []securityRuleAddress) bool {
+	if addressesMatchAny(outer) {
+		return true
+	}
+
+	for _, i := range inner {
+		covered := false
+		for _, o := range outer {
+			if addressCovers(o, i) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+func addressCovers(outer securityRuleAddress, inner securityRuleAddress) bool {
+	switch {
+	case outer.any:
+		return true
+	case inner.any:
+		return false
+	case outer.prefix != nil && inner.prefix != nil:
+		return outer.prefix.Bits() <= inner.prefix.Bits() && outer.prefix.Contains(inner.prefix.Addr())
+	case outer.prefix == nil && inner.prefix == nil:
+		return outer.opaque == inner.opaque
+	}
+
+	// the address ranges of Service Tags and Application Security Groups aren't known, so can't be compared to IP Prefixes
+	return false
+}
+
+func addressesOverlap(first []securityRuleAddress, second []securityRuleAddress) bool {
+	for _, f := range first {
+		for _, s := range second {
+			switch {
+			case f.any || s.any:
+				return true
+			case f.prefix != nil && s.prefix != nil:
+				if f.prefix.Overlaps(*s.prefix) {
+					return true
+				}
+			case f.prefix == nil && s.prefix == nil:
+				if f.opaque == s.opaque {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
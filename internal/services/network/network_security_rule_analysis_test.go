@@ -0,0 +1,158 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestAnalyseSecurityRules(t *testing.T) {
+	rule := func(name string, priority int, access string, protocol string, sourceAddresses []string, destinationPorts []string) securityRuleForAnalysis {
+		return securityRuleForAnalysis{
+			Name:                  name,
+			Priority:              priority,
+			Direction:             "Inbound",
+			Access:                access,
+			Protocol:              protocol,
+			SourcePortRanges:      []string{"*"},
+			DestinationPortRanges: destinationPorts,
+			SourceAddresses:       sourceAddresses,
+			DestinationAddresses:  []string{"*"},
+		}
+	}
+
+	testData := []struct {
+		name     string
+		input    []securityRuleForAnalysis
+		expected [][]string
+	}{
+		{
+			name: "no conflicts",
+			input: []securityRuleForAnalysis{
+				rule("https", 100, "Allow", "Tcp", []string{"10.0.0.0/24"}, []string{"443"}),
+				rule("ssh", 110, "Allow", "Tcp", []string{"10.0.1.0/24"}, []string{"22"}),
+				rule("deny-all", 4096, "Deny", "*", []string{"*"}, []string{"*"}),
+			},
+			expected: [][]string{},
+		},
+		{
+			name: "duplicate priority",
+			input: []securityRuleForAnalysis{
+				rule("https", 100, "Allow", "Tcp", []string{"10.0.0.0/24"}, []string{"443"}),
+				rule("ssh", 100, "Allow", "Tcp", []string{"10.0.1.0/24"}, []string{"22"}),
+			},
+			expected: [][]string{{"https", "ssh"}},
+		},
+		{
+			name: "duplicate priority in different directions",
+			input: []securityRuleForAnalysis{
+				rule("https", 100, "Allow", "Tcp", []string{"10.0.0.0/24"}, []string{"443"}),
+				func() securityRuleForAnalysis {
+					r := rule("ssh", 100, "Allow", "Tcp", []string{"10.0.1.0/24"}, []string{"22"})
+					r.Direction = "Outbound"
+					return r
+				}(),
+			},
+			expected: [][]string{},
+		},
+		{
+			name: "shadowed by a wider address range",
+			input: []securityRuleForAnalysis{
+				rule("wide", 100, "Deny", "Tcp", []string{"10.0.0.0/16"}, []string{"1000-2000"}),
+				rule("narrow", 200, "Allow", "Tcp", []string{"10.0.1.0/24", "10.0.2.4"}, []string{"1500", "1600-1700"}),
+			},
+			expected: [][]string{{"wide", "narrow"}},
+		},
+		{
+			name: "shadowed by adjacent port ranges",
+			input: []securityRuleForAnalysis{
+				rule("low", 100, "Allow", "*", []string{"*"}, []string{"1000-1499", "1500-2000"}),
+				rule("range", 200, "Allow", "Udp", []string{"10.0.1.0/24"}, []string{"1200-1800"}),
+			},
+			expected: [][]string{{"low", "range"}},
+		},
+		{
+			name: "not shadowed by a narrower protocol",
+			input: []securityRuleForAnalysis{
+				rule("tcp", 100, "Allow", "Tcp", []string{"*"}, []string{"*"}),
+				rule("any", 200, "Allow", "*", []string{"10.0.1.0/24"}, []string{"80"}),
+			},
+			expected: [][]string{},
+		},
+		{
+			name: "overlapping with conflicting access",
+			input: []securityRuleForAnalysis{
+				rule("allow", 100, "Allow", "Tcp", []string{"10.0.0.0/24"}, []string{"80-90"}),
+				rule("deny", 200, "Deny", "Tcp", []string{"10.0.0.128/25", "10.1.0.0/16"}, []string{"85-100"}),
+			},
+			expected: [][]string{{"allow", "deny"}},
+		},
+		{
+			name: "overlapping with the same access",
+			input: []securityRuleForAnalysis{
+				rule("first", 100, "Allow", "Tcp", []string{"10.0.0.0/24"}, []string{"80-90"}),
+				rule("second", 200, "Allow", "Tcp", []string{"10.0.0.128/25", "10.1.0.0/16"}, []string{"85-100"}),
+			},
+			expected: [][]string{},
+		},
+		{
+			name: "service tags are only compared by name",
+			input: []securityRuleForAnalysis{
+				rule("vnet", 100, "Allow", "Tcp", []string{"VirtualNetwork"}, []string{"443"}),
+				rule("cidr", 200, "Deny", "Tcp", []string{"10.0.0.0/24"}, []string{"443"}),
+				rule("vnet-again", 300, "Deny", "Tcp", []string{"virtualnetwork"}, []string{"443"}),
+			},
+			expected: [][]string{{"vnet", "vnet-again"}},
+		},
+		{
+			name: "unknown values are skipped",
+			input: []securityRuleForAnalysis{
+				rule("wide", 100, "Deny", "*", []string{"*"}, []string{"*"}),
+				rule("unknown", 200, "Allow", "Tcp", []string{unknownSecurityRuleValue}, []string{"443"}),
+				rule("missing", 300, "Allow", "Tcp", []string{}, []string{"443"}),
+			},
+			expected: [][]string{},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.name)
+
+		actual := make([][]string, 0)
+		for _, finding := range analyseSecurityRules(v.input) {
+			actual = append(actual, finding.Rules)
+		}
+
+		if !reflect.DeepEqual(actual, v.expected) {
+			t.Fatalf("expected findings for %+v but got %+v", v.expected, actual)
+		}
+	}
+}
+
+func TestSecurityRuleFindingsWarnings(t *testing.T) {
+	findings := []securityRuleFinding{
+		{
+			Rules:   []string{"first", "second"},
+			Message: `the security rules "first" and "second" both use the priority 100 for Inbound traffic`,
+		},
+	}
+
+	diags := securityRuleFindingsWarnings(`Network Security Group "example"`, findings)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning but got %+v", diags)
+	}
+	if diags.HasError() {
+		t.Fatalf("expected no errors but got %+v", diags)
+	}
+
+	if err := securityRuleFindingsError(`Network Security Group "example"`, nil); err != nil {
+		t.Fatalf("expected no error when there are no findings but got: %+v", err)
+	}
+	if err := securityRuleFindingsError(`Network Security Group "example"`, findings); err == nil {
+		t.Fatalf("expected an error when there are findings")
+	}
+}
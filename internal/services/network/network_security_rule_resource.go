@@ -22,10 +22,11 @@ import (
 
 func resourceNetworkSecurityRule() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
-		Create: resourceNetworkSecurityRuleCreateUpdate,
-		Read:   resourceNetworkSecurityRuleRead,
-		Update: resourceNetworkSecurityRuleCreateUpdate,
-		Delete: resourceNetworkSecurityRuleDelete,
+		// any conflicting security rules are returned as warnings once the security rule has been created or updated
+		CreateContext: networkSecurityRuleWithSecurityRuleFindings(resourceNetworkSecurityRuleCreateUpdate),
+		Read:          resourceNetworkSecurityRuleRead,
+		UpdateContext: networkSecurityRuleWithSecurityRuleFindings(resourceNetworkSecurityRuleCreateUpdate),
+		Delete:        resourceNetworkSecurityRuleDelete,
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
 			_, err := securityrules.ParseSecurityRuleID(id)
 			return err
@@ -38,6 +39,8 @@ func resourceNetworkSecurityRule() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(networkSecurityRuleCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -213,7 +213,9 @@ func resourceNetworkWatcherFlowLogCreateUpdate(d *pluginsdk.ResourceData, meta i
 		}
 	}
 
-	locks.ByID(nsgId.ID())
+	if err := locks.ByID(ctx, nsgId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(nsgId.ID())
 
 	loc := d.Get("location").(string)
@@ -351,7 +353,9 @@ func resourceNetworkWatcherFlowLogDelete(d *pluginsdk.ResourceData, meta interfa
 		return fmt.Errorf("parsing %q as a Network Security Group ID: %+v", resp.Model.Properties.TargetResourceId, err)
 	}
 
-	locks.ByID(networkSecurityGroupId.ID())
+	if err := locks.ByID(ctx, networkSecurityGroupId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(networkSecurityGroupId.ID())
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
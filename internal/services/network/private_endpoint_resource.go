@@ -348,11 +348,15 @@ func resourcePrivateEndpointCreate(d *pluginsdk.ResourceData, meta interface{})
 	cosmosDbResIds := getCosmosDbResIdInPrivateServiceConnections(parameters.Properties)
 	for _, cosmosDbResId := range cosmosDbResIds {
 		log.Printf("[DEBUG] Add Lock For Private Endpoint %q, lock name: %q", id.PrivateEndpointName, cosmosDbResId)
-		locks.ByName(cosmosDbResId, "azurerm_private_endpoint")
+		if err := locks.ByName(ctx, cosmosDbResId, "azurerm_private_endpoint"); err != nil {
+			return err
+		}
 		//goland:noinspection GoDeferInLoop
 		defer locks.UnlockByName(cosmosDbResId, "azurerm_private_endpoint")
 	}
-	locks.ByName(subnetId, "azurerm_private_endpoint")
+	if err := locks.ByName(ctx, subnetId, "azurerm_private_endpoint"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(subnetId, "azurerm_private_endpoint")
 
 	err = pluginsdk.Retry(d.Timeout(pluginsdk.TimeoutCreate), func() *pluginsdk.RetryError {
@@ -513,7 +517,9 @@ func resourcePrivateEndpointUpdate(d *pluginsdk.ResourceData, meta interface{})
 		return err
 	}
 
-	locks.ByName(subnetId, "azurerm_private_endpoint")
+	if err := locks.ByName(ctx, subnetId, "azurerm_private_endpoint"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(subnetId, "azurerm_private_endpoint")
 
 	err = pluginsdk.Retry(d.Timeout(pluginsdk.TimeoutCreate), func() *pluginsdk.RetryError {
@@ -739,11 +745,15 @@ func resourcePrivateEndpointDelete(d *pluginsdk.ResourceData, meta interface{})
 
 	cosmosDbResIds := getCosmosDbResIdInPrivateServiceConnections(existing.Model.Properties)
 	for _, cosmosDbResId := range cosmosDbResIds {
-		locks.ByName(cosmosDbResId, "azurerm_private_endpoint")
+		if err := locks.ByName(ctx, cosmosDbResId, "azurerm_private_endpoint"); err != nil {
+			return err
+		}
 		//goland:noinspection GoDeferInLoop
 		defer locks.UnlockByName(cosmosDbResId, "azurerm_private_endpoint")
 	}
-	locks.ByName(subnetId, "azurerm_private_endpoint")
+	if err := locks.ByName(ctx, subnetId, "azurerm_private_endpoint"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(subnetId, "azurerm_private_endpoint")
 
 	log.Printf("[DEBUG] Deleting %s", id)
@@ -106,7 +106,9 @@ func resourceRouteCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		}
 	}
 
-	locks.ByName(id.RouteTableName, routeTableResourceName)
+	if err := locks.ByName(ctx, id.RouteTableName, routeTableResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.RouteTableName, routeTableResourceName)
 
 	route := routes.Route{
@@ -173,7 +175,9 @@ func resourceRouteDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.RouteTableName, routeTableResourceName)
+	if err := locks.ByName(ctx, id.RouteTableName, routeTableResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.RouteTableName, routeTableResourceName)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
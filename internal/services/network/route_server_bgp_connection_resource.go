@@ -79,7 +79,9 @@ func resourceRouteServerBgpConnectionCreate(d *pluginsdk.ResourceData, meta inte
 		return err
 	}
 
-	locks.ByName(routerServerId.Name, "azurerm_route_server")
+	if err := locks.ByName(ctx, routerServerId.Name, "azurerm_route_server"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(routerServerId.Name, "azurerm_route_server")
 
 	id := parse.NewBgpConnectionID(routerServerId.SubscriptionId, routerServerId.ResourceGroup, routerServerId.Name, d.Get("name").(string))
@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"strconv"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/tombuildsstuff/kermit/sdk/network/2022-07-01/network"
+)
+
+// allocateSubnetAddressPrefix returns the next free address prefix with the specified prefix length within the
+// address space of the Virtual Network, taking the address prefixes of the existing Subnets into account.
+//
+// NOTE: the caller is expected to hold the lock on the Virtual Network name, so that Subnets being created
+// in parallel are allocated different address prefixes.
+func allocateSubnetAddressPrefix(ctx context.Context, client *network.VirtualNetworksClient, id commonids.VirtualNetworkId, prefixLength int) (*string, error) {
+	vnet, err := client.Get(ctx, id.ResourceGroupName, id.VirtualNetworkName, "")
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	addressSpaces := make([]string, 0)
+	existing := make([]string, 0)
+	if props := vnet.VirtualNetworkPropertiesFormat; props != nil {
+		if props.AddressSpace != nil && props.AddressSpace.AddressPrefixes != nil {
+			addressSpaces = *props.AddressSpace.AddressPrefixes
+		}
+
+		if props.Subnets != nil {
+			for _, subnet := range *props.Subnets {
+				if subnet.SubnetPropertiesFormat == nil {
+					continue
+				}
+				if v := subnet.SubnetPropertiesFormat.AddressPrefix; v != nil && *v != "" {
+					existing = append(existing, *v)
+				}
+				if v := subnet.SubnetPropertiesFormat.AddressPrefixes; v != nil {
+					existing = append(existing, *v...)
+				}
+			}
+		}
+	}
+
+	prefix, err := nextFreeAddressPrefix(addressSpaces, existing, prefixLength)
+	if err != nil {
+		return nil, fmt.Errorf("allocating an address prefix with a prefix length of %d within %s: %+v", prefixLength, id, err)
+	}
+
+	return &prefix, nil
+}
+
+// nextFreeAddressPrefix returns the lowest block with the specified prefix length which doesn't overlap any of the
+// existing address prefixes, searching each of the address spaces in order.
+func nextFreeAddressPrefix(addressSpaces []string, existing []string, prefixLength int) (string, error) {
+	existingPrefixes := make([]netip.Prefix, 0, len(existing))
+	for _, v := range existing {
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			return "", fmt.Errorf("parsing the existing address prefix %q: %+v", v, err)
+		}
+		existingPrefixes = append(existingPrefixes, prefix.Masked())
+	}
+
+	parsedAddressSpaces := make([]netip.Prefix, 0, len(addressSpaces))
+	supportsPrefixLength := false
+	for _, v := range addressSpaces {
+		addressSpace, err := netip.ParsePrefix(v)
+		if err != nil {
+			return "", fmt.Errorf("parsing the address space %q: %+v", v, err)
+		}
+		parsedAddressSpaces = append(parsedAddressSpaces, addressSpace.Masked())
+
+		if prefixLength <= addressSpace.Addr().BitLen() {
+			supportsPrefixLength = true
+		}
+	}
+
+	// a prefix length which is only valid for IPv6 (e.g. /64) can't be allocated within an IPv4 address space
+	if !supportsPrefixLength {
+		return "", fmt.Errorf("a prefix length of %d is only valid for an IPv6 address space, but the address space %q contains no IPv6 address prefixes - IPv4 address prefixes support a prefix length of at most 32", prefixLength, addressSpaces)
+	}
+
+	for _, addressSpace := range parsedAddressSpaces {
+		bitLength := addressSpace.Addr().BitLen()
+		if prefixLength < addressSpace.Bits() || prefixLength > bitLength {
+			continue
+		}
+
+		blockSize := new(big.Int).Lsh(big.NewInt(1), uint(bitLength-prefixLength))
+		start := addressToInt(addressSpace.Addr())
+		end := new(big.Int).Add(start, new(big.Int).Lsh(big.NewInt(1), uint(bitLength-addressSpace.Bits())))
+
+		candidateStart := start
+		for new(big.Int).Add(candidateStart, blockSize).Cmp(end) <= 0 {
+			candidate := netip.PrefixFrom(intToAddress(candidateStart, addressSpace.Addr().Is4()), prefixLength)
+
+			// find the end of the furthest overlapping prefix, so that we can skip past it
+			var overlapEnd *big.Int
+			for _, existingPrefix := range existingPrefixes {
+				if !existingPrefix.Overlaps(candidate) {
+					continue
+				}
+				existingEnd := new(big.Int).Add(addressToInt(existingPrefix.Addr()), new(big.Int).Lsh(big.NewInt(1), uint(existingPrefix.Addr().BitLen()-existingPrefix.Bits())))
+				if overlapEnd == nil || existingEnd.Cmp(overlapEnd) > 0 {
+					overlapEnd = existingEnd
+				}
+			}
+
+			if overlapEnd == nil {
+				return candidate.String(), nil
+			}
+
+			// move to the first block after both the candidate and the overlapping prefix, aligned to the block size
+			next := new(big.Int).Add(candidateStart, blockSize)
+			if overlapEnd.Cmp(next) > 0 {
+				next = overlapEnd
+			}
+			remainder := new(big.Int).Mod(next, blockSize)
+			if remainder.Sign() != 0 {
+				next.Add(next, new(big.Int).Sub(blockSize, remainder))
+			}
+			candidateStart = next
+		}
+	}
+
+	return "", fmt.Errorf("no free address prefix with a prefix length of %d was found within the address space %q", prefixLength, addressSpaces)
+}
+
+func addressToInt(input netip.Addr) *big.Int {
+	return new(big.Int).SetBytes(input.AsSlice())
+}
+
+func intToAddress(input *big.Int, is4 bool) netip.Addr {
+	if is4 {
+		var out [4]byte
+		input.FillBytes(out[:])
+		return netip.AddrFrom4(out)
+	}
+
+	var out [16]byte
+	input.FillBytes(out[:])
+	return netip.AddrFrom16(out)
+}
+
+// suppressSubnetAddressPrefixLengthDiff suppresses the diff when `address_prefix_length` is specified for an
+// existing Subnet (for example after an import) whose address prefix already has the specified prefix length,
+// and when `address_prefix_length` is replaced by `address_prefixes` - which can be updated in-place.
+func suppressSubnetAddressPrefixLengthDiff(_, old, new string, d *pluginsdk.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+
+	if new == "" || new == "0" {
+		return true
+	}
+
+	if old != "" && old != "0" {
+		return false
+	}
+
+	prefixLength, err := strconv.Atoi(new)
+	if err != nil {
+		return false
+	}
+
+	addressPrefixes := d.Get("address_prefixes").([]interface{})
+	if len(addressPrefixes) != 1 {
+		return false
+	}
+
+	prefix, err := netip.ParsePrefix(addressPrefixes[0].(string))
+	if err != nil {
+		return false
+	}
+
+	return prefix.Bits() == prefixLength
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import "testing"
+
+func TestNextFreeAddressPrefix(t *testing.T) {
+	testData := []struct {
+		addressSpaces []string
+		existing      []string
+		prefixLength  int
+		expected      string
+		error         bool
+	}{
+		{
+			// empty virtual network
+			addressSpaces: []string{"10.0.0.0/16"},
+			prefixLength:  24,
+			expected:      "10.0.0.0/24",
+		},
+		{
+			addressSpaces: []string{"10.0.0.0/16"},
+			existing:      []string{"10.0.0.0/24", "10.0.1.0/24"},
+			prefixLength:  24,
+			expected:      "10.0.2.0/24",
+		},
+		{
+			// gaps between existing subnets are used
+			addressSpaces: []string{"10.0.0.0/16"},
+			existing:      []string{"10.0.0.0/24", "10.0.2.0/24"},
+			prefixLength:  24,
+			expected:      "10.0.1.0/24",
+		},
+		{
+			// smaller existing subnets occupy the whole block
+			addressSpaces: []string{"10.0.0.0/16"},
+			existing:      []string{"10.0.0.64/26"},
+			prefixLength:  24,
+			expected:      "10.0.1.0/24",
+		},
+		{
+			// larger existing subnets are skipped entirely
+			addressSpaces: []string{"10.0.0.0/16"},
+			existing:      []string{"10.0.0.0/20"},
+			prefixLength:  26,
+			expected:      "10.0.16.0/26",
+		},
+		{
+			addressSpaces: []string{"10.0.0.0/16"},
+			existing:      []string{"10.0.0.0/26", "10.0.0.128/25"},
+			prefixLength:  26,
+			expected:      "10.0.0.64/26",
+		},
+		{
+			// the address space is full
+			addressSpaces: []string{"10.0.0.0/24"},
+			existing:      []string{"10.0.0.0/25", "10.0.0.128/25"},
+			prefixLength:  28,
+			error:         true,
+		},
+		{
+			// the prefix length is larger than the address space
+			addressSpaces: []string{"10.0.0.0/24"},
+			prefixLength:  16,
+			error:         true,
+		},
+		{
+			// the next address space is used when the first is full
+			addressSpaces: []string{"10.0.0.0/24", "10.1.0.0/16"},
+			existing:      []string{"10.0.0.0/24"},
+			prefixLength:  24,
+			expected:      "10.1.0.0/24",
+		},
+		{
+			// IPv6 address spaces are only used for IPv6 prefix lengths
+			addressSpaces: []string{"10.0.0.0/16", "ace:cab:deca::/48"},
+			existing:      []string{"10.0.0.0/24", "ace:cab:deca::/64"},
+			prefixLength:  64,
+			expected:      "ace:cab:deca:1::/64",
+		},
+		{
+			// an IPv6 prefix length within an IPv4 address space
+			addressSpaces: []string{"10.0.0.0/16"},
+			prefixLength:  64,
+			error:         true,
+		},
+		{
+			addressSpaces: []string{"10.0.0.0/16"},
+			existing:      []string{"not-a-prefix"},
+			prefixLength:  24,
+			error:         true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing /%d within %q (existing %q)", v.prefixLength, v.addressSpaces, v.existing)
+
+		actual, err := nextFreeAddressPrefix(v.addressSpaces, v.existing, v.prefixLength)
+		if err != nil {
+			if v.error {
+				continue
+			}
+
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+		if v.error {
+			t.Fatalf("expected an error but got %q", actual)
+		}
+
+		if actual != v.expected {
+			t.Fatalf("expected %q but got %q", v.expected, actual)
+		}
+	}
+}
@@ -130,12 +130,23 @@ func resourceSubnet() *pluginsdk.Resource {
 
 			"address_prefixes": {
 				Type:     pluginsdk.TypeList,
-				Required: true,
+				Optional: true,
+				Computed: true,
 				MinItems: 1,
 				Elem: &pluginsdk.Schema{
 					Type:         pluginsdk.TypeString,
 					ValidateFunc: validation.StringIsNotEmpty,
 				},
+				ExactlyOneOf: []string{"address_prefixes", "address_prefix_length"},
+			},
+
+			"address_prefix_length": {
+				Type:             pluginsdk.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.IntBetween(1, 128),
+				DiffSuppressFunc: suppressSubnetAddressPrefixLengthDiff,
+				ExactlyOneOf:     []string{"address_prefixes", "address_prefix_length"},
 			},
 
 			"service_endpoints": {
@@ -286,11 +297,21 @@ func resourceSubnetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return tf.ImportAsExistsError("azurerm_subnet", id.ID())
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, id.VirtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
 
 	properties := subnets.SubnetPropertiesFormat{}
-	if value, ok := d.GetOk("address_prefixes"); ok {
+	if value, ok := d.GetOk("address_prefix_length"); ok {
+		vnetId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName)
+		addressPrefix, err := allocateSubnetAddressPrefix(ctx, vnetClient, vnetId, value.(int))
+		if err != nil {
+			return fmt.Errorf("creating %s: %+v", id, err)
+		}
+		log.Printf("[DEBUG] Allocated the address prefix %q for %s", *addressPrefix, id)
+		properties.AddressPrefixes = &[]string{*addressPrefix}
+	} else if value, ok := d.GetOk("address_prefixes"); ok {
 		var addressPrefixes []string
 		for _, item := range value.([]interface{}) {
 			addressPrefixes = append(addressPrefixes, item.(string))
@@ -429,10 +450,14 @@ func resourceSubnetUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, id.VirtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
 
-	locks.ByName(id.SubnetName, SubnetResourceName)
+	if err := locks.ByName(ctx, id.SubnetName, SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
 
 	existing, err := client.Get(ctx, *id, subnets.DefaultGetOperationOptions())
@@ -641,10 +666,14 @@ func resourceSubnetDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByName(ctx, id.VirtualNetworkName, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
 
-	locks.ByName(id.SubnetName, SubnetResourceName)
+	if err := locks.ByName(ctx, id.SubnetName, SubnetResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
@@ -96,6 +96,26 @@ func TestAccSubnet_update_addressPrefixes(t *testing.T) {
 	})
 }
 
+func TestAccSubnet_addressPrefixLength(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
+	r := SubnetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.addressPrefixLength(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("address_prefixes.#").HasValue("1"),
+				check.That("azurerm_subnet.second").ExistsInAzure(r),
+				check.That("azurerm_subnet.second").Key("address_prefixes.#").HasValue("1"),
+				check.That("azurerm_subnet.third").ExistsInAzure(r),
+				check.That("azurerm_subnet.third").Key("address_prefixes.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("address_prefix_length"),
+	})
+}
+
 func TestAccSubnet_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_subnet", "test")
 	r := SubnetResource{}
@@ -878,6 +898,46 @@ resource "azurerm_subnet" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger, data.RandomInteger)
 }
 
+func (r SubnetResource) addressPrefixLength(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_subnet" "existing" {
+  name                 = "existing"
+  resource_group_name  = azurerm_resource_group.test.name
+  virtual_network_name = azurerm_virtual_network.test.name
+  address_prefixes     = ["10.0.0.0/24"]
+}
+
+resource "azurerm_subnet" "test" {
+  name                  = "internal"
+  resource_group_name   = azurerm_resource_group.test.name
+  virtual_network_name  = azurerm_virtual_network.test.name
+  address_prefix_length = 24
+
+  depends_on = [azurerm_subnet.existing]
+}
+
+resource "azurerm_subnet" "second" {
+  name                  = "second"
+  resource_group_name   = azurerm_resource_group.test.name
+  virtual_network_name  = azurerm_virtual_network.test.name
+  address_prefix_length = 26
+
+  depends_on = [azurerm_subnet.existing]
+}
+
+resource "azurerm_subnet" "third" {
+  name                  = "third"
+  resource_group_name   = azurerm_resource_group.test.name
+  virtual_network_name  = azurerm_virtual_network.test.name
+  address_prefix_length = 24
+
+  depends_on = [azurerm_subnet.existing]
+}
+`, r.template(data))
+}
+
 func (r SubnetResource) requiresImport(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
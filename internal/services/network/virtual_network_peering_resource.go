@@ -129,7 +129,9 @@ func resourceVirtualNetworkPeeringCreate(d *pluginsdk.ResourceData, meta interfa
 		},
 	}
 
-	locks.ByID(virtualNetworkPeeringResourceType)
+	if err := locks.ByID(ctx, virtualNetworkPeeringResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(virtualNetworkPeeringResourceType)
 
 	deadline, ok := ctx.Deadline()
@@ -182,7 +184,9 @@ func resourceVirtualNetworkPeeringUpdate(d *pluginsdk.ResourceData, meta interfa
 		return err
 	}
 
-	locks.ByID(virtualNetworkPeeringResourceType)
+	if err := locks.ByID(ctx, virtualNetworkPeeringResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(virtualNetworkPeeringResourceType)
 
 	existing, err := client.Get(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name)
@@ -272,7 +276,9 @@ func resourceVirtualNetworkPeeringDelete(d *pluginsdk.ResourceData, meta interfa
 		return err
 	}
 
-	locks.ByID(virtualNetworkPeeringResourceType)
+	if err := locks.ByID(ctx, virtualNetworkPeeringResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(virtualNetworkPeeringResourceType)
 
 	future, err := client.Delete(ctx, id.ResourceGroup, id.VirtualNetworkName, id.Name)
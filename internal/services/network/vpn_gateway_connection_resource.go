@@ -354,7 +354,9 @@ func resourceVpnGatewayConnectionResourceCreateUpdate(d *pluginsdk.ResourceData,
 		}
 	}
 
-	locks.ByName(gatewayId.VpnGatewayName, VPNGatewayResourceName)
+	if err := locks.ByName(ctx, gatewayId.VpnGatewayName, VPNGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(gatewayId.VpnGatewayName, VPNGatewayResourceName)
 
 	payload := virtualwans.VpnConnection{
@@ -449,7 +451,9 @@ func resourceVpnGatewayConnectionResourceDelete(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(id.GatewayName, VPNGatewayResourceName)
+	if err := locks.ByName(ctx, id.GatewayName, VPNGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.GatewayName, VPNGatewayResourceName)
 
 	if err := client.VpnConnectionsDeleteThenPoll(ctx, *id); err != nil {
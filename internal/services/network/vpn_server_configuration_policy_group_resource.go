@@ -112,7 +112,9 @@ func resourceVPNServerConfigurationPolicyGroupCreateUpdate(d *pluginsdk.Resource
 		return err
 	}
 
-	locks.ByID(vpnServerConfigurationId.ID())
+	if err := locks.ByID(ctx, vpnServerConfigurationId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(vpnServerConfigurationId.ID())
 
 	id := virtualwans.NewConfigurationPolicyGroupID(subscriptionId, vpnServerConfigurationId.ResourceGroupName, vpnServerConfigurationId.VpnServerConfigurationName, d.Get("name").(string))
@@ -196,7 +198,9 @@ func resourceVPNServerConfigurationPolicyGroupDelete(d *pluginsdk.ResourceData,
 
 	vpnServerConfigurationId := virtualwans.NewVpnServerConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.VpnServerConfigurationName)
 
-	locks.ByID(vpnServerConfigurationId.ID())
+	if err := locks.ByID(ctx, vpnServerConfigurationId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(vpnServerConfigurationId.ID())
 
 	if err := client.ConfigurationPolicyGroupsDeleteThenPoll(ctx, *id); err != nil {
@@ -0,0 +1,476 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package evaluate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// operators maps the lower-cased name of each supported condition to its canonical name
+var operators = map[string]string{
+	"equals":                "equals",
+	"notequals":             "notEquals",
+	"like":                  "like",
+	"notlike":               "notLike",
+	"match":                 "match",
+	"notmatch":              "notMatch",
+	"matchinsensitively":    "matchInsensitively",
+	"notmatchinsensitively": "notMatchInsensitively",
+	"contains":              "contains",
+	"notcontains":           "notContains",
+	"in":                    "in",
+	"notin":                 "notIn",
+	"containskey":           "containsKey",
+	"notcontainskey":        "notContainsKey",
+	"less":                  "less",
+	"lessorequals":          "lessOrEquals",
+	"greater":               "greater",
+	"greaterorequals":       "greaterOrEquals",
+	"exists":                "exists",
+}
+
+// operand is the value of a `field`, `value` or `count` expression, which doesn't exist when a `field` isn't present
+type operand struct {
+	value  interface{}
+	exists bool
+}
+
+func (e *evaluator) condition(input interface{}) (bool, error) {
+	obj, ok := input.(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("expected a condition to be an object but got %T", input)
+	}
+
+	if v, ok := lookup(obj, "allOf"); ok {
+		items, ok := v.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("expected `allOf` to be an array but got %T", v)
+		}
+		for i, item := range items {
+			result, err := e.condition(item)
+			if err != nil {
+				return false, fmt.Errorf("allOf[%d]: %+v", i, err)
+			}
+			if !result {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	if v, ok := lookup(obj, "anyOf"); ok {
+		items, ok := v.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("expected `anyOf` to be an array but got %T", v)
+		}
+		for i, item := range items {
+			result, err := e.condition(item)
+			if err != nil {
+				return false, fmt.Errorf("anyOf[%d]: %+v", i, err)
+			}
+			if result {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	if v, ok := lookup(obj, "not"); ok {
+		result, err := e.condition(v)
+		if err != nil {
+			return false, fmt.Errorf("not: %+v", err)
+		}
+		return !result, nil
+	}
+
+	operator, expected, err := conditionOperator(obj)
+	if err != nil {
+		return false, err
+	}
+	expectedValue, err := e.value(expected)
+	if err != nil {
+		return false, fmt.Errorf("evaluating the value for `%s`: %+v", operator, err)
+	}
+
+	if v, ok := lookup(obj, "field"); ok {
+		path, ok := v.(string)
+		if !ok {
+			return false, fmt.Errorf("expected `field` to be a string but got %T", v)
+		}
+		path, err := e.stringValue(path)
+		if err != nil {
+			return false, fmt.Errorf("evaluating the field %q: %+v", path, err)
+		}
+
+		operands, err := e.field(path)
+		if err != nil {
+			return false, err
+		}
+
+		// conditions against an alias containing `[*]` are true only when they're true for every element
+		for _, v := range operands {
+			result, err := compare(operator, v, expectedValue)
+			if err != nil {
+				return false, fmt.Errorf("field %q: %+v", path, err)
+			}
+			if !result {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	if v, ok := lookup(obj, "value"); ok {
+		actual, err := e.value(v)
+		if err != nil {
+			return false, fmt.Errorf("evaluating `value`: %+v", err)
+		}
+		return compare(operator, operand{value: actual, exists: true}, expectedValue)
+	}
+
+	if v, ok := lookup(obj, "count"); ok {
+		count, err := e.count(v)
+		if err != nil {
+			return false, fmt.Errorf("count: %+v", err)
+		}
+		return compare(operator, operand{value: float64(count), exists: true}, expectedValue)
+	}
+
+	return false, fmt.Errorf("expected a condition to contain one of `allOf`, `anyOf`, `not`, `field`, `value` or `count`")
+}
+
+func conditionOperator(obj map[string]interface{}) (string, interface{}, error) {
+	found := make([]string, 0)
+	var value interface{}
+	for k, v := range obj {
+		lower := strings.ToLower(k)
+		if lower == "field" || lower == "value" || lower == "count" {
+			continue
+		}
+
+		operator, ok := operators[lower]
+		if !ok {
+			return "", nil, fmt.Errorf("the condition %q isn't supported", k)
+		}
+		found = append(found, operator)
+		value = v
+	}
+
+	if len(found) != 1 {
+		sort.Strings(found)
+		return "", nil, fmt.Errorf("expected a single condition (such as `equals`) but got %d: %s", len(found), strings.Join(found, ", "))
+	}
+
+	return found[0], value, nil
+}
+
+// count evaluates a field count (`{"field": "alias[*]", "where": {...}}`) or a value count
+// (`{"value": [...], "name": "...", "where": {...}}`), returning the number of elements matching the `where` condition.
+func (e *evaluator) count(input interface{}) (int, error) {
+	obj, ok := input.(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("expected `count` to be an object but got %T", input)
+	}
+
+	var scope countScope
+	elements := make([]interface{}, 0)
+
+	if v, ok := lookup(obj, "field"); ok {
+		path, ok := v.(string)
+		if !ok {
+			return 0, fmt.Errorf("expected `field` to be a string but got %T", v)
+		}
+		if !strings.HasSuffix(path, "[*]") {
+			return 0, fmt.Errorf("expected the field %q to be an array alias ending with `[*]`", path)
+		}
+
+		operands, err := e.field(path)
+		if err != nil {
+			return 0, err
+		}
+		for _, v := range operands {
+			if v.exists {
+				elements = append(elements, v.value)
+			}
+		}
+		scope = countScope{
+			name:  strings.ToLower(path),
+			field: true,
+		}
+	} else if v, ok := lookup(obj, "value"); ok {
+		value, err := e.value(v)
+		if err != nil {
+			return 0, fmt.Errorf("evaluating `value`: %+v", err)
+		}
+		items, ok := value.([]interface{})
+		if !ok {
+			return 0, fmt.Errorf("expected `value` to be an array but got %T", value)
+		}
+		elements = items
+
+		name := ""
+		if v, ok := lookup(obj, "name"); ok {
+			if name, ok = v.(string); !ok {
+				return 0, fmt.Errorf("expected `name` to be a string but got %T", v)
+			}
+		}
+		scope = countScope{
+			name: strings.ToLower(name),
+		}
+	} else {
+		return 0, fmt.Errorf("expected `count` to contain either `field` or `value`")
+	}
+
+	where, hasWhere := lookup(obj, "where")
+	if !hasWhere {
+		return len(elements), nil
+	}
+
+	count := 0
+	for i, element := range elements {
+		scope.element = element
+		e.scopes = append(e.scopes, scope)
+		result, err := e.condition(where)
+		e.scopes = e.scopes[:len(e.scopes)-1]
+		if err != nil {
+			return 0, fmt.Errorf("where (element %d): %+v", i, err)
+		}
+		if result {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func compare(operator string, actual operand, expected interface{}) (bool, error) {
+	if negated, ok := negatedOperators[operator]; ok {
+		result, err := compare(negated, actual, expected)
+		return !result, err
+	}
+
+	switch operator {
+	case "exists":
+		want, err := toBool(expected)
+		if err != nil {
+			return false, fmt.Errorf("`exists`: %+v", err)
+		}
+		return actual.exists == want, nil
+
+	case "equals":
+		return actual.exists && equal(actual.value, expected), nil
+
+	case "in":
+		items, ok := expected.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("expected the value for `in` to be an array but got %T", expected)
+		}
+		if !actual.exists {
+			return false, nil
+		}
+		for _, item := range items {
+			if equal(actual.value, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+
+	case "like":
+		pattern, ok := expected.(string)
+		if !ok {
+			return false, fmt.Errorf("expected the value for `like` to be a string but got %T", expected)
+		}
+		value, ok := actual.value.(string)
+		if !actual.exists || !ok {
+			return false, nil
+		}
+		return likePattern(pattern).MatchString(value), nil
+
+	case "match", "matchInsensitively":
+		pattern, ok := expected.(string)
+		if !ok {
+			return false, fmt.Errorf("expected the value for `%s` to be a string but got %T", operator, expected)
+		}
+		value, ok := actual.value.(string)
+		if !actual.exists || !ok {
+			return false, nil
+		}
+		return matchPattern(pattern, operator == "matchInsensitively").MatchString(value), nil
+
+	case "contains":
+		if !actual.exists {
+			return false, nil
+		}
+		switch v := actual.value.(type) {
+		case string:
+			s, ok := expected.(string)
+			return ok && strings.Contains(strings.ToLower(v), strings.ToLower(s)), nil
+		case []interface{}:
+			for _, item := range v {
+				if equal(item, expected) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+
+	case "containsKey":
+		key, ok := expected.(string)
+		if !ok {
+			return false, fmt.Errorf("expected the value for `containsKey` to be a string but got %T", expected)
+		}
+		obj, ok := actual.value.(map[string]interface{})
+		if !actual.exists || !ok {
+			return false, nil
+		}
+		_, found := lookup(obj, key)
+		return found, nil
+
+	case "less", "lessOrEquals", "greater", "greaterOrEquals":
+		if !actual.exists {
+			return false, nil
+		}
+		result, ok := order(actual.value, expected)
+		if !ok {
+			return false, fmt.Errorf("unable to compare %v (%T) with %v (%T) using `%s`", actual.value, actual.value, expected, expected, operator)
+		}
+		switch operator {
+		case "less":
+			return result < 0, nil
+		case "lessOrEquals":
+			return result <= 0, nil
+		case "greater":
+			return result > 0, nil
+		default:
+			return result >= 0, nil
+		}
+	}
+
+	return false, fmt.Errorf("the condition %q isn't supported", operator)
+}
+
+var negatedOperators = map[string]string{
+	"notEquals":             "equals",
+	"notLike":               "like",
+	"notMatch":              "match",
+	"notMatchInsensitively": "matchInsensitively",
+	"notContains":           "contains",
+	"notIn":                 "in",
+	"notContainsKey":        "containsKey",
+}
+
+// equal compares two values, where strings are compared case-insensitively as they are by Azure Policy
+func equal(a, b interface{}) bool {
+	switch v := a.(type) {
+	case string:
+		if s, ok := b.(string); ok {
+			return strings.EqualFold(v, s)
+		}
+		if f, ok := b.(float64); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			return err == nil && parsed == f
+		}
+		if bv, ok := b.(bool); ok {
+			parsed, err := strconv.ParseBool(v)
+			return err == nil && parsed == bv
+		}
+		return false
+	case float64, bool:
+		if s, ok := b.(string); ok {
+			return equal(s, v)
+		}
+	case []interface{}:
+		items, ok := b.([]interface{})
+		if !ok || len(v) != len(items) {
+			return false
+		}
+		for i := range v {
+			if !equal(v[i], items[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// order compares two numbers or strings, returning a negative number when a < b, 0 when a == b and a positive number otherwise
+func order(a, b interface{}) (int, bool) {
+	if af, ok := toNumber(a); ok {
+		if bf, ok := toNumber(b); ok {
+			switch {
+			case af < bf:
+				return -1, true
+			case af > bf:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+
+	as, aok := a.(string)
+	bs, bok := b.(string)
+	if aok && bok {
+		return strings.Compare(strings.ToLower(as), strings.ToLower(bs)), true
+	}
+
+	return 0, false
+}
+
+func toNumber(input interface{}) (float64, bool) {
+	switch v := input.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func toBool(input interface{}) (bool, error) {
+	switch v := input.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(v)
+	}
+	return false, fmt.Errorf("expected a boolean but got %T", input)
+}
+
+// likePattern converts the pattern for a `like` condition, where `*` is a wildcard, into a case-insensitive regular expression
+func likePattern(pattern string) *regexp.Regexp {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	return regexp.MustCompile("(?is)^" + expr + "$")
+}
+
+// matchPattern converts the pattern for a `match` condition, where `#` is a digit, `?` is a letter and `.` is any
+// character, into a regular expression
+func matchPattern(pattern string, insensitive bool) *regexp.Regexp {
+	var expr strings.Builder
+	if insensitive {
+		expr.WriteString("(?i)")
+	}
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '#':
+			expr.WriteString("[0-9]")
+		case '?':
+			expr.WriteString("[a-zA-Z]")
+		case '.':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
@@ -0,0 +1,475 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package evaluate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// value evaluates any template expressions (e.g. `[parameters('effect')]`) within the value
+func (e *evaluator) value(input interface{}) (interface{}, error) {
+	switch v := input.(type) {
+	case string:
+		if !isExpression(v) {
+			// a leading `[[` escapes a literal value starting with `[`
+			if strings.HasPrefix(v, "[[") {
+				return v[1:], nil
+			}
+			return v, nil
+		}
+
+		p := &expressionParser{
+			evaluator: e,
+			input:     v[1 : len(v)-1],
+		}
+		result, err := p.parse()
+		if err != nil {
+			return nil, fmt.Errorf("evaluating the expression %q: %+v", v, err)
+		}
+		return result, nil
+
+	case []interface{}:
+		output := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			value, err := e.value(item)
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, value)
+		}
+		return output, nil
+	}
+
+	return input, nil
+}
+
+func (e *evaluator) stringValue(input string) (string, error) {
+	value, err := e.value(input)
+	if err != nil {
+		return "", err
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("expected a string but got %T", value)
+	}
+	return s, nil
+}
+
+func isExpression(input string) bool {
+	return strings.HasPrefix(input, "[") && !strings.HasPrefix(input, "[[") && strings.HasSuffix(input, "]")
+}
+
+// expressionParser evaluates the subset of the template language used within Policy Rules, that is function
+// calls, string/number/boolean literals and property/index accessors.
+type expressionParser struct {
+	evaluator *evaluator
+	input     string
+	pos       int
+}
+
+func (p *expressionParser) parse() (interface{}, error) {
+	value, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipWhitespace()
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.input[p.pos:], p.pos)
+	}
+	return value, nil
+}
+
+func (p *expressionParser) expression() (interface{}, error) {
+	p.skipWhitespace()
+	if p.pos >= len(p.input) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	var value interface{}
+	var err error
+
+	switch c := p.input[p.pos]; {
+	case c == '\'':
+		value, err = p.stringLiteral()
+	case c == '-' || unicode.IsDigit(rune(c)):
+		value, err = p.numberLiteral()
+	case unicode.IsLetter(rune(c)):
+		name := p.identifier()
+		switch strings.ToLower(name) {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			value, err = p.call(name)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", string(c), p.pos)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return p.accessors(value)
+}
+
+func (p *expressionParser) call(name string) (interface{}, error) {
+	p.skipWhitespace()
+	if !p.consume('(') {
+		return nil, fmt.Errorf("expected `(` after %q", name)
+	}
+
+	args := make([]interface{}, 0)
+	p.skipWhitespace()
+	if !p.consume(')') {
+		for {
+			arg, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			p.skipWhitespace()
+			if p.consume(')') {
+				break
+			}
+			if !p.consume(',') {
+				return nil, fmt.Errorf("expected `,` or `)` at position %d", p.pos)
+			}
+		}
+	}
+
+	return p.evaluator.function(name, args)
+}
+
+func (p *expressionParser) accessors(value interface{}) (interface{}, error) {
+	for {
+		p.skipWhitespace()
+		switch {
+		case p.consume('.'):
+			name := p.identifier()
+			if name == "" {
+				return nil, fmt.Errorf("expected a property name at position %d", p.pos)
+			}
+			obj, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("unable to access the property %q of %T", name, value)
+			}
+			v, ok := lookup(obj, name)
+			if !ok {
+				return nil, fmt.Errorf("the property %q doesn't exist", name)
+			}
+			value = v
+
+		case p.consume('['):
+			index, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			p.skipWhitespace()
+			if !p.consume(']') {
+				return nil, fmt.Errorf("expected `]` at position %d", p.pos)
+			}
+			if value, err = indexValue(value, index); err != nil {
+				return nil, err
+			}
+
+		default:
+			return value, nil
+		}
+	}
+}
+
+func indexValue(value, index interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		i, ok := index.(float64)
+		if !ok || i < 0 || int(i) >= len(v) {
+			return nil, fmt.Errorf("the index %v is out of range", index)
+		}
+		return v[int(i)], nil
+	case map[string]interface{}:
+		key, ok := index.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string key but got %T", index)
+		}
+		item, ok := lookup(v, key)
+		if !ok {
+			return nil, fmt.Errorf("the property %q doesn't exist", key)
+		}
+		return item, nil
+	}
+
+	return nil, fmt.Errorf("unable to index %T", value)
+}
+
+func (p *expressionParser) stringLiteral() (interface{}, error) {
+	p.pos++ // opening quote
+
+	var output strings.Builder
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+		if c != '\'' {
+			output.WriteByte(c)
+			continue
+		}
+
+		// a quote is escaped by doubling it
+		if p.pos < len(p.input) && p.input[p.pos] == '\'' {
+			output.WriteByte('\'')
+			p.pos++
+			continue
+		}
+		return output.String(), nil
+	}
+
+	return nil, fmt.Errorf("unterminated string literal")
+}
+
+func (p *expressionParser) numberLiteral() (interface{}, error) {
+	start := p.pos
+	p.pos++
+	for p.pos < len(p.input) && (unicode.IsDigit(rune(p.input[p.pos])) || p.input[p.pos] == '.') {
+		p.pos++
+	}
+
+	value, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the number %q: %+v", p.input[start:p.pos], err)
+	}
+	return value, nil
+}
+
+func (p *expressionParser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.input) && (unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos])) || p.input[p.pos] == '_') {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *expressionParser) consume(c byte) bool {
+	if p.pos < len(p.input) && p.input[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *expressionParser) skipWhitespace() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// function evaluates the template function with the (already evaluated) arguments
+func (e *evaluator) function(name string, args []interface{}) (interface{}, error) {
+	switch strings.ToLower(name) {
+	case "parameters":
+		key, err := stringArg(name, args, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := lookup(e.parameters, key)
+		if !ok {
+			return nil, fmt.Errorf("no value was specified for the parameter %q", key)
+		}
+		return value, nil
+
+	case "field":
+		path, err := stringArg(name, args, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		operands, err := e.field(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(operands) == 1 && !strings.Contains(path, "[*]") {
+			return operands[0].value, nil
+		}
+		values := make([]interface{}, 0)
+		for _, v := range operands {
+			if v.exists {
+				values = append(values, v.value)
+			}
+		}
+		return values, nil
+
+	case "current":
+		key := ""
+		if len(args) > 0 {
+			var err error
+			if key, err = stringArg(name, args, 0, 1); err != nil {
+				return nil, err
+			}
+		}
+		for i := len(e.scopes) - 1; i >= 0; i-- {
+			if key == "" || e.scopes[i].name == strings.ToLower(key) {
+				return e.scopes[i].element, nil
+			}
+		}
+		return nil, fmt.Errorf("`current(%q)` must be used within the `where` condition of a matching `count`", key)
+
+	case "concat":
+		if len(args) > 0 {
+			if _, ok := args[0].([]interface{}); ok {
+				output := make([]interface{}, 0)
+				for _, arg := range args {
+					items, ok := arg.([]interface{})
+					if !ok {
+						return nil, fmt.Errorf("`concat`: expected all of the arguments to be arrays")
+					}
+					output = append(output, items...)
+				}
+				return output, nil
+			}
+		}
+		var output strings.Builder
+		for _, arg := range args {
+			output.WriteString(toString(arg))
+		}
+		return output.String(), nil
+
+	case "tolower", "toupper":
+		s, err := stringArg(name, args, 0, 1)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(name, "toLower") {
+			return strings.ToLower(s), nil
+		}
+		return strings.ToUpper(s), nil
+
+	case "replace":
+		if len(args) != 3 {
+			return nil, fmt.Errorf("`replace` expects 3 arguments but got %d", len(args))
+		}
+		return strings.ReplaceAll(toString(args[0]), toString(args[1]), toString(args[2])), nil
+
+	case "split":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("`split` expects 2 arguments but got %d", len(args))
+		}
+		output := make([]interface{}, 0)
+		for _, v := range strings.Split(toString(args[0]), toString(args[1])) {
+			output = append(output, v)
+		}
+		return output, nil
+
+	case "empty":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("`empty` expects 1 argument but got %d", len(args))
+		}
+		return length(args[0]) == 0, nil
+
+	case "length":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("`length` expects 1 argument but got %d", len(args))
+		}
+		return float64(length(args[0])), nil
+
+	case "first", "last":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("`%s` expects 1 argument but got %d", name, len(args))
+		}
+		items, ok := args[0].([]interface{})
+		if !ok || len(items) == 0 {
+			return nil, nil
+		}
+		if strings.EqualFold(name, "first") {
+			return items[0], nil
+		}
+		return items[len(items)-1], nil
+
+	case "contains":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("`contains` expects 2 arguments but got %d", len(args))
+		}
+		return compare("contains", operand{value: args[0], exists: true}, args[1])
+
+	case "equals":
+		if len(args) != 2 {
+			return nil, fmt.Errorf("`equals` expects 2 arguments but got %d", len(args))
+		}
+		return equal(args[0], args[1]), nil
+
+	case "not":
+		if len(args) != 1 {
+			return nil, fmt.Errorf("`not` expects 1 argument but got %d", len(args))
+		}
+		b, err := toBool(args[0])
+		return !b, err
+
+	case "and", "or":
+		isAnd := strings.EqualFold(name, "and")
+		for _, arg := range args {
+			b, err := toBool(arg)
+			if err != nil {
+				return nil, fmt.Errorf("`%s`: %+v", name, err)
+			}
+			if b != isAnd {
+				return b, nil
+			}
+		}
+		return isAnd, nil
+
+	case "if":
+		if len(args) != 3 {
+			return nil, fmt.Errorf("`if` expects 3 arguments but got %d", len(args))
+		}
+		b, err := toBool(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("`if`: %+v", err)
+		}
+		if b {
+			return args[1], nil
+		}
+		return args[2], nil
+	}
+
+	return nil, fmt.Errorf("the function %q isn't supported", name)
+}
+
+func stringArg(function string, args []interface{}, index, expected int) (string, error) {
+	if len(args) != expected {
+		return "", fmt.Errorf("`%s` expects %d argument(s) but got %d", function, expected, len(args))
+	}
+	s, ok := args[index].(string)
+	if !ok {
+		return "", fmt.Errorf("`%s` expects argument %d to be a string but got %T", function, index+1, args[index])
+	}
+	return s, nil
+}
+
+func toString(input interface{}) string {
+	switch v := input.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", input)
+}
+
+func length(input interface{}) int {
+	switch v := input.(type) {
+	case string:
+		return len(v)
+	case []interface{}:
+		return len(v)
+	case map[string]interface{}:
+		return len(v)
+	}
+	return 0
+}
@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package evaluate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// aliases maps the (lower-cased) Policy Aliases for common properties which don't follow the path of the property
+// within the Resource to that path. Other aliases are resolved by removing the Resource Type and matching the
+// remaining segments against the Resource, where segments which aren't found are looked up within `properties`.
+var aliases = map[string]string{
+	"microsoft.compute/virtualmachines/imagepublisher":         "properties.storageProfile.imageReference.publisher",
+	"microsoft.compute/virtualmachines/imageoffer":             "properties.storageProfile.imageReference.offer",
+	"microsoft.compute/virtualmachines/imagesku":               "properties.storageProfile.imageReference.sku",
+	"microsoft.compute/virtualmachines/imageversion":           "properties.storageProfile.imageReference.version",
+	"microsoft.compute/virtualmachines/sku.name":               "properties.hardwareProfile.vmSize",
+	"microsoft.compute/virtualmachines/osdisk.ostype":          "properties.storageProfile.osDisk.osType",
+	"microsoft.compute/virtualmachinescalesets/imagepublisher": "properties.virtualMachineProfile.storageProfile.imageReference.publisher",
+	"microsoft.compute/virtualmachinescalesets/imageoffer":     "properties.virtualMachineProfile.storageProfile.imageReference.offer",
+	"microsoft.compute/virtualmachinescalesets/imagesku":       "properties.virtualMachineProfile.storageProfile.imageReference.sku",
+	"microsoft.compute/virtualmachinescalesets/imageversion":   "properties.virtualMachineProfile.storageProfile.imageReference.version",
+	"microsoft.storage/storageaccounts/enableblobencryption":   "properties.encryption.services.blob.enabled",
+	"microsoft.storage/storageaccounts/enablefileencryption":   "properties.encryption.services.file.enabled",
+}
+
+type pathSegment struct {
+	name     string
+	wildcard bool
+}
+
+var tagPathRegex = regexp.MustCompile(`(?i)^tags(?:\[['"]?(.+?)['"]?\]|\.(.+))$`)
+
+// field returns the value of the field (or alias) within the Resource - returning multiple values when the alias
+// contains `[*]`, or a single value which doesn't exist when the field isn't present.
+func (e *evaluator) field(path string) ([]operand, error) {
+	lower := strings.ToLower(path)
+
+	// within a field count, aliases which begin with the counted alias refer to the current element
+	for i := len(e.scopes) - 1; i >= 0; i-- {
+		scope := e.scopes[i]
+		if !scope.field || !strings.HasPrefix(lower, scope.name) {
+			continue
+		}
+		remaining := strings.TrimPrefix(strings.TrimPrefix(path[len(scope.name):], "."), "/")
+		segments, err := parsePath(remaining)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the field %q: %+v", path, err)
+		}
+		return resolve(scope.element, segments), nil
+	}
+
+	switch lower {
+	case "name", "fullname", "kind", "type", "location", "id", "tags":
+		return e.rootField(strings.TrimPrefix(lower, "full")), nil
+	case "identity.type":
+		return resolve(e.resource, []pathSegment{{name: "identity"}, {name: "type"}}), nil
+	}
+
+	if match := tagPathRegex.FindStringSubmatch(path); match != nil {
+		name := match[1]
+		if name == "" {
+			name = match[2]
+		}
+		tags, ok := lookup(e.resource, "tags")
+		if !ok {
+			return []operand{{}}, nil
+		}
+		tagsObj, ok := tags.(map[string]interface{})
+		if !ok {
+			return []operand{{}}, nil
+		}
+		value, exists := lookup(tagsObj, name)
+		return []operand{{value: value, exists: exists}}, nil
+	}
+
+	propertyPath := path
+	if v, ok := aliases[lower]; ok {
+		propertyPath = v
+	} else if e.resourceType != "" && strings.HasPrefix(lower, strings.ToLower(e.resourceType)+"/") {
+		propertyPath = path[len(e.resourceType)+1:]
+	} else if strings.Contains(path, "/") {
+		// an alias for another Resource Type, so the field doesn't exist for this Resource
+		return []operand{{}}, nil
+	}
+
+	segments, err := parsePath(propertyPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the field %q: %+v", path, err)
+	}
+	return resolve(e.resource, segments), nil
+}
+
+func (e *evaluator) rootField(name string) []operand {
+	value, exists := lookup(e.resource, name)
+	return []operand{{value: value, exists: exists}}
+}
+
+// parsePath parses a path such as `securityRules[*].properties.access` into its segments
+func parsePath(input string) ([]pathSegment, error) {
+	segments := make([]pathSegment, 0)
+	if input == "" {
+		return segments, nil
+	}
+
+	for _, part := range strings.Split(input, ".") {
+		wildcards := 0
+		for strings.HasSuffix(part, "[*]") {
+			part = strings.TrimSuffix(part, "[*]")
+			wildcards++
+		}
+		if part == "" || strings.ContainsAny(part, "[]") {
+			return nil, fmt.Errorf("the segment %q is invalid", part)
+		}
+
+		segments = append(segments, pathSegment{name: part})
+		for i := 0; i < wildcards; i++ {
+			segments = append(segments, pathSegment{wildcard: true})
+		}
+	}
+
+	return segments, nil
+}
+
+// resolve returns the values at the path within the input. Properties which aren't found are looked up within
+// the `properties` of the object, since aliases omit these for nested resources (such as Security Rules).
+func resolve(input interface{}, segments []pathSegment) []operand {
+	if len(segments) == 0 {
+		return []operand{{value: input, exists: true}}
+	}
+
+	segment := segments[0]
+	if segment.wildcard {
+		items, ok := input.([]interface{})
+		if !ok {
+			return []operand{{}}
+		}
+		output := make([]operand, 0)
+		for _, item := range items {
+			output = append(output, resolve(item, segments[1:])...)
+		}
+		return output
+	}
+
+	obj, ok := input.(map[string]interface{})
+	if !ok {
+		return []operand{{}}
+	}
+	value, exists := lookup(obj, segment.name)
+	if !exists {
+		if properties, ok := lookup(obj, "properties"); ok && !strings.EqualFold(segment.name, "properties") {
+			if propertiesObj, ok := properties.(map[string]interface{}); ok {
+				value, exists = lookup(propertiesObj, segment.name)
+			}
+		}
+	}
+	if !exists {
+		return []operand{{}}
+	}
+
+	return resolve(value, segments[1:])
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package evaluate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Result is the outcome of evaluating a Policy Rule against a Resource
+type Result struct {
+	// Matched specifies whether the `if` condition of the Policy Rule matched the Resource
+	Matched bool
+
+	// Effect is the `effect` within the `then` block of the Policy Rule, with any expressions evaluated
+	Effect string
+}
+
+// PolicyRuleFromJSON evaluates the JSON representation of a Policy Rule (the `policy_rule` of a Policy Definition)
+// against the JSON representation of a Resource, as returned by Resource Manager.
+//
+// The parameters are a JSON object containing the value for each parameter used within the Policy Rule, either
+// directly or in the same format as the `parameters` of a Policy Assignment (e.g. `{"effect": {"value": "Deny"}}`).
+func PolicyRuleFromJSON(rule, resource, parameters string) (*Result, error) {
+	var ruleObj map[string]interface{}
+	if err := json.Unmarshal([]byte(rule), &ruleObj); err != nil {
+		return nil, fmt.Errorf("parsing the Policy Rule: %+v", err)
+	}
+
+	var resourceObj map[string]interface{}
+	if err := json.Unmarshal([]byte(resource), &resourceObj); err != nil {
+		return nil, fmt.Errorf("parsing the Resource: %+v", err)
+	}
+
+	parametersObj := make(map[string]interface{})
+	if strings.TrimSpace(parameters) != "" {
+		if err := json.Unmarshal([]byte(parameters), &parametersObj); err != nil {
+			return nil, fmt.Errorf("parsing the Parameters: %+v", err)
+		}
+	}
+
+	for k, v := range parametersObj {
+		if obj, ok := v.(map[string]interface{}); ok && len(obj) == 1 {
+			if value, ok := obj["value"]; ok {
+				parametersObj[k] = value
+			}
+		}
+	}
+
+	return PolicyRule(ruleObj, resourceObj, parametersObj)
+}
+
+// PolicyRule evaluates a Policy Rule against a Resource, using the specified values for the parameters.
+func PolicyRule(rule, resource, parameters map[string]interface{}) (*Result, error) {
+	e := newEvaluator(resource, parameters)
+
+	condition, ok := lookup(rule, "if")
+	if !ok {
+		return nil, fmt.Errorf("the Policy Rule must contain an `if` condition")
+	}
+
+	then, ok := lookup(rule, "then")
+	if !ok {
+		return nil, fmt.Errorf("the Policy Rule must contain a `then` block")
+	}
+	thenObj, ok := then.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected `then` to be an object but got %T", then)
+	}
+
+	rawEffect, ok := lookup(thenObj, "effect")
+	if !ok {
+		return nil, fmt.Errorf("the `then` block must contain an `effect`")
+	}
+	effect, err := e.value(rawEffect)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating `then.effect`: %+v", err)
+	}
+	effectStr, ok := effect.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected `then.effect` to evaluate to a string but got %T", effect)
+	}
+
+	matched, err := e.condition(condition)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating the `if` condition: %+v", err)
+	}
+
+	return &Result{
+		Matched: matched,
+		Effect:  effectStr,
+	}, nil
+}
+
+type evaluator struct {
+	resource     map[string]interface{}
+	resourceType string
+	parameters   map[string]interface{}
+
+	// scopes contains the element currently being evaluated by each enclosing `count` expression
+	scopes []countScope
+}
+
+type countScope struct {
+	// name is the (lower-cased) `name` of a value count, or the alias of the array for a field count
+	name    string
+	element interface{}
+	field   bool
+}
+
+func newEvaluator(resource, parameters map[string]interface{}) *evaluator {
+	resourceType := ""
+	if v, ok := lookup(resource, "type"); ok {
+		resourceType, _ = v.(string)
+	}
+
+	if parameters == nil {
+		parameters = make(map[string]interface{})
+	}
+
+	return &evaluator{
+		resource:     resource,
+		resourceType: resourceType,
+		parameters:   parameters,
+	}
+}
+
+// lookup returns the value for the key within the object, since the keys within Policy Rules and Resources are case-insensitive.
+func lookup(input map[string]interface{}, key string) (interface{}, bool) {
+	if v, ok := input[key]; ok {
+		return v, true
+	}
+
+	for k, v := range input {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package evaluate
+
+import (
+	"testing"
+)
+
+const testStorageAccount = `{
+  "id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example",
+  "name": "example",
+  "type": "Microsoft.Storage/storageAccounts",
+  "location": "westeurope",
+  "kind": "StorageV2",
+  "sku": {
+    "name": "Standard_LRS"
+  },
+  "tags": {
+    "Environment": "Production"
+  },
+  "properties": {
+    "supportsHttpsTrafficOnly": false,
+    "minimumTlsVersion": "TLS1_0",
+    "encryption": {
+      "services": {
+        "blob": {
+          "enabled": true
+        }
+      }
+    },
+    "networkAcls": {
+      "defaultAction": "Allow",
+      "ipRules": [
+        {
+          "value": "1.2.3.4",
+          "action": "Allow"
+        },
+        {
+          "value": "0.0.0.0/0",
+          "action": "Allow"
+        }
+      ]
+    }
+  }
+}`
+
+const testNetworkSecurityGroup = `{
+  "name": "example",
+  "type": "Microsoft.Network/networkSecurityGroups",
+  "location": "westeurope",
+  "properties": {
+    "securityRules": [
+      {
+        "name": "ssh",
+        "properties": {
+          "access": "Allow",
+          "direction": "Inbound",
+          "destinationPortRange": "22",
+          "sourceAddressPrefix": "*"
+        }
+      },
+      {
+        "name": "https",
+        "properties": {
+          "access": "Allow",
+          "direction": "Inbound",
+          "destinationPortRange": "443",
+          "sourceAddressPrefix": "10.0.0.0/8"
+        }
+      }
+    ]
+  }
+}`
+
+func TestPolicyRuleFromJSON(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Rule       string
+		Resource   string
+		Parameters string
+		Matched    bool
+		Effect     string
+		Error      bool
+	}{
+		{
+			Name: "equals on an alias",
+			Rule: `{
+  "if": {
+    "allOf": [
+      {"field": "type", "equals": "Microsoft.Storage/storageAccounts"},
+      {"field": "Microsoft.Storage/storageAccounts/supportsHttpsTrafficOnly", "equals": "false"}
+    ]
+  },
+  "then": {"effect": "deny"}
+}`,
+			Resource: testStorageAccount,
+			Matched:  true,
+			Effect:   "deny",
+		},
+		{
+			Name: "equals is case-insensitive",
+			Rule: `{
+  "if": {"field": "kind", "equals": "storagev2"},
+  "then": {"effect": "audit"}
+}`,
+			Resource: testStorageAccount,
+			Matched:  true,
+			Effect:   "audit",
+		},
+		{
+			Name: "alias for another resource type doesn't exist",
+			Rule: `{
+  "if": {"field": "Microsoft.Compute/virtualMachines/imagePublisher", "exists": "true"},
+  "then": {"effect": "audit"}
+}`,
+			Resource: testStorageAccount,
+			Matched:  false,
+			Effect:   "audit",
+		},
+		{
+			Name: "mapped alias",
+			Rule: `{
+  "if": {"field": "Microsoft.Storage/storageAccounts/enableBlobEncryption", "equals": true},
+  "then": {"effect": "audit"}
+}`,
+			Resource: testStorageAccount,
+			Matched:  true,
+			Effect:   "audit",
+		},
+		{
+			Name: "top-level alias",
+			Rule: `{
+  "if": {"field": "Microsoft.Storage/storageAccounts/sku.name", "in": ["Standard_GRS", "Standard_RAGRS"]},
+  "then": {"effect": "deny"}
+}`,
+			Resource: testStorageAccount,
+			Matched:  false,
+			Effect:   "deny",
+		},
+		{
+			Name: "notIn",
+			Rule: `{
+  "if": {"field": "location", "notIn": "[parameters('allowedLocations')]"},
+  "then": {"effect": "[parameters('effect')]"}
+}`,
+			Resource:   testStorageAccount,
+			Parameters: `{"allowedLocations": {"value": ["eastus", "westus"]}, "effect": "Deny"}`,
+			Matched:    true,
+			Effect:     "Deny",
+		},
+		{
+			Name: "tags",
+			Rule: `{
+  "if": {
+    "anyOf": [
+      {"field": "tags['CostCenter']", "exists": false},
+      {"field": "tags.Environment", "notEquals": "Production"}
+    ]
+  },
+  "then": {"effect": "modify"}
+}`,
+			Resource: testStorageAccount,
+			Matched:  true,
+			Effect:   "modify",
+		},
+		{
+			Name: "tag name from a parameter",
+			Rule: `{
+  "if": {"field": "[concat('tags[', parameters('tagName'), ']')]", "exists": "false"},
+  "then": {"effect": "deny"}
+}`,
+			Resource:   testStorageAccount,
+			Parameters: `{"tagName": {"value": "Environment"}}`,
+			Matched:    false,
+			Effect:     "deny",
+		},
+		{
+			Name: "like and not",
+			Rule: `{
+  "if": {"not": {"field": "name", "like": "ex*"}},
+  "then": {"effect": "deny"}
+}`,
+			Resource: testStorageAccount,
+			Matched:  false,
+			Effect:   "deny",
+		},
+		{
+			Name: "match",
+			Rule: `{
+  "if": {"field": "Microsoft.Storage/storageAccounts/minimumTlsVersion", "match": "???#_#"},
+  "then": {"effect": "deny"}
+}`,
+			Resource: testStorageAccount,
+			Matched:  true,
+			Effect:   "deny",
+		},
+		{
+			Name: "array alias must match every element",
+			Rule: `{
+  "if": {"field": "Microsoft.Storage/storageAccounts/networkAcls.ipRules[*].action", "equals": "Allow"},
+  "then": {"effect": "audit"}
+}`,
+			Resource: testStorageAccount,
+			Matched:  true,
+			Effect:   "audit",
+		},
+		{
+			Name: "field count",
+			Rule: `{
+  "if": {
+    "count": {
+      "field": "Microsoft.Network/networkSecurityGroups/securityRules[*]",
+      "where": {
+        "allOf": [
+          {"field": "Microsoft.Network/networkSecurityGroups/securityRules[*].access", "equals": "Allow"},
+          {"field": "Microsoft.Network/networkSecurityGroups/securityRules[*].sourceAddressPrefix", "in": ["*", "Internet"]}
+        ]
+      }
+    },
+    "greater": 0
+  },
+  "then": {"effect": "deny"}
+}`,
+			Resource: testNetworkSecurityGroup,
+			Matched:  true,
+			Effect:   "deny",
+		},
+		{
+			Name: "value count",
+			Rule: `{
+  "if": {
+    "count": {
+      "value": "[parameters('blockedPorts')]",
+      "name": "port",
+      "where": {
+        "count": {
+          "field": "Microsoft.Network/networkSecurityGroups/securityRules[*]",
+          "where": {"field": "Microsoft.Network/networkSecurityGroups/securityRules[*].destinationPortRange", "equals": "[current('port')]"}
+        },
+        "greater": 0
+      }
+    },
+    "equals": 1
+  },
+  "then": {"effect": "deny"}
+}`,
+			Resource:   testNetworkSecurityGroup,
+			Parameters: `{"blockedPorts": {"value": ["22", "3389"]}}`,
+			Matched:    true,
+			Effect:     "deny",
+		},
+		{
+			Name: "value condition with a template function",
+			Rule: `{
+  "if": {"value": "[toLower(field('location'))]", "contains": "europe"},
+  "then": {"effect": "audit"}
+}`,
+			Resource: testStorageAccount,
+			Matched:  true,
+			Effect:   "audit",
+		},
+		{
+			Name: "unsupported condition",
+			Rule: `{
+  "if": {"field": "name", "startsWith": "ex"},
+  "then": {"effect": "deny"}
+}`,
+			Resource: testStorageAccount,
+			Error:    true,
+		},
+		{
+			Name: "missing parameter",
+			Rule: `{
+  "if": {"field": "name", "equals": "[parameters('name')]"},
+  "then": {"effect": "deny"}
+}`,
+			Resource: testStorageAccount,
+			Error:    true,
+		},
+		{
+			Name: "multiple conditions",
+			Rule: `{
+  "if": {"field": "name", "equals": "example", "notEquals": "other"},
+  "then": {"effect": "deny"}
+}`,
+			Resource: testStorageAccount,
+			Error:    true,
+		},
+		{
+			Name:     "missing then",
+			Rule:     `{"if": {"field": "name", "equals": "example"}}`,
+			Resource: testStorageAccount,
+			Error:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Name)
+
+		result, err := PolicyRuleFromJSON(tc.Rule, tc.Resource, tc.Parameters)
+		if err != nil {
+			if tc.Error {
+				continue
+			}
+			t.Fatalf("evaluating: %+v", err)
+		}
+		if tc.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		if result.Matched != tc.Matched {
+			t.Fatalf("expected Matched to be %t but got %t", tc.Matched, result.Matched)
+		}
+		if result.Effect != tc.Effect {
+			t.Fatalf("expected the Effect to be %q but got %q", tc.Effect, result.Effect)
+		}
+	}
+}
+
+func TestLikePattern(t *testing.T) {
+	cases := []struct {
+		Pattern  string
+		Input    string
+		Expected bool
+	}{
+		{Pattern: "prod-*", Input: "PROD-web", Expected: true},
+		{Pattern: "*-web", Input: "prod-web", Expected: true},
+		{Pattern: "prod-*", Input: "dev-web", Expected: false},
+		{Pattern: "a.b", Input: "axb", Expected: false},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q against %q", tc.Input, tc.Pattern)
+
+		if actual := likePattern(tc.Pattern).MatchString(tc.Input); actual != tc.Expected {
+			t.Fatalf("expected %t but got %t", tc.Expected, actual)
+		}
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	cases := []struct {
+		Pattern     string
+		Input       string
+		Insensitive bool
+		Expected    bool
+	}{
+		{Pattern: "vm-###", Input: "vm-001", Expected: true},
+		{Pattern: "vm-###", Input: "VM-001", Expected: false},
+		{Pattern: "vm-###", Input: "VM-001", Insensitive: true, Expected: true},
+		{Pattern: "??-.", Input: "ab-!", Expected: true},
+		{Pattern: "??-.", Input: "a1-!", Expected: false},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q against %q", tc.Input, tc.Pattern)
+
+		if actual := matchPattern(tc.Pattern, tc.Insensitive).MatchString(tc.Input); actual != tc.Expected {
+			t.Fatalf("expected %t but got %t", tc.Expected, actual)
+		}
+	}
+}
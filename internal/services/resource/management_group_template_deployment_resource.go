@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
@@ -100,6 +101,11 @@ func managementGroupTemplateDeploymentResource() *pluginsdk.Resource {
 
 			"tags": tags.Schema(),
 
+			"what_if_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+			},
+
 			// Computed
 			"output_content": {
 				Type:     pluginsdk.TypeString,
@@ -107,7 +113,11 @@ func managementGroupTemplateDeploymentResource() *pluginsdk.Resource {
 				// NOTE:  outputs can be strings, ints, objects etc - whilst using a nested object was considered
 				// parsing the JSON using `jsondecode` allows the users to interact with/map objects as required
 			},
+
+			"what_if_changes": templateDeploymentWhatIfChangesSchema(),
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(managementGroupTemplateDeploymentWhatIfCustomizeDiff),
 	}
 }
 
@@ -339,6 +349,9 @@ func managementGroupTemplateDeploymentResourceRead(d *pluginsdk.ResourceData, me
 	}
 	d.Set("template_content", flattenedTemplate)
 
+	// the predicted changes only apply to the plan in which the What-If operation was run
+	d.Set("what_if_changes", []interface{}{})
+
 	return tags.FlattenAndSet(d, resp.Tags)
 }
 
@@ -391,3 +404,38 @@ func validateManagementGroupTemplateDeployment(ctx context.Context, id parse.Man
 
 	return nil
 }
+
+func managementGroupTemplateDeploymentWhatIfCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	fieldsToCompare := append([]string{"management_group_id", "location"}, templateDeploymentWhatIfFields...)
+	_, err := templateDeploymentWhatIfChanges(ctx, d, meta, fieldsToCompare, func(ctx context.Context, client *resources.DeploymentsClient, d *pluginsdk.ResourceDiff, properties resources.DeploymentWhatIfProperties) (*resources.WhatIfOperationResult, error) {
+		managementGroupId, err := mgParse.ManagementGroupID(d.Get("management_group_id").(string))
+		if err != nil {
+			return nil, err
+		}
+
+		parameters := resources.ScopedDeploymentWhatIf{
+			Location:   utils.String(location.Normalize(d.Get("location").(string))),
+			Properties: &properties,
+		}
+
+		future, err := client.WhatIfAtManagementGroupScope(ctx, managementGroupId.Name, d.Get("name").(string), parameters)
+		if err != nil {
+			// the Management Group may be created within the same apply
+			if response.WasNotFound(future.Response()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("requesting What-If: %+v", err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return nil, fmt.Errorf("waiting for What-If: %+v", err)
+		}
+		result, err := future.Result(*client)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving What-If result: %+v", err)
+		}
+
+		return &result, nil
+	})
+
+	return err
+}
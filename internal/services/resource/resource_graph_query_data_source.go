@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	resourcegraph "github.com/hashicorp/go-azure-sdk/resource-manager/resourcegraph/2022-10-01/resources"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	mgParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/managementgroup/parse"
+	mgValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/managementgroup/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// resourceGraphQueryPageSize is the maximum number of rows which can be returned in a single page
+const resourceGraphQueryPageSize = 1000
+
+var _ sdk.DataSource = ResourceGraphQueryDataSource{}
+
+type ResourceGraphQueryDataSource struct{}
+
+type ResourceGraphQueryDataSourceModel struct {
+	Query              string   `tfschema:"query"`
+	SubscriptionIds    []string `tfschema:"subscription_ids"`
+	ManagementGroupIds []string `tfschema:"management_group_ids"`
+	AllowPartialScopes bool     `tfschema:"allow_partial_scopes"`
+	RowsJson           string   `tfschema:"rows_json"`
+	TotalRecords       int64    `tfschema:"total_records"`
+}
+
+func (ResourceGraphQueryDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"query": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotWhiteSpace,
+		},
+
+		"subscription_ids": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.IsUUID,
+			},
+			ConflictsWith: []string{"management_group_ids"},
+		},
+
+		"management_group_ids": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: mgValidate.ManagementGroupID,
+			},
+			ConflictsWith: []string{"subscription_ids"},
+		},
+
+		"allow_partial_scopes": {
+			Type:         pluginsdk.TypeBool,
+			Optional:     true,
+			RequiredWith: []string{"management_group_ids"},
+		},
+	}
+}
+
+func (ResourceGraphQueryDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"rows_json": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"total_records": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+	}
+}
+
+func (ResourceGraphQueryDataSource) ModelObject() interface{} {
+	return &ResourceGraphQueryDataSourceModel{}
+}
+
+func (ResourceGraphQueryDataSource) ResourceType() string {
+	return "azurerm_resource_graph_query"
+}
+
+func (ResourceGraphQueryDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.ResourceGraphClient
+
+			var state ResourceGraphQueryDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			request := resourcegraph.QueryRequest{
+				Query: state.Query,
+				Options: &resourcegraph.QueryRequestOptions{
+					ResultFormat: pointer.To(resourcegraph.ResultFormatObjectArray),
+					Top:          pointer.To(int64(resourceGraphQueryPageSize)),
+				},
+			}
+
+			scopes := make([]string, 0)
+			if len(state.ManagementGroupIds) > 0 {
+				managementGroups := make([]string, 0)
+				for _, v := range state.ManagementGroupIds {
+					id, err := mgParse.ManagementGroupID(v)
+					if err != nil {
+						return err
+					}
+					managementGroups = append(managementGroups, id.Name)
+					scopes = append(scopes, id.ID())
+				}
+				request.ManagementGroups = &managementGroups
+				request.Options.AllowPartialScopes = pointer.To(state.AllowPartialScopes)
+			} else {
+				subscriptions := state.SubscriptionIds
+				if len(subscriptions) == 0 {
+					subscriptions = []string{metadata.Client.Account.SubscriptionId}
+				}
+				request.Subscriptions = &subscriptions
+				for _, v := range subscriptions {
+					scopes = append(scopes, fmt.Sprintf("/subscriptions/%s", v))
+				}
+			}
+
+			rows := make([]interface{}, 0)
+			for {
+				resp, err := client.Resources(ctx, request)
+				if err != nil {
+					return fmt.Errorf("running the Resource Graph query: %+v", err)
+				}
+				if resp.Model == nil {
+					return fmt.Errorf("running the Resource Graph query: model was nil")
+				}
+
+				page, err := resourceGraphQueryRows(resp.Model.Data)
+				if err != nil {
+					return err
+				}
+				rows = append(rows, page...)
+				state.TotalRecords = resp.Model.TotalRecords
+
+				if resp.Model.SkipToken == nil || *resp.Model.SkipToken == "" {
+					break
+				}
+				request.Options.SkipToken = resp.Model.SkipToken
+			}
+
+			rowsJson, err := json.Marshal(rows)
+			if err != nil {
+				return fmt.Errorf("marshalling the rows returned from the Resource Graph query: %+v", err)
+			}
+			state.RowsJson = string(rowsJson)
+
+			hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%s", state.Query, strings.Join(scopes, ","))))
+			metadata.ResourceData.SetId(fmt.Sprintf("resourceGraphQuery/%s", hex.EncodeToString(hash[:])))
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+// resourceGraphQueryRows returns the rows within a page of results, which are returned as an array of objects
+// since the `objectArray` result format is requested.
+func resourceGraphQueryRows(input interface{}) ([]interface{}, error) {
+	if input == nil {
+		return []interface{}{}, nil
+	}
+
+	rows, ok := input.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected the Resource Graph query to return an array of rows but got %T", input)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type ResourceGraphQueryDataSource struct{}
+
+func TestAccResourceGraphQueryDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource_graph_query", "test")
+	r := ResourceGraphQueryDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("total_records").HasValue("1"),
+				check.That(data.ResourceName).Key("rows_json").HasValue(fmt.Sprintf(`[{"name":"acctestRG-%d","tagValue":"%d"}]`, data.RandomInteger, data.RandomInteger)),
+			),
+		},
+	})
+}
+
+func TestAccResourceGraphQueryDataSource_subscriptionIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource_graph_query", "test")
+	r := ResourceGraphQueryDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.subscriptionIds(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("total_records").HasValue("1"),
+				check.That(data.ResourceName).Key("rows_json").IsNotEmpty(),
+			),
+		},
+	})
+}
+
+func (ResourceGraphQueryDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = %[2]q
+
+  tags = {
+    acctest = "%[1]d"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (r ResourceGraphQueryDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_resource_graph_query" "test" {
+  query = <<QUERY
+ResourceContainers
+| where type =~ 'microsoft.resources/subscriptions/resourcegroups'
+| where name == '${azurerm_resource_group.test.name}'
+| project name, tagValue = tostring(tags.acctest)
+QUERY
+}
+`, r.template(data))
+}
+
+func (r ResourceGraphQueryDataSource) subscriptionIds(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_client_config" "current" {}
+
+data "azurerm_resource_graph_query" "test" {
+  query            = "ResourceContainers | where id =~ '${azurerm_resource_group.test.id}' | project id, location, tags"
+  subscription_ids = [data.azurerm_client_config.current.subscription_id]
+}
+`, r.template(data))
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
@@ -100,6 +101,17 @@ func resourceGroupTemplateDeploymentResource() *pluginsdk.Resource {
 
 			"tags": tags.Schema(),
 
+			"what_if_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+			},
+
+			"what_if_error_on_delete": {
+				Type:         pluginsdk.TypeBool,
+				Optional:     true,
+				RequiredWith: []string{"what_if_enabled"},
+			},
+
 			// Computed
 			"output_content": {
 				Type:     pluginsdk.TypeString,
@@ -107,32 +119,38 @@ func resourceGroupTemplateDeploymentResource() *pluginsdk.Resource {
 				// NOTE:  outputs can be strings, ints, objects etc - whilst using a nested object was considered
 				// parsing the JSON using `jsondecode` allows the users to interact with/map objects as required
 			},
-		},
 
-		// this is needed to fix https://github.com/hashicorp/terraform-provider-azurerm/issues/12828
-		// On a change to `template_content` or `parameters_content`, we'll set `output_content` to empty
-		// The adverse effect of this is that any change to `template_content` will also cause any resource referencing `output_content` to update
-		CustomizeDiff: func(ctx context.Context, d *pluginsdk.ResourceDiff, i interface{}) error {
-			if d.HasChange("template_content") {
-				o, n := d.GetChange("template_content")
+			"what_if_changes": templateDeploymentWhatIfChangesSchema(),
+		},
 
-				// the json has to be normalized and then compared against to see if a change has occurred
-				if !strings.EqualFold(o.(string), utils.NormalizeJson(n)) {
-					return d.SetNewComputed("output_content")
+		CustomizeDiff: pluginsdk.CustomDiffInSequence(
+			// this is needed to fix https://github.com/hashicorp/terraform-provider-azurerm/issues/12828
+			// On a change to `template_content` or `parameters_content`, we'll set `output_content` to empty
+			// The adverse effect of this is that any change to `template_content` will also cause any resource referencing `output_content` to update
+			func(ctx context.Context, d *pluginsdk.ResourceDiff, i interface{}) error {
+				if d.HasChange("template_content") {
+					o, n := d.GetChange("template_content")
+
+					// the json has to be normalized and then compared against to see if a change has occurred
+					if !strings.EqualFold(o.(string), utils.NormalizeJson(n)) {
+						return d.SetNewComputed("output_content")
+					}
 				}
-			}
 
-			if d.HasChange("parameters_content") {
-				o, n := d.GetChange("parameters_content")
+				if d.HasChange("parameters_content") {
+					o, n := d.GetChange("parameters_content")
 
-				// the json has to be normalized and then compared against to see if a change has occurred
-				if !strings.EqualFold(o.(string), utils.NormalizeJson(n)) {
-					return d.SetNewComputed("output_content")
+					// the json has to be normalized and then compared against to see if a change has occurred
+					if !strings.EqualFold(o.(string), utils.NormalizeJson(n)) {
+						return d.SetNewComputed("output_content")
+					}
 				}
-			}
 
-			return nil
-		},
+				return nil
+			},
+
+			resourceGroupTemplateDeploymentWhatIfCustomizeDiff,
+		),
 	}
 }
 
@@ -359,6 +377,9 @@ func resourceGroupTemplateDeploymentResourceRead(d *pluginsdk.ResourceData, meta
 	}
 	d.Set("template_content", flattenedTemplate)
 
+	// the predicted changes only apply to the plan in which the What-If operation was run
+	d.Set("what_if_changes", []interface{}{})
+
 	return tags.FlattenAndSet(d, resp.Tags)
 }
 
@@ -433,3 +454,42 @@ func validateResourceGroupTemplateDeployment(ctx context.Context, id parse.Resou
 
 	return nil
 }
+
+func resourceGroupTemplateDeploymentWhatIfCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	fieldsToCompare := append([]string{"resource_group_name", "deployment_mode"}, templateDeploymentWhatIfFields...)
+	changes, err := templateDeploymentWhatIfChanges(ctx, d, meta, fieldsToCompare, func(ctx context.Context, client *resources.DeploymentsClient, d *pluginsdk.ResourceDiff, properties resources.DeploymentWhatIfProperties) (*resources.WhatIfOperationResult, error) {
+		properties.Mode = resources.DeploymentMode(d.Get("deployment_mode").(string))
+		parameters := resources.DeploymentWhatIf{
+			Properties: &properties,
+		}
+
+		future, err := client.WhatIf(ctx, d.Get("resource_group_name").(string), d.Get("name").(string), parameters)
+		if err != nil {
+			// the Resource Group may be created within the same apply
+			if response.WasNotFound(future.Response()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("requesting What-If: %+v", err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return nil, fmt.Errorf("waiting for What-If: %+v", err)
+		}
+		result, err := future.Result(*client)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving What-If result: %+v", err)
+		}
+
+		return &result, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if d.Get("what_if_error_on_delete").(bool) && d.Get("deployment_mode").(string) == string(resources.DeploymentModeComplete) {
+		if deletions := templateDeploymentWhatIfDeletions(changes); len(deletions) > 0 {
+			return fmt.Errorf("the What-If operation predicts that deploying Template Deployment %q (Resource Group %q) in `Complete` mode would delete the following resources, which isn't allowed since `what_if_error_on_delete` is enabled:\n\n* %s", d.Get("name").(string), d.Get("resource_group_name").(string), strings.Join(deletions, "\n* "))
+		}
+	}
+
+	return nil
+}
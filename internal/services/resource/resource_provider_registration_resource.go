@@ -373,13 +373,13 @@ func (r ResourceProviderRegistrationResource) checkIfManagedByTerraform(name str
 		return nil
 	}
 
-	for resourceProvider := range resourceproviders.Required() {
+	for resourceProvider := range account.RegisteredResourceProviders {
 		if resourceProvider == name {
 			fmtStr := `The Resource Provider %q is automatically registered by Terraform.
 
 To manage this Resource Provider Registration with Terraform you need to opt-out
-of Automatic Resource Provider Registration (by setting 'skip_provider_registration'
-to 'true' in the Provider block) to avoid conflicting with Terraform.`
+of Automatic Resource Provider Registration (by setting 'resource_provider_registrations'
+to 'none' in the Provider block) to avoid conflicting with Terraform.`
 			return fmt.Errorf(fmtStr, name)
 		}
 	}
@@ -91,6 +91,11 @@ func subscriptionTemplateDeploymentResource() *pluginsdk.Resource {
 
 			"tags": tags.Schema(),
 
+			"what_if_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+			},
+
 			// Computed
 			"output_content": {
 				Type:     pluginsdk.TypeString,
@@ -98,7 +103,11 @@ func subscriptionTemplateDeploymentResource() *pluginsdk.Resource {
 				// NOTE:  outputs can be strings, ints, objects etc - whilst using a nested object was considered
 				// parsing the JSON using `jsondecode` allows the users to interact with/map objects as required
 			},
+
+			"what_if_changes": templateDeploymentWhatIfChangesSchema(),
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(subscriptionTemplateDeploymentWhatIfCustomizeDiff),
 	}
 }
 
@@ -322,6 +331,9 @@ func subscriptionTemplateDeploymentResourceRead(d *pluginsdk.ResourceData, meta
 	}
 	d.Set("template_content", flattenedTemplate)
 
+	// the predicted changes only apply to the plan in which the What-If operation was run
+	d.Set("what_if_changes", []interface{}{})
+
 	return tags.FlattenAndSet(d, resp.Tags)
 }
 
@@ -374,3 +386,29 @@ func validateSubscriptionTemplateDeployment(ctx context.Context, id parse.Subscr
 
 	return nil
 }
+
+func subscriptionTemplateDeploymentWhatIfCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	fieldsToCompare := append([]string{"location"}, templateDeploymentWhatIfFields...)
+	_, err := templateDeploymentWhatIfChanges(ctx, d, meta, fieldsToCompare, func(ctx context.Context, client *resources.DeploymentsClient, d *pluginsdk.ResourceDiff, properties resources.DeploymentWhatIfProperties) (*resources.WhatIfOperationResult, error) {
+		parameters := resources.DeploymentWhatIf{
+			Location:   utils.String(location.Normalize(d.Get("location").(string))),
+			Properties: &properties,
+		}
+
+		future, err := client.WhatIfAtSubscriptionScope(ctx, d.Get("name").(string), parameters)
+		if err != nil {
+			return nil, fmt.Errorf("requesting What-If: %+v", err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return nil, fmt.Errorf("waiting for What-If: %+v", err)
+		}
+		result, err := future.Result(*client)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving What-If result: %+v", err)
+		}
+
+		return &result, nil
+	})
+
+	return err
+}
@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// the What-If operation is run during the plan, where there's no configurable timeout available
+const templateDeploymentWhatIfTimeout = 30 * time.Minute
+
+// templateDeploymentWhatIfFields are the fields which cause the What-If operation to be run when changed
+var templateDeploymentWhatIfFields = []string{
+	"template_content",
+	"template_spec_version_id",
+	"parameters_content",
+}
+
+// templateDeploymentWhatIfFunc runs the What-If operation for a Template Deployment at the relevant scope, returning
+// nil when the changes can't be predicted yet (for example when the Resource Group doesn't exist yet).
+type templateDeploymentWhatIfFunc func(ctx context.Context, client *resources.DeploymentsClient, d *pluginsdk.ResourceDiff, properties resources.DeploymentWhatIfProperties) (*resources.WhatIfOperationResult, error)
+
+func templateDeploymentWhatIfChangesSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Computed: true,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"resource_id": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"change_type": {
+					Type:     pluginsdk.TypeString,
+					Computed: true,
+				},
+
+				"changed_properties": {
+					Type:     pluginsdk.TypeList,
+					Computed: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// templateDeploymentWhatIfChanges runs the What-If operation when `what_if_enabled` is set and the Template Deployment
+// is being created or any of the specified fields are changing, exposing the predicted changes within the plan as
+// `what_if_changes`. The predicted changes are returned so that the caller can validate them, or nil if the What-If
+// operation wasn't run.
+func templateDeploymentWhatIfChanges(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}, fieldsToCompare []string, whatIf templateDeploymentWhatIfFunc) ([]resources.WhatIfChange, error) {
+	// clear any changes predicted during a previous plan when the What-If operation isn't run
+	if !d.Get("what_if_enabled").(bool) {
+		return nil, d.SetNew("what_if_changes", []interface{}{})
+	}
+
+	if d.Id() != "" && !d.HasChanges(fieldsToCompare...) {
+		return nil, d.SetNew("what_if_changes", []interface{}{})
+	}
+
+	for _, key := range append([]string{"name"}, fieldsToCompare...) {
+		if !d.NewValueKnown(key) {
+			log.Printf("[DEBUG] Skipping the What-If operation for the Template Deployment since %q isn't known yet", key)
+			return nil, d.SetNewComputed("what_if_changes")
+		}
+	}
+
+	client, ok := meta.(*clients.Client)
+	if !ok {
+		return nil, nil
+	}
+
+	properties := resources.DeploymentWhatIfProperties{
+		Mode: resources.DeploymentModeIncremental,
+		WhatIfSettings: &resources.DeploymentWhatIfSettings{
+			ResultFormat: resources.WhatIfResultFormatFullResourcePayloads,
+		},
+	}
+
+	if v, ok := d.GetOk("template_spec_version_id"); ok && v.(string) != "" {
+		properties.TemplateLink = &resources.TemplateLink{
+			ID: utils.String(v.(string)),
+		}
+	} else {
+		template, err := expandTemplateDeploymentBody(d.Get("template_content").(string))
+		if err != nil {
+			return nil, fmt.Errorf("expanding `template_content`: %+v", err)
+		}
+		properties.Template = template
+	}
+
+	if v, ok := d.GetOk("parameters_content"); ok && v.(string) != "" {
+		parameters, err := expandTemplateDeploymentBody(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("expanding `parameters_content`: %+v", err)
+		}
+		properties.Parameters = parameters
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, templateDeploymentWhatIfTimeout)
+	defer cancel()
+
+	log.Printf("[DEBUG] Running the What-If operation for the Template Deployment %q..", d.Get("name").(string))
+	result, err := whatIf(ctx, client.Resource.DeploymentsClient, d, properties)
+	if err != nil {
+		return nil, fmt.Errorf("running the What-If operation for the Template Deployment %q: %+v", d.Get("name").(string), err)
+	}
+	if result == nil {
+		log.Printf("[DEBUG] The changes for the Template Deployment %q can't be predicted yet", d.Get("name").(string))
+		return nil, d.SetNewComputed("what_if_changes")
+	}
+	if result.Error != nil {
+		if result.Error.Message != nil {
+			return nil, fmt.Errorf("running the What-If operation for the Template Deployment %q: %s", d.Get("name").(string), *result.Error.Message)
+		}
+		return nil, fmt.Errorf("running the What-If operation for the Template Deployment %q: %+v", d.Get("name").(string), *result.Error)
+	}
+
+	changes := make([]resources.WhatIfChange, 0)
+	if result.WhatIfOperationProperties != nil && result.WhatIfOperationProperties.Changes != nil {
+		for _, change := range *result.WhatIfOperationProperties.Changes {
+			if change.ChangeType == resources.ChangeTypeNoChange || change.ChangeType == resources.ChangeTypeIgnore {
+				continue
+			}
+			changes = append(changes, change)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return strings.ToLower(utils.NormalizeNilableString(changes[i].ResourceID)) < strings.ToLower(utils.NormalizeNilableString(changes[j].ResourceID))
+	})
+
+	return changes, d.SetNew("what_if_changes", flattenTemplateDeploymentWhatIfChanges(changes))
+}
+
+func flattenTemplateDeploymentWhatIfChanges(input []resources.WhatIfChange) []interface{} {
+	output := make([]interface{}, 0)
+
+	for _, change := range input {
+		changedProperties := make([]interface{}, 0)
+		if change.Delta != nil {
+			for _, path := range flattenTemplateDeploymentWhatIfPropertyPaths(*change.Delta, "") {
+				changedProperties = append(changedProperties, path)
+			}
+		}
+
+		output = append(output, map[string]interface{}{
+			"resource_id":        utils.NormalizeNilableString(change.ResourceID),
+			"change_type":        string(change.ChangeType),
+			"changed_properties": changedProperties,
+		})
+	}
+
+	return output
+}
+
+// flattenTemplateDeploymentWhatIfPropertyPaths returns the full path to each of the changed properties, since the
+// paths of nested property changes are relative to their parent.
+func flattenTemplateDeploymentWhatIfPropertyPaths(input []resources.WhatIfPropertyChange, parent string) []string {
+	output := make([]string, 0)
+
+	for _, change := range input {
+		path := utils.NormalizeNilableString(change.Path)
+		if parent != "" {
+			path = fmt.Sprintf("%s.%s", parent, path)
+		}
+
+		if change.Children != nil && len(*change.Children) > 0 {
+			output = append(output, flattenTemplateDeploymentWhatIfPropertyPaths(*change.Children, path)...)
+			continue
+		}
+
+		output = append(output, path)
+	}
+
+	return output
+}
+
+// templateDeploymentWhatIfDeletions returns the IDs of the resources which the What-If operation predicts will be deleted.
+func templateDeploymentWhatIfDeletions(input []resources.WhatIfChange) []string {
+	output := make([]string, 0)
+
+	for _, change := range input {
+		if change.ChangeType == resources.ChangeTypeDelete {
+			output = append(output, utils.NormalizeNilableString(change.ResourceID))
+		}
+	}
+
+	return output
+}
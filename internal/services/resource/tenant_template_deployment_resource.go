@@ -91,6 +91,11 @@ func tenantTemplateDeploymentResource() *pluginsdk.Resource {
 
 			"tags": tags.Schema(),
 
+			"what_if_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+			},
+
 			// Computed
 			"output_content": {
 				Type:     pluginsdk.TypeString,
@@ -98,7 +103,11 @@ func tenantTemplateDeploymentResource() *pluginsdk.Resource {
 				// NOTE:  outputs can be strings, ints, objects etc - whilst using a nested object was considered
 				// parsing the JSON using `jsondecode` allows the users to interact with/map objects as required
 			},
+
+			"what_if_changes": templateDeploymentWhatIfChangesSchema(),
 		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(tenantTemplateDeploymentWhatIfCustomizeDiff),
 	}
 }
 
@@ -323,6 +332,9 @@ func tenantTemplateDeploymentResourceRead(d *pluginsdk.ResourceData, meta interf
 	}
 	d.Set("template_content", flattenedTemplate)
 
+	// the predicted changes only apply to the plan in which the What-If operation was run
+	d.Set("what_if_changes", []interface{}{})
+
 	return tags.FlattenAndSet(d, resp.Tags)
 }
 
@@ -375,3 +387,29 @@ func validateTenantTemplateDeployment(ctx context.Context, id parse.TenantTempla
 
 	return nil
 }
+
+func tenantTemplateDeploymentWhatIfCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	fieldsToCompare := append([]string{"location"}, templateDeploymentWhatIfFields...)
+	_, err := templateDeploymentWhatIfChanges(ctx, d, meta, fieldsToCompare, func(ctx context.Context, client *resources.DeploymentsClient, d *pluginsdk.ResourceDiff, properties resources.DeploymentWhatIfProperties) (*resources.WhatIfOperationResult, error) {
+		parameters := resources.ScopedDeploymentWhatIf{
+			Location:   utils.String(location.Normalize(d.Get("location").(string))),
+			Properties: &properties,
+		}
+
+		future, err := client.WhatIfAtTenantScope(ctx, d.Get("name").(string), parameters)
+		if err != nil {
+			return nil, fmt.Errorf("requesting What-If: %+v", err)
+		}
+		if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+			return nil, fmt.Errorf("waiting for What-If: %+v", err)
+		}
+		result, err := future.Result(*client)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving What-If result: %+v", err)
+		}
+
+		return &result, nil
+	})
+
+	return err
+}
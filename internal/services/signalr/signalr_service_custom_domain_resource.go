@@ -89,7 +89,9 @@ func (r CustomDomainSignalrServiceResource) Create() sdk.ResourceFunc {
 
 			id := signalr.NewCustomDomainID(signalRServiceId.SubscriptionId, signalRServiceId.ResourceGroupName, signalRServiceId.SignalRName, metadata.ResourceData.Get("name").(string))
 
-			locks.ByID(signalRServiceId.ID())
+			if err := locks.ByID(ctx, signalRServiceId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(signalRServiceId.ID())
 
 			if _, err := signalr.ParseCustomCertificateIDInsensitively(customDomainSignalrServiceModel.SignalrCustomCertificateId); err != nil {
@@ -204,7 +206,9 @@ func (r CustomDomainSignalrServiceResource) Delete() sdk.ResourceFunc {
 
 			signalrId := signalr.NewSignalRID(id.SubscriptionId, id.ResourceGroupName, id.SignalRName)
 
-			locks.ByID(signalrId.ID())
+			if err := locks.ByID(ctx, signalrId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(signalrId.ID())
 
 			if err := client.CustomDomainsDeleteThenPoll(ctx, *id); err != nil {
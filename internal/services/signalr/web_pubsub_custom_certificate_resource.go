@@ -101,7 +101,9 @@ func (r CustomCertWebPubsubResource) Create() sdk.ResourceFunc {
 
 			id := webpubsub.NewCustomCertificateID(webPubsubId.SubscriptionId, webPubsubId.ResourceGroupName, webPubsubId.WebPubSubName, customCertWebPubsub.Name)
 
-			locks.ByID(webPubsubId.ID())
+			if err := locks.ByID(ctx, webPubsubId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(webPubsubId.ID())
 
 			existing, err := client.CustomCertificatesGet(ctx, id)
@@ -206,7 +208,9 @@ func (r CustomCertWebPubsubResource) Delete() sdk.ResourceFunc {
 
 			webPubsubId := webpubsub.NewWebPubSubID(id.SubscriptionId, id.ResourceGroupName, id.WebPubSubName)
 
-			locks.ByID(webPubsubId.ID())
+			if err := locks.ByID(ctx, webPubsubId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(webPubsubId.ID())
 
 			if _, err := client.CustomCertificatesDelete(ctx, *id); err != nil {
@@ -89,7 +89,9 @@ func (r CustomDomainWebPubsubResource) Create() sdk.ResourceFunc {
 
 			id := webpubsub.NewCustomDomainID(webPubsubId.SubscriptionId, webPubsubId.ResourceGroupName, webPubsubId.WebPubSubName, metadata.ResourceData.Get("name").(string))
 
-			locks.ByID(webPubsubId.ID())
+			if err := locks.ByID(ctx, webPubsubId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(webPubsubId.ID())
 
 			if _, err := webpubsub.ParseCustomCertificateIDInsensitively(customDomainWebPubsubModel.WebPubsubCustomCertificateId); err != nil {
@@ -205,7 +207,9 @@ func (r CustomDomainWebPubsubResource) Delete() sdk.ResourceFunc {
 
 			webPubsubId := webpubsub.NewWebPubSubID(id.SubscriptionId, id.ResourceGroupName, id.WebPubSubName)
 
-			locks.ByID(webPubsubId.ID())
+			if err := locks.ByID(ctx, webPubsubId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(webPubsubId.ID())
 
 			if err := client.CustomDomainsDeleteThenPoll(ctx, *id); err != nil {
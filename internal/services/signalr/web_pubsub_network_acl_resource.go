@@ -140,7 +140,9 @@ func resourceWebPubsubNetworkACLCreateUpdate(d *pluginsdk.ResourceData, meta int
 		return fmt.Errorf("checking for present of existing %q: %+v", id, err)
 	}
 
-	locks.ByName(id.WebPubSubName, "azurerm_web_pubsub")
+	if err := locks.ByName(ctx, id.WebPubSubName, "azurerm_web_pubsub"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.WebPubSubName, "azurerm_web_pubsub")
 
 	if d.IsNewResource() {
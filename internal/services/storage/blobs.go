@@ -165,6 +165,9 @@ func (sbu BlobUpload) uploadBlockBlob(ctx context.Context) error {
 		ContentType: pointer.To(sbu.ContentType),
 		MetaData:    sbu.MetaData,
 	}
+	if sbu.CacheControl != "" {
+		input.CacheControl = pointer.To(sbu.CacheControl)
+	}
 	if sbu.ContentMD5 != "" {
 		input.ContentMD5 = pointer.To(sbu.ContentMD5)
 	}
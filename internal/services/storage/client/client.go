@@ -39,6 +39,9 @@ type Client struct {
 	FileServicesClient *storage.FileServicesClient
 
 	authConfigForAzureAD *auth.Credentials
+
+	// emulator (when set) is the Storage Emulator which the Data Plane Clients should use for the emulated Storage Account
+	emulator *common.StorageEmulator
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
@@ -112,9 +115,11 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		StorageDomainSuffix: *storageSuffix,
 	}
 
-	if o.StorageUseAzureAD {
+	// the Storage Emulator only supports authenticating using the Account Key
+	if o.StorageUseAzureAD && o.StorageEmulator == nil {
 		client.authConfigForAzureAD = o.AuthConfig
 	}
+	client.emulator = o.StorageEmulator
 
 	return &client, nil
 }
@@ -78,7 +78,7 @@ func (c Client) AccountsDataPlaneClient(ctx context.Context, account accountDeta
 	const clientName = "Blob Storage Accounts"
 	operation.sharedKeyAuthenticationType = auth.SharedKey
 
-	baseUri, err := account.DataPlaneEndpoint(EndpointTypeBlob)
+	baseUri, err := account.dataPlaneRequestEndpoint(EndpointTypeBlob)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +100,7 @@ func (c Client) BlobsDataPlaneClient(ctx context.Context, account accountDetails
 	const clientName = "Blob Storage Blobs"
 	operation.sharedKeyAuthenticationType = auth.SharedKey
 
-	baseUri, err := account.DataPlaneEndpoint(EndpointTypeBlob)
+	baseUri, err := account.dataPlaneRequestEndpoint(EndpointTypeBlob)
 	if err != nil {
 		return nil, err
 	}
@@ -122,7 +122,7 @@ func (c Client) ContainersDataPlaneClient(ctx context.Context, account accountDe
 	const clientName = "Blob Storage Containers"
 	operation.sharedKeyAuthenticationType = auth.SharedKey
 
-	baseUri, err := account.DataPlaneEndpoint(EndpointTypeBlob)
+	baseUri, err := account.dataPlaneRequestEndpoint(EndpointTypeBlob)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +144,7 @@ func (c Client) DataLakeFilesystemsDataPlaneClient(ctx context.Context, account
 	const clientName = "Data Lake Gen2 Filesystems"
 	operation.sharedKeyAuthenticationType = auth.SharedKey
 
-	baseUri, err := account.DataPlaneEndpoint(EndpointTypeDfs)
+	baseUri, err := account.dataPlaneRequestEndpoint(EndpointTypeDfs)
 	if err != nil {
 		return nil, err
 	}
@@ -166,7 +166,7 @@ func (c Client) DataLakePathsDataPlaneClient(ctx context.Context, account accoun
 	const clientName = "Data Lake Gen2 Paths"
 	operation.sharedKeyAuthenticationType = auth.SharedKey
 
-	baseUri, err := account.DataPlaneEndpoint(EndpointTypeDfs)
+	baseUri, err := account.dataPlaneRequestEndpoint(EndpointTypeDfs)
 	if err != nil {
 		return nil, err
 	}
@@ -188,7 +188,7 @@ func (c Client) FileShareDirectoriesDataPlaneClient(ctx context.Context, account
 	const clientName = "File Storage Share Directories"
 	operation.sharedKeyAuthenticationType = auth.SharedKey
 
-	baseUri, err := account.DataPlaneEndpoint(EndpointTypeFile)
+	baseUri, err := account.dataPlaneRequestEndpoint(EndpointTypeFile)
 	if err != nil {
 		return nil, err
 	}
@@ -210,7 +210,7 @@ func (c Client) FileShareFilesDataPlaneClient(ctx context.Context, account accou
 	const clientName = "File Storage Share Files"
 	operation.sharedKeyAuthenticationType = auth.SharedKey
 
-	baseUri, err := account.DataPlaneEndpoint(EndpointTypeFile)
+	baseUri, err := account.dataPlaneRequestEndpoint(EndpointTypeFile)
 	if err != nil {
 		return nil, err
 	}
@@ -232,7 +232,7 @@ func (c Client) FileSharesDataPlaneClient(ctx context.Context, account accountDe
 	const clientName = "File Storage Shares"
 	operation.sharedKeyAuthenticationType = auth.SharedKey
 
-	baseUri, err := account.DataPlaneEndpoint(EndpointTypeFile)
+	baseUri, err := account.dataPlaneRequestEndpoint(EndpointTypeFile)
 	if err != nil {
 		return nil, err
 	}
@@ -254,7 +254,7 @@ func (c Client) QueuesDataPlaneClient(ctx context.Context, account accountDetail
 	const clientName = "File Storage Queue Queues"
 	operation.sharedKeyAuthenticationType = auth.SharedKey
 
-	baseUri, err := account.DataPlaneEndpoint(EndpointTypeQueue)
+	baseUri, err := account.dataPlaneRequestEndpoint(EndpointTypeQueue)
 	if err != nil {
 		return nil, err
 	}
@@ -276,7 +276,7 @@ func (c Client) TableEntityDataPlaneClient(ctx context.Context, account accountD
 	const clientName = "Table Storage Share Entities"
 	operation.sharedKeyAuthenticationType = auth.SharedKeyTable
 
-	baseUri, err := account.DataPlaneEndpoint(EndpointTypeTable)
+	baseUri, err := account.dataPlaneRequestEndpoint(EndpointTypeTable)
 	if err != nil {
 		return nil, err
 	}
@@ -298,7 +298,7 @@ func (c Client) TablesDataPlaneClient(ctx context.Context, account accountDetail
 	const clientName = "Table Storage Share Tables"
 	operation.sharedKeyAuthenticationType = auth.SharedKeyTable
 
-	baseUri, err := account.DataPlaneEndpoint(EndpointTypeTable)
+	baseUri, err := account.dataPlaneRequestEndpoint(EndpointTypeTable)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/storage/2023-01-01/storageaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+// EmulatedStorageAccountResourceGroupName is the name of the (placeholder) Resource Group used to build the
+// Resource Manager IDs for the Storage Account within a Storage Emulator, since this doesn't exist in Azure
+const EmulatedStorageAccountResourceGroupName = "storage-emulator"
+
+// emulatedAccountDetails returns the details for the Storage Account within a Storage Emulator, for which only the
+// Blob, Queue and Table services are supported.
+//
+// The Primary Endpoints use the same format as Azure, so that the Resource IDs (and the parsing of these) are
+// unchanged - however requests to the Data Plane API are sent to the path-style endpoints of the Storage Emulator.
+func emulatedAccountDetails(subscriptionId, accountName, domainSuffix string, emulator common.StorageEmulator) (*accountDetails, error) {
+	if !strings.EqualFold(accountName, emulator.AccountName) {
+		return nil, fmt.Errorf("the Storage Account %q is not available within the Storage Emulator, only the Storage Account %q can be used", accountName, emulator.AccountName)
+	}
+
+	out := accountDetails{
+		Kind:             storageaccounts.KindStorageVTwo,
+		StorageAccountId: commonids.NewStorageAccountID(subscriptionId, EmulatedStorageAccountResourceGroupName, emulator.AccountName),
+
+		accountKey: pointer.To(emulator.AccountKey),
+
+		primaryBlobEndpoint:  pointer.To(fmt.Sprintf("https://%s.blob.%s", emulator.AccountName, domainSuffix)),
+		primaryQueueEndpoint: pointer.To(fmt.Sprintf("https://%s.queue.%s", emulator.AccountName, domainSuffix)),
+		primaryTableEndpoint: pointer.To(fmt.Sprintf("https://%s.table.%s", emulator.AccountName, domainSuffix)),
+
+		dataPlaneEndpointOverrides: map[EndpointType]string{
+			EndpointTypeBlob:  emulatedEndpoint(emulator.BlobEndpoint, emulator.AccountName),
+			EndpointTypeQueue: emulatedEndpoint(emulator.QueueEndpoint, emulator.AccountName),
+			EndpointTypeTable: emulatedEndpoint(emulator.TableEndpoint, emulator.AccountName),
+		},
+	}
+
+	return &out, nil
+}
+
+// emulatedEndpoint returns the path-style endpoint for the Storage Account within a Storage Emulator
+// e.g. `http://127.0.0.1:10000/devstoreaccount1`
+func emulatedEndpoint(endpoint, accountName string) string {
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(endpoint, "/"), accountName)
+}
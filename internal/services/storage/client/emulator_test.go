@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/accounts"
+)
+
+func TestEmulatedAccountDetails(t *testing.T) {
+	emulator := common.StorageEmulator{
+		AccountName:   common.StorageEmulatorDefaultAccountName,
+		AccountKey:    common.StorageEmulatorDefaultAccountKey,
+		BlobEndpoint:  "http://127.0.0.1:10000/",
+		QueueEndpoint: "http://127.0.0.1:10001",
+		TableEndpoint: "http://azurite:10002",
+	}
+
+	if _, err := emulatedAccountDetails("00000000-0000-0000-0000-000000000000", "other", "core.windows.net", emulator); err == nil {
+		t.Fatalf("expected an error for a Storage Account which isn't within the Storage Emulator but didn't get one")
+	}
+
+	account, err := emulatedAccountDetails("00000000-0000-0000-0000-000000000000", "devstoreaccount1", "core.windows.net", emulator)
+	if err != nil {
+		t.Fatalf("retrieving the emulated Storage Account: %+v", err)
+	}
+
+	if *account.accountKey != common.StorageEmulatorDefaultAccountKey {
+		t.Fatalf("expected the Account Key to be the Storage Emulator Account Key but got %q", *account.accountKey)
+	}
+
+	testCases := []struct {
+		EndpointType    EndpointType
+		PrimaryEndpoint string
+		RequestEndpoint string
+		ExpectedToFail  bool
+	}{
+		{
+			EndpointType:    EndpointTypeBlob,
+			PrimaryEndpoint: "https://devstoreaccount1.blob.core.windows.net",
+			RequestEndpoint: "http://127.0.0.1:10000/devstoreaccount1",
+		},
+		{
+			EndpointType:    EndpointTypeQueue,
+			PrimaryEndpoint: "https://devstoreaccount1.queue.core.windows.net",
+			RequestEndpoint: "http://127.0.0.1:10001/devstoreaccount1",
+		},
+		{
+			EndpointType:    EndpointTypeTable,
+			PrimaryEndpoint: "https://devstoreaccount1.table.core.windows.net",
+			RequestEndpoint: "http://azurite:10002/devstoreaccount1",
+		},
+		{
+			EndpointType:   EndpointTypeDfs,
+			ExpectedToFail: true,
+		},
+		{
+			EndpointType:   EndpointTypeFile,
+			ExpectedToFail: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("[DEBUG] Testing %q", testCase.EndpointType)
+
+		requestEndpoint, err := account.dataPlaneRequestEndpoint(testCase.EndpointType)
+		if testCase.ExpectedToFail {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("retrieving the request endpoint: %+v", err)
+		}
+		if *requestEndpoint != testCase.RequestEndpoint {
+			t.Fatalf("expected the request endpoint to be %q but got %q", testCase.RequestEndpoint, *requestEndpoint)
+		}
+
+		primaryEndpoint, err := account.DataPlaneEndpoint(testCase.EndpointType)
+		if err != nil {
+			t.Fatalf("retrieving the primary endpoint: %+v", err)
+		}
+		if *primaryEndpoint != testCase.PrimaryEndpoint {
+			t.Fatalf("expected the primary endpoint to be %q but got %q", testCase.PrimaryEndpoint, *primaryEndpoint)
+		}
+
+		// the Resource IDs are built from the Primary Endpoint, so this must remain parseable
+		if _, err := accounts.ParseAccountID(*primaryEndpoint, "core.windows.net"); err != nil {
+			t.Fatalf("parsing the primary endpoint: %+v", err)
+		}
+	}
+}
@@ -58,6 +58,11 @@ type accountDetails struct {
 	// primaryTableEndpoint is the Primary Table Endpoint for the Data Plane API for this Storage Account
 	// e.g. `https://{account}.table.core.windows.net`
 	primaryTableEndpoint *string
+
+	// dataPlaneEndpointOverrides are the endpoints which requests to the Data Plane API are sent to in place of the
+	// Primary Endpoints (which continue to be used to build the Resource IDs) for this Storage Account
+	// e.g. `http://127.0.0.1:10000/{account}` when using a Storage Emulator
+	dataPlaneEndpointOverrides map[EndpointType]string
 }
 
 func (ad *accountDetails) AccountKey(ctx context.Context, client Client) (*string, error) {
@@ -127,6 +132,16 @@ func (ad *accountDetails) DataPlaneEndpoint(endpointType EndpointType) (*string,
 	return baseUri, nil
 }
 
+// dataPlaneRequestEndpoint returns the endpoint which requests to the Data Plane API should be sent to, which is the
+// Primary Endpoint unless this has been overridden for this Storage Account
+func (ad *accountDetails) dataPlaneRequestEndpoint(endpointType EndpointType) (*string, error) {
+	if endpoint, ok := ad.dataPlaneEndpointOverrides[endpointType]; ok {
+		return pointer.To(endpoint), nil
+	}
+
+	return ad.DataPlaneEndpoint(endpointType)
+}
+
 func (c Client) AddToCache(accountId commonids.StorageAccountId, account storageaccounts.StorageAccount) error {
 	accountsLock.Lock()
 	defer accountsLock.Unlock()
@@ -147,6 +162,11 @@ func (c Client) RemoveAccountFromCache(accountId commonids.StorageAccountId) {
 }
 
 func (c Client) FindAccount(ctx context.Context, subscriptionIdRaw, accountName string) (*accountDetails, error) {
+	// the Storage Account within a Storage Emulator doesn't exist in Azure, so can't be looked up
+	if c.emulator != nil {
+		return emulatedAccountDetails(subscriptionIdRaw, accountName, c.StorageDomainSuffix, *c.emulator)
+	}
+
 	accountsLock.Lock()
 	defer accountsLock.Unlock()
 
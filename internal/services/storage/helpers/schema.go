@@ -23,7 +23,7 @@ func ImporterValidatingStorageResourceId(validateFunc StorageIDValidationFunc) *
 // ImporterValidatingStorageResourceIdThen is similar to pluginsdk.ImporterValidatingResourceIdThen whilst additionally passing in
 // the storage domain suffix for the current environment/cloud, so that the domain component of the ID can be validated.
 func ImporterValidatingStorageResourceIdThen(validateFunc StorageIDValidationFunc, thenFunc pluginsdk.ImporterFunc) *schema.ResourceImporter {
-	return &schema.ResourceImporter{
+	importer := &schema.ResourceImporter{
 		StateContext: func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
 			storageDomainSuffix := meta.(*clients.Client).Storage.StorageDomainSuffix
 			log.Printf("[DEBUG] Importing Storage Resource - parsing %q using domain suffix %q", d.Id(), storageDomainSuffix)
@@ -38,4 +38,7 @@ func ImporterValidatingStorageResourceIdThen(validateFunc StorageIDValidationFun
 			return []*pluginsdk.ResourceData{d}, nil
 		},
 	}
+	pluginsdk.RecordImporterIDValidationFunc(importer, validateFunc)
+
+	return importer
 }
@@ -148,7 +148,9 @@ func resourceStorageAccountNetworkRulesCreateUpdate(d *pluginsdk.ResourceData, m
 		resourceGroup = parsedStorageAccountId.ResourceGroupName
 	}
 
-	locks.ByName(storageAccountName, storageAccountResourceName)
+	if err := locks.ByName(ctx, storageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(storageAccountName, storageAccountResourceName)
 
 	id := commonids.NewStorageAccountID(subscriptionId, resourceGroup, storageAccountName)
@@ -248,7 +250,9 @@ func resourceStorageAccountNetworkRulesDelete(d *pluginsdk.ResourceData, meta in
 		return err
 	}
 
-	locks.ByName(parsedStorageAccountNetworkRuleId.StorageAccountName, storageAccountResourceName)
+	if err := locks.ByName(ctx, parsedStorageAccountNetworkRuleId.StorageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(parsedStorageAccountNetworkRuleId.StorageAccountName, storageAccountResourceName)
 
 	storageAccount, err := client.GetProperties(ctx, parsedStorageAccountNetworkRuleId.ResourceGroupName, parsedStorageAccountNetworkRuleId.StorageAccountName, "")
@@ -0,0 +1,668 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/helpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/accounts"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
+)
+
+func resourceStorageBlobDirectory() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceStorageBlobDirectoryCreate,
+		Read:   resourceStorageBlobDirectoryRead,
+		Update: resourceStorageBlobDirectoryUpdate,
+		Delete: resourceStorageBlobDirectoryDelete,
+
+		Importer: helpers.ImporterValidatingStorageResourceIdThen(func(id, storageDomainSuffix string) error {
+			_, _, err := parseStorageBlobDirectoryId(id, storageDomainSuffix)
+			return err
+		}, func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+			id, prefix, err := parseStorageBlobDirectoryId(d.Id(), meta.(*clients.Client).Storage.StorageDomainSuffix)
+			if err != nil {
+				return nil, err
+			}
+
+			d.Set("storage_account_name", id.AccountId.AccountName)
+			d.Set("storage_container_name", id.ContainerName)
+			d.Set("prefix", prefix)
+			d.Set("delete_orphaned_blobs", true)
+			d.Set("default_content_type", "application/octet-stream")
+			d.Set("parallelism", 8)
+
+			return []*pluginsdk.ResourceData{d}, nil
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"storage_container_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageContainerName,
+			},
+
+			"prefix": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^[^/](.*[^/])?$`),
+					"`prefix` must not start or end with a `/`",
+				),
+			},
+
+			"source_directory": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"default_content_type": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Default:      "application/octet-stream",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"content_types": {
+				Type:         pluginsdk.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateStorageBlobDirectoryContentTypes,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"cache_control": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"pattern": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validateStorageBlobDirectoryPattern,
+						},
+
+						"value": {
+							Type:         pluginsdk.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+
+			"delete_orphaned_blobs": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"parallelism": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      8,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"manifest": {
+				Type:     pluginsdk.TypeMap,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(resourceStorageBlobDirectoryCustomizeDiff),
+	}
+}
+
+func resourceStorageBlobDirectoryCustomizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("source_directory") {
+		return d.SetNewComputed("manifest")
+	}
+
+	manifest, err := storageBlobDirectoryManifest(d.Get("source_directory").(string))
+	if err != nil {
+		return err
+	}
+
+	existing := d.Get("manifest").(map[string]interface{})
+	changed := len(existing) != len(manifest)
+	for k, v := range manifest {
+		if existing[k] != v {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	return d.SetNew("manifest", manifest)
+}
+
+func resourceStorageBlobDirectoryCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+
+	account, err := storageClient.FindAccount(ctx, subscriptionId, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Storage Account %q for Container %q: %v", accountName, containerName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("locating Storage Account %q", accountName)
+	}
+
+	accountId := accounts.AccountId{
+		AccountName:   accountName,
+		DomainSuffix:  storageClient.StorageDomainSuffix,
+		SubDomainType: accounts.BlobSubDomainType,
+	}
+
+	id := containers.NewContainerID(accountId, containerName).ID()
+	if prefix := d.Get("prefix").(string); prefix != "" {
+		id = fmt.Sprintf("%s/%s", id, prefix)
+	}
+
+	d.SetId(id)
+
+	return resourceStorageBlobDirectoryUpdate(d, meta)
+}
+
+func resourceStorageBlobDirectoryUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+	prefix := d.Get("prefix").(string)
+	sourceDirectory := d.Get("source_directory").(string)
+
+	account, err := storageClient.FindAccount(ctx, subscriptionId, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Storage Account %q for Container %q: %v", accountName, containerName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("locating Storage Account %q", accountName)
+	}
+
+	blobsClient, err := storageClient.BlobsDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return fmt.Errorf("building Blobs Client: %v", err)
+	}
+
+	// the directory is hashed again rather than relying on the plan, since the files may have changed in the meantime
+	manifest, err := storageBlobDirectoryManifest(sourceDirectory)
+	if err != nil {
+		return err
+	}
+
+	oldManifestRaw, _ := d.GetChange("manifest")
+	oldManifest := oldManifestRaw.(map[string]interface{})
+
+	// the properties of every blob need to be updated when the rules used to determine them change, which is
+	// done by uploading the files again since `cache_control` is only applied to files which are uploaded
+	uploadAll := d.HasChanges("default_content_type", "content_types", "cache_control")
+
+	toUpload := make([]string, 0)
+	for name, hash := range manifest {
+		if uploadAll || oldManifest[name] != hash {
+			toUpload = append(toUpload, name)
+		}
+	}
+	sort.Strings(toUpload)
+
+	toDelete := make([]string, 0)
+	if d.Get("delete_orphaned_blobs").(bool) {
+		for name := range oldManifest {
+			if _, ok := manifest[name]; !ok {
+				toDelete = append(toDelete, name)
+			}
+		}
+	}
+	sort.Strings(toDelete)
+
+	contentTypes := make(map[string]string)
+	for k, v := range d.Get("content_types").(map[string]interface{}) {
+		contentTypes[strings.ToLower(k)] = v.(string)
+	}
+	rules := expandStorageBlobDirectoryCacheControlRules(d.Get("cache_control").([]interface{}))
+	defaultContentType := d.Get("default_content_type").(string)
+
+	log.Printf("[DEBUG] Uploading %d and deleting %d blobs for %s..", len(toUpload), len(toDelete), d.Id())
+	uploads := make([]BlobUpload, 0)
+	for _, name := range toUpload {
+		contentMD5, err := convertHexToBase64Encoding(manifest[name].(string))
+		if err != nil {
+			return fmt.Errorf("base64 encoding the MD5 hash of %q: %v", name, err)
+		}
+
+		uploads = append(uploads, BlobUpload{
+			AccountName:   accountName,
+			BlobName:      storageBlobDirectoryBlobName(prefix, name),
+			ContainerName: containerName,
+			Client:        blobsClient,
+
+			BlobType:     "Block",
+			CacheControl: storageBlobDirectoryCacheControl(name, rules),
+			ContentType:  storageBlobDirectoryContentType(name, contentTypes, defaultContentType),
+			ContentMD5:   contentMD5,
+			Source:       filepath.Join(sourceDirectory, filepath.FromSlash(name)),
+		})
+	}
+
+	if err := storageBlobDirectoryUpload(ctx, uploads, d.Get("parallelism").(int)); err != nil {
+		return fmt.Errorf("uploading %s: %v", d.Id(), err)
+	}
+
+	for _, name := range toDelete {
+		blobName := storageBlobDirectoryBlobName(prefix, name)
+		input := blobs.DeleteInput{
+			DeleteSnapshots: true,
+		}
+		if resp, err := blobsClient.Delete(ctx, containerName, blobName, input); err != nil && !response.WasNotFound(resp.HttpResponse) {
+			return fmt.Errorf("deleting orphaned Blob %q (Container %q / Account %q): %v", blobName, containerName, accountName, err)
+		}
+	}
+	log.Printf("[DEBUG] Synchronised %s.", d.Id())
+
+	if err := d.Set("manifest", manifest); err != nil {
+		return fmt.Errorf("setting `manifest`: %v", err)
+	}
+
+	return resourceStorageBlobDirectoryRead(d, meta)
+}
+
+func resourceStorageBlobDirectoryRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+	prefix := d.Get("prefix").(string)
+
+	account, err := storageClient.FindAccount(ctx, subscriptionId, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Storage Account %q for Container %q: %v", accountName, containerName, err)
+	}
+	if account == nil {
+		log.Printf("[DEBUG] Unable to locate Account %q for Container %q - assuming removed & removing from state!", accountName, containerName)
+		d.SetId("")
+		return nil
+	}
+
+	containersClient, err := storageClient.ContainersDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return fmt.Errorf("building Containers Client: %v", err)
+	}
+
+	listPrefix := storageBlobDirectoryBlobName(prefix, "")
+	blobList, err := containersClient.ListBlobs(ctx, containerName, listPrefix)
+	if err != nil {
+		return fmt.Errorf("listing Blobs within %s: %v", d.Id(), err)
+	}
+	if blobList == nil {
+		log.Printf("[INFO] Container %q was not found in Account %q - assuming removed & removing from state...", containerName, accountName)
+		d.SetId("")
+		return nil
+	}
+
+	// only the blobs uploaded by this resource are tracked, since these are the only blobs which are removed (either
+	// when the file is removed from the source directory, or when this resource is deleted) - any blob which has
+	// since been removed is omitted, so that it's uploaded again
+	uploaded := d.Get("manifest").(map[string]interface{})
+
+	manifest := make(map[string]interface{})
+	for _, blob := range *blobList {
+		name := strings.TrimPrefix(blob.Name, listPrefix)
+		if _, ok := uploaded[name]; !ok {
+			continue
+		}
+
+		// blobs without a Content MD5 are tracked with an empty hash, so that they're uploaded again
+		contentMD5 := ""
+		if blob.Properties != nil && blob.Properties.ContentMD5 != nil && *blob.Properties.ContentMD5 != "" {
+			contentMD5, err = convertBase64ToHexEncoding(*blob.Properties.ContentMD5)
+			if err != nil {
+				return fmt.Errorf("converting the Content MD5 of Blob %q to hex encoding: %v", blob.Name, err)
+			}
+		}
+		manifest[name] = contentMD5
+	}
+
+	if err := d.Set("manifest", manifest); err != nil {
+		return fmt.Errorf("setting `manifest`: %v", err)
+	}
+
+	return nil
+}
+
+func resourceStorageBlobDirectoryDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+	prefix := d.Get("prefix").(string)
+
+	account, err := storageClient.FindAccount(ctx, subscriptionId, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Storage Account %q for Container %q: %v", accountName, containerName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("locating Storage Account %q", accountName)
+	}
+
+	blobsClient, err := storageClient.BlobsDataPlaneClient(ctx, *account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return fmt.Errorf("building Blobs Client: %v", err)
+	}
+
+	for name := range d.Get("manifest").(map[string]interface{}) {
+		blobName := storageBlobDirectoryBlobName(prefix, name)
+		input := blobs.DeleteInput{
+			DeleteSnapshots: true,
+		}
+		if resp, err := blobsClient.Delete(ctx, containerName, blobName, input); err != nil && !response.WasNotFound(resp.HttpResponse) {
+			return fmt.Errorf("deleting Blob %q (Container %q / Account %q): %v", blobName, containerName, accountName, err)
+		}
+	}
+
+	return nil
+}
+
+// parseStorageBlobDirectoryId parses the ID of a Storage Blob Directory, which is the URL of the Container optionally
+// followed by the prefix, for example `https://account1.blob.core.windows.net/container1/site`.
+func parseStorageBlobDirectoryId(input, domainSuffix string) (*containers.ContainerId, string, error) {
+	uri, err := url.Parse(input)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing %q as a URL: %v", input, err)
+	}
+
+	segments := strings.SplitN(strings.TrimPrefix(uri.Path, "/"), "/", 2)
+	if segments[0] == "" {
+		return nil, "", fmt.Errorf("parsing %q: expected the path to contain the name of a container", input)
+	}
+
+	prefix := ""
+	if len(segments) == 2 {
+		prefix = segments[1]
+		if prefix == "" || strings.HasSuffix(prefix, "/") {
+			return nil, "", fmt.Errorf("parsing %q: the prefix must not end with a `/`", input)
+		}
+	}
+
+	containerUri := *uri
+	containerUri.Path = fmt.Sprintf("/%s", segments[0])
+	id, err := containers.ParseContainerID(containerUri.String(), domainSuffix)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing %q: %v", input, err)
+	}
+
+	return id, prefix, nil
+}
+
+// storageBlobDirectoryManifest returns the hex encoded MD5 hash of each file within the directory, keyed by the
+// path of the file relative to the directory using `/` as the separator.
+func storageBlobDirectoryManifest(directory string) (map[string]interface{}, error) {
+	info, err := os.Stat(directory)
+	if err != nil {
+		return nil, fmt.Errorf("reading `source_directory` %q: %v", directory, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("`source_directory` %q is not a directory", directory)
+	}
+
+	manifest := make(map[string]interface{})
+	err = filepath.WalkDir(directory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		// symlinks are followed, but anything else which isn't a regular file (e.g. sockets) is skipped
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(directory, filePath)
+		if err != nil {
+			return err
+		}
+
+		hash, err := storageBlobDirectoryFileMD5(filePath)
+		if err != nil {
+			return err
+		}
+		manifest[filepath.ToSlash(relativePath)] = hash
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hashing the files within `source_directory` %q: %v", directory, err)
+	}
+
+	return manifest, nil
+}
+
+func storageBlobDirectoryFileMD5(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := md5.New() // nolint: gosec
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("hashing %q: %v", filePath, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func storageBlobDirectoryBlobName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return fmt.Sprintf("%s/%s", prefix, name)
+}
+
+// storageBlobDirectoryContentType determines the Content Type for a file from its extension, using the user specified
+// overrides before falling back to the well known types.
+func storageBlobDirectoryContentType(name string, overrides map[string]string, defaultContentType string) string {
+	extension := strings.ToLower(path.Ext(name))
+	if extension == "" {
+		return defaultContentType
+	}
+
+	if v, ok := overrides[extension]; ok {
+		return v
+	}
+
+	if v := mime.TypeByExtension(extension); v != "" {
+		return v
+	}
+
+	return defaultContentType
+}
+
+type storageBlobDirectoryCacheControlRule struct {
+	pattern string
+	value   string
+}
+
+func expandStorageBlobDirectoryCacheControlRules(input []interface{}) []storageBlobDirectoryCacheControlRule {
+	output := make([]storageBlobDirectoryCacheControlRule, 0)
+
+	for _, item := range input {
+		v := item.(map[string]interface{})
+		output = append(output, storageBlobDirectoryCacheControlRule{
+			pattern: v["pattern"].(string),
+			value:   v["value"].(string),
+		})
+	}
+
+	return output
+}
+
+// storageBlobDirectoryCacheControl returns the Cache Control value of the first rule matching the file. Patterns
+// containing a `/` are matched against the relative path of the file, otherwise they're matched against its name.
+func storageBlobDirectoryCacheControl(name string, rules []storageBlobDirectoryCacheControlRule) string {
+	for _, rule := range rules {
+		target := name
+		if !strings.Contains(rule.pattern, "/") {
+			target = path.Base(name)
+		}
+
+		if matched, _ := path.Match(rule.pattern, target); matched {
+			return rule.value
+		}
+	}
+
+	return ""
+}
+
+type storageBlobDirectoryUploadContext struct {
+	uploads chan BlobUpload
+	errors  chan error
+	wg      *sync.WaitGroup
+}
+
+func storageBlobDirectoryUpload(ctx context.Context, uploads []BlobUpload, parallelism int) error {
+	if len(uploads) == 0 {
+		return nil
+	}
+
+	queue := make(chan BlobUpload, len(uploads))
+	errors := make(chan error, len(uploads))
+	wg := &sync.WaitGroup{}
+	wg.Add(len(uploads))
+
+	for _, upload := range uploads {
+		queue <- upload
+	}
+	close(queue)
+
+	for i := 0; i < parallelism; i++ {
+		go storageBlobDirectoryUploadWorker(ctx, storageBlobDirectoryUploadContext{
+			uploads: queue,
+			errors:  errors,
+			wg:      wg,
+		})
+	}
+
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return <-errors
+	}
+
+	return nil
+}
+
+func storageBlobDirectoryUploadWorker(ctx context.Context, uploadCtx storageBlobDirectoryUploadContext) {
+	for upload := range uploadCtx.uploads {
+		if err := upload.Create(ctx); err != nil {
+			uploadCtx.errors <- fmt.Errorf("uploading %q to Blob %q: %v", upload.Source, upload.BlobName, err)
+		}
+		uploadCtx.wg.Done()
+	}
+}
+
+func validateStorageBlobDirectoryPattern(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	if v == "" {
+		errors = append(errors, fmt.Errorf("%q must not be empty", k))
+		return
+	}
+
+	if _, err := path.Match(v, ""); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid pattern: %v", k, err))
+	}
+
+	return
+}
+
+func validateStorageBlobDirectoryContentTypes(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(map[string]interface{})
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be a map", k))
+		return
+	}
+
+	for extension := range v {
+		if !strings.HasPrefix(extension, ".") || len(extension) < 2 {
+			errors = append(errors, fmt.Errorf("the keys of %q must be file extensions starting with a `.` but got %q", k, extension))
+		}
+	}
+
+	return
+}
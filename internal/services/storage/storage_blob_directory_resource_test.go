@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+)
+
+type StorageBlobDirectoryResource struct{}
+
+func TestAccStorageBlobDirectory_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := StorageBlobDirectoryResource{}
+	directory := r.sourceDirectory(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, directory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("manifest.%").HasValue("3"),
+				check.That(data.ResourceName).Key("manifest.index.html").HasValue("c83301425b2ad1d496473a5ff3d9ecca"),
+				data.CheckWithClient(r.blobHasProperties("site/css/main.css", mime.TypeByExtension(".css"), "")),
+			),
+		},
+		data.ImportStep("source_directory", "manifest"),
+	})
+}
+
+func TestAccStorageBlobDirectory_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := StorageBlobDirectoryResource{}
+	directory := r.sourceDirectory(t)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, directory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("manifest.%").HasValue("3"),
+			),
+		},
+		{
+			PreConfig: func() {
+				r.writeFile(t, directory, "index.html", "<html><body></body></html>")
+				r.writeFile(t, directory, "js/app.js", "console.log('hello')")
+				if err := os.Remove(filepath.Join(directory, "robots.txt")); err != nil {
+					t.Fatalf("removing robots.txt: %+v", err)
+				}
+			},
+			Config: r.cacheControl(data, directory),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("manifest.%").HasValue("3"),
+				check.That(data.ResourceName).Key("manifest.js/app.js").Exists(),
+				check.That(data.ResourceName).Key("manifest.robots.txt").DoesNotExist(),
+				data.CheckWithClient(r.blobHasProperties("site/index.html", mime.TypeByExtension(".html"), "no-cache")),
+				data.CheckWithClient(r.blobHasProperties("site/js/app.js", mime.TypeByExtension(".js"), "max-age=3600")),
+				data.CheckWithClient(r.blobDoesNotExist("site/robots.txt")),
+			),
+		},
+	})
+}
+
+func (r StorageBlobDirectoryResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	accountName := state.Attributes["storage_account_name"]
+	containerName := state.Attributes["storage_container_name"]
+	prefix := state.Attributes["prefix"]
+
+	account, err := client.Storage.FindAccount(ctx, client.Account.SubscriptionId, accountName)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("unable to locate Account %q for Container %q", accountName, containerName)
+	}
+	containersClient, err := client.Storage.ContainersDataPlaneClient(ctx, *account, client.Storage.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return nil, fmt.Errorf("building Containers Client: %+v", err)
+	}
+	blobList, err := containersClient.ListBlobs(ctx, containerName, fmt.Sprintf("%s/", prefix))
+	if err != nil {
+		return nil, fmt.Errorf("listing Blobs within Container %q (Account %q): %+v", containerName, accountName, err)
+	}
+	return utils.Bool(blobList != nil && len(*blobList) > 0), nil
+}
+
+func (r StorageBlobDirectoryResource) blobHasProperties(blobName, contentType, cacheControl string) acceptance.ClientCheckFunc {
+	return func(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
+		blobsClient, err := r.blobsClient(ctx, client, state)
+		if err != nil {
+			return err
+		}
+
+		props, err := blobsClient.GetProperties(ctx, state.Attributes["storage_container_name"], blobName, blobs.GetPropertiesInput{})
+		if err != nil {
+			return fmt.Errorf("retrieving properties for Blob %q: %+v", blobName, err)
+		}
+		if props.ContentType != contentType {
+			return fmt.Errorf("expected the Content Type of Blob %q to be %q but got %q", blobName, contentType, props.ContentType)
+		}
+		if props.CacheControl != cacheControl {
+			return fmt.Errorf("expected the Cache Control of Blob %q to be %q but got %q", blobName, cacheControl, props.CacheControl)
+		}
+
+		return nil
+	}
+}
+
+func (r StorageBlobDirectoryResource) blobDoesNotExist(blobName string) acceptance.ClientCheckFunc {
+	return func(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
+		blobsClient, err := r.blobsClient(ctx, client, state)
+		if err != nil {
+			return err
+		}
+
+		props, err := blobsClient.GetProperties(ctx, state.Attributes["storage_container_name"], blobName, blobs.GetPropertiesInput{})
+		if err == nil {
+			return fmt.Errorf("expected Blob %q to have been deleted", blobName)
+		}
+		if !response.WasNotFound(props.HttpResponse) {
+			return fmt.Errorf("retrieving properties for Blob %q: %+v", blobName, err)
+		}
+
+		return nil
+	}
+}
+
+func (StorageBlobDirectoryResource) blobsClient(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*blobs.Client, error) {
+	accountName := state.Attributes["storage_account_name"]
+	account, err := client.Storage.FindAccount(ctx, client.Account.SubscriptionId, accountName)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("unable to locate Account %q", accountName)
+	}
+	return client.Storage.BlobsDataPlaneClient(ctx, *account, client.Storage.DataPlaneOperationSupportingAnyAuthMethod())
+}
+
+func (r StorageBlobDirectoryResource) sourceDirectory(t *testing.T) string {
+	directory := t.TempDir()
+	r.writeFile(t, directory, "index.html", "<html></html>")
+	r.writeFile(t, directory, "css/main.css", "body {}")
+	r.writeFile(t, directory, "robots.txt", "User-agent: *")
+	return directory
+}
+
+func (StorageBlobDirectoryResource) writeFile(t *testing.T, directory, name, content string) {
+	filePath := filepath.Join(directory, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		t.Fatalf("creating directory for %q: %+v", name, err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing %q: %+v", name, err)
+	}
+}
+
+func (StorageBlobDirectoryResource) basic(data acceptance.TestData, directory string) string {
+	template := StorageBlobResource{}.template(data, "private")
+	return fmt.Sprintf(`
+%s
+
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_storage_blob_directory" "test" {
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  prefix                 = "site"
+  source_directory       = %q
+}
+`, template, filepath.ToSlash(directory))
+}
+
+func (StorageBlobDirectoryResource) cacheControl(data acceptance.TestData, directory string) string {
+	template := StorageBlobResource{}.template(data, "private")
+	return fmt.Sprintf(`
+%s
+
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_storage_blob_directory" "test" {
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  prefix                 = "site"
+  source_directory       = %q
+  parallelism            = 2
+
+  cache_control {
+    pattern = "*.html"
+    value   = "no-cache"
+  }
+
+  cache_control {
+    pattern = "js/*"
+    value   = "max-age=3600"
+  }
+}
+`, template, filepath.ToSlash(directory))
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"mime"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestStorageBlobDirectoryManifest(t *testing.T) {
+	directory := t.TempDir()
+	files := map[string]string{
+		"index.html":         "<html></html>",
+		"css/main.css":       "body {}",
+		"assets/img/empty.x": "",
+	}
+	for name, content := range files {
+		filePath := filepath.Join(directory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+			t.Fatalf("creating directory for %q: %+v", name, err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
+			t.Fatalf("writing %q: %+v", name, err)
+		}
+	}
+
+	actual, err := storageBlobDirectoryManifest(directory)
+	if err != nil {
+		t.Fatalf("building manifest: %+v", err)
+	}
+
+	expected := map[string]interface{}{
+		"index.html":         "c83301425b2ad1d496473a5ff3d9ecca",
+		"css/main.css":       "fcdce6b6d6e2175f6406869882f6f1ce",
+		"assets/img/empty.x": "d41d8cd98f00b204e9800998ecf8427e",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+
+	if _, err := storageBlobDirectoryManifest(filepath.Join(directory, "index.html")); err == nil {
+		t.Fatalf("expected an error when the source directory is a file")
+	}
+}
+
+func TestStorageBlobDirectoryContentType(t *testing.T) {
+	overrides := map[string]string{
+		".md": "text/markdown",
+	}
+
+	cases := []struct {
+		Name     string
+		Expected string
+	}{
+		{
+			Name:     "README.md",
+			Expected: "text/markdown",
+		},
+		{
+			Name:     "css/main.CSS",
+			Expected: mime.TypeByExtension(".css"),
+		},
+		{
+			Name:     "LICENSE",
+			Expected: "application/octet-stream",
+		},
+		{
+			Name:     "data.unknownextension",
+			Expected: "application/octet-stream",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Name)
+
+		actual := storageBlobDirectoryContentType(tc.Name, overrides, "application/octet-stream")
+		if actual != tc.Expected {
+			t.Fatalf("expected %q but got %q", tc.Expected, actual)
+		}
+	}
+}
+
+func TestStorageBlobDirectoryCacheControl(t *testing.T) {
+	rules := []storageBlobDirectoryCacheControlRule{
+		{
+			pattern: "*.html",
+			value:   "no-cache",
+		},
+		{
+			pattern: "assets/*",
+			value:   "max-age=31536000",
+		},
+	}
+
+	cases := []struct {
+		Name     string
+		Expected string
+	}{
+		{
+			Name:     "index.html",
+			Expected: "no-cache",
+		},
+		{
+			Name:     "docs/guide.html",
+			Expected: "no-cache",
+		},
+		{
+			Name:     "assets/app.js",
+			Expected: "max-age=31536000",
+		},
+		{
+			Name:     "assets/img/logo.png",
+			Expected: "",
+		},
+		{
+			Name:     "robots.txt",
+			Expected: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Name)
+
+		actual := storageBlobDirectoryCacheControl(tc.Name, rules)
+		if actual != tc.Expected {
+			t.Fatalf("expected %q but got %q", tc.Expected, actual)
+		}
+	}
+}
+
+func TestParseStorageBlobDirectoryId(t *testing.T) {
+	cases := []struct {
+		Input         string
+		Valid         bool
+		ContainerName string
+		Prefix        string
+	}{
+		{
+			Input: "",
+			Valid: false,
+		},
+		{
+			Input: "https://account1.blob.core.windows.net",
+			Valid: false,
+		},
+		{
+			Input: "https://account1.queue.core.windows.net/container1",
+			Valid: false,
+		},
+		{
+			Input:         "https://account1.blob.core.windows.net/container1",
+			Valid:         true,
+			ContainerName: "container1",
+		},
+		{
+			Input:         "https://account1.blob.core.windows.net/container1/site",
+			Valid:         true,
+			ContainerName: "container1",
+			Prefix:        "site",
+		},
+		{
+			Input:         "https://account1.blob.core.windows.net/container1/site/v1",
+			Valid:         true,
+			ContainerName: "container1",
+			Prefix:        "site/v1",
+		},
+		{
+			Input: "https://account1.blob.core.windows.net/container1/site/",
+			Valid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Input)
+
+		id, prefix, err := parseStorageBlobDirectoryId(tc.Input, "core.windows.net")
+		if err != nil {
+			if tc.Valid {
+				t.Fatalf("expected %q to be valid but got: %+v", tc.Input, err)
+			}
+			continue
+		}
+		if !tc.Valid {
+			t.Fatalf("expected %q to be invalid", tc.Input)
+		}
+
+		if id.ContainerName != tc.ContainerName {
+			t.Fatalf("expected the container name to be %q but got %q", tc.ContainerName, id.ContainerName)
+		}
+		if prefix != tc.Prefix {
+			t.Fatalf("expected the prefix to be %q but got %q", tc.Prefix, prefix)
+		}
+	}
+}
@@ -100,7 +100,9 @@ func (r JobScheduleResource) Create() sdk.ResourceFunc {
 			// This is a virtual resource so the last segment is hardcoded
 			id := parse.NewStreamingJobScheduleID(streamAnalyticsId.SubscriptionId, streamAnalyticsId.ResourceGroupName, streamAnalyticsId.StreamingJobName, "default")
 
-			locks.ByID(id.ID())
+			if err := locks.ByID(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			var opts streamingjobs.GetOperationOptions
@@ -148,7 +148,9 @@ func resourceSubscriptionCreate(d *pluginsdk.ResourceData, meta interface{}) err
 		return tf.ImportAsExistsError("azurerm_subscription", id.ID())
 	}
 
-	locks.ByName(aliasName, SubscriptionResourceName)
+	if err := locks.ByName(ctx, aliasName, SubscriptionResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(aliasName, SubscriptionResourceName)
 
 	workload := subscriptionAlias.WorkloadProduction
@@ -170,7 +172,9 @@ func resourceSubscriptionCreate(d *pluginsdk.ResourceData, meta interface{}) err
 		subscriptionId = subscriptionIdRaw.(string)
 		subscriptionResourceId := commonids.NewSubscriptionID(subscriptionId)
 
-		locks.ByID(subscriptionId)
+		if err := locks.ByID(ctx, subscriptionId); err != nil {
+			return err
+		}
 		defer locks.UnlockByID(subscriptionId)
 
 		// Terraform assumes a 1:1 mapping between a Subscription and an Alias - first check if there's any existing aliases
@@ -264,7 +268,9 @@ func resourceSubscriptionUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(id.AliasName, SubscriptionResourceName)
+	if err := locks.ByName(ctx, id.AliasName, SubscriptionResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AliasName, SubscriptionResourceName)
 	resp, err := aliasClient.AliasGet(ctx, *id)
 	if err != nil || resp.Model == nil || resp.Model.Properties == nil || resp.Model.Properties.SubscriptionId == nil {
@@ -274,7 +280,9 @@ func resourceSubscriptionUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 	subscriptionId := commonids.NewSubscriptionID(*resp.Model.Properties.SubscriptionId)
 
 	if d.HasChange("subscription_name") {
-		locks.ByID(subscriptionId.ID())
+		if err := locks.ByID(ctx, subscriptionId.ID()); err != nil {
+			return err
+		}
 		defer locks.UnlockByID(subscriptionId.ID())
 
 		displayName := subscriptionAlias.SubscriptionName{
@@ -376,7 +384,9 @@ func resourceSubscriptionDelete(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(id.AliasName, SubscriptionResourceName)
+	if err := locks.ByName(ctx, id.AliasName, SubscriptionResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AliasName, SubscriptionResourceName)
 
 	// Get subscription details for later
@@ -388,7 +398,9 @@ func resourceSubscriptionDelete(d *pluginsdk.ResourceData, meta interface{}) err
 	if subscriptionIdRaw := alias.Model.Properties.SubscriptionId; subscriptionIdRaw != nil {
 		subscriptionId = *subscriptionIdRaw
 	}
-	locks.ByID(subscriptionId)
+	if err := locks.ByID(ctx, subscriptionId); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(subscriptionId)
 
 	subscriptionResourceId := commonids.NewSubscriptionID(subscriptionId)
@@ -98,7 +98,9 @@ func resourceSynapseWorkspaceKeysCreateUpdate(d *pluginsdk.ResourceData, meta in
 		actualKeyName = keyName
 	}
 
-	locks.ByName(workspaceId.Name, "azurerm_synapse_workspace")
+	if err := locks.ByName(ctx, workspaceId.Name, "azurerm_synapse_workspace"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(workspaceId.Name, "azurerm_synapse_workspace")
 	keyresult, err := client.CreateOrUpdate(ctx, workspaceId.ResourceGroup, workspaceId.Name, actualKeyName, synapseKey)
 	if err != nil {
@@ -28,3 +28,27 @@ func Filter(tagsMap *map[string]string, tagNames ...string) *map[string]string {
 
 	return &tagsRet
 }
+
+// FilterByPrefix returns the tags whose keys don't start with (case insensitive) any of the specified prefixes
+func FilterByPrefix(tagsMap *map[string]string, prefixes ...string) *map[string]string {
+	if len(prefixes) == 0 || tagsMap == nil {
+		return tagsMap
+	}
+
+	tagsRet := make(map[string]string)
+	for k, v := range *tagsMap {
+		filtered := false
+		for _, prefix := range prefixes {
+			if len(prefix) > 0 && strings.HasPrefix(strings.ToLower(k), strings.ToLower(prefix)) {
+				filtered = true
+				break
+			}
+		}
+
+		if !filtered {
+			tagsRet[k] = v
+		}
+	}
+
+	return &tagsRet
+}
@@ -25,3 +25,21 @@ func TestFilter(t *testing.T) {
 		t.Fatalf("Expected %v in filtered tag map, got %v", valueData[1], (*filtered)["key2"])
 	}
 }
+
+func TestFilterByPrefix(t *testing.T) {
+	testData := map[string]string{
+		"hidden-title": "value1",
+		"Hidden-Link":  "value2",
+		"environment":  "value3",
+	}
+
+	filtered := FilterByPrefix(&testData, "hidden-", "")
+
+	if len(*filtered) != 1 {
+		t.Fatalf("Expected 1 result in filtered tag map, got %d", len(*filtered))
+	}
+
+	if (*filtered)["environment"] != "value3" {
+		t.Fatalf("Expected %v in filtered tag map, got %v", "value3", (*filtered)["environment"])
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"sort"
+	"strings"
+)
+
+// ProviderTags defines the Tags configured within the Provider block, which
+// are applied to every Resource which supports Tags
+type ProviderTags struct {
+	// DefaultTags are merged into the Tags of every Resource, with the Tags
+	// defined on the Resource taking precedence
+	DefaultTags map[string]string
+
+	// IgnoreKeys are Tag keys which are filtered out when reading a Resource
+	IgnoreKeys []string
+
+	// IgnoreKeyPrefixes are Tag key prefixes which are filtered out when reading a Resource
+	IgnoreKeyPrefixes []string
+}
+
+// IsEmpty returns whether any default or ignored Tags have been configured
+func (p ProviderTags) IsEmpty() bool {
+	return len(p.DefaultTags) == 0 && len(p.IgnoreKeys) == 0 && len(p.IgnoreKeyPrefixes) == 0
+}
+
+// Merge returns the effective set of Tags for a Resource, being the DefaultTags
+// overridden by the Tags defined on the Resource. Ignored Tags aren't removed, since
+// these only apply to the Tags read from Azure - see FilterRemote
+func (p ProviderTags) Merge(resourceTags map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{})
+	for k, v := range p.DefaultTags {
+		output[k] = v
+	}
+	for k, v := range resourceTags {
+		// tag keys are case-insensitive, so a tag on the resource replaces any default with the same key
+		for existing := range output {
+			if strings.EqualFold(existing, k) {
+				delete(output, existing)
+			}
+		}
+		output[k] = v
+	}
+
+	return output
+}
+
+// Filter returns the Tags which are not ignored by either IgnoreKeys or IgnoreKeyPrefixes
+func (p ProviderTags) Filter(tagsMap map[string]interface{}) map[string]interface{} {
+	input := make(map[string]string)
+	for k, v := range tagsMap {
+		input[k] = v.(string)
+	}
+
+	filtered := FilterByPrefix(Filter(&input, p.IgnoreKeys...), p.IgnoreKeyPrefixes...)
+
+	output := make(map[string]interface{})
+	for k, v := range *filtered {
+		output[k] = v
+	}
+	return output
+}
+
+// FilterRemote returns the Tags read from Azure which aren't ignored by either IgnoreKeys or IgnoreKeyPrefixes,
+// retaining any Tags within configuredTags (being the Tags defined on the Resource or the DefaultTags) since
+// these were explicitly set rather than being added outside of Terraform
+func (p ProviderTags) FilterRemote(remoteTags map[string]interface{}, configuredTags map[string]interface{}) map[string]interface{} {
+	filtered := p.Filter(remoteTags)
+
+	for k, v := range remoteTags {
+		if containsKey(configuredTags, k) {
+			filtered[k] = v
+		}
+	}
+
+	return filtered
+}
+
+// ResourceTags returns the subset of tagsAll which should be set into the `tags` field
+// of a Resource - that is, removing any DefaultTags which haven't been explicitly
+// defined on the Resource via configuredTags
+func (p ProviderTags) ResourceTags(tagsAll map[string]interface{}, configuredTags map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{})
+	for k, v := range tagsAll {
+		if p.isDefaultTag(k, v) && !containsKey(configuredTags, k) {
+			continue
+		}
+		output[k] = v
+	}
+	return output
+}
+
+// RemovedKeys returns the keys present in oldTags which aren't present in newTags and
+// aren't ignored, in a consistent order
+func (p ProviderTags) RemovedKeys(oldTags map[string]interface{}, newTags map[string]interface{}) []string {
+	removed := make([]string, 0)
+	for k := range p.Filter(oldTags) {
+		if !containsKey(newTags, k) {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+func (p ProviderTags) isDefaultTag(key string, value interface{}) bool {
+	for k, v := range p.DefaultTags {
+		if strings.EqualFold(k, key) && v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsKey(input map[string]interface{}, key string) bool {
+	for k := range input {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
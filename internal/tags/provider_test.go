@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProviderTagsMerge(t *testing.T) {
+	testData := []struct {
+		Name         string
+		ProviderTags ProviderTags
+		Input        map[string]interface{}
+		Expected     map[string]interface{}
+	}{
+		{
+			Name:         "No Provider Tags",
+			ProviderTags: ProviderTags{},
+			Input: map[string]interface{}{
+				"environment": "production",
+			},
+			Expected: map[string]interface{}{
+				"environment": "production",
+			},
+		},
+		{
+			Name: "Default Tags",
+			ProviderTags: ProviderTags{
+				DefaultTags: map[string]string{
+					"cost-center": "12345",
+				},
+			},
+			Input: map[string]interface{}{
+				"environment": "production",
+			},
+			Expected: map[string]interface{}{
+				"cost-center": "12345",
+				"environment": "production",
+			},
+		},
+		{
+			Name: "Resource Tags Override Default Tags",
+			ProviderTags: ProviderTags{
+				DefaultTags: map[string]string{
+					"Owner":       "platform",
+					"cost-center": "12345",
+				},
+			},
+			Input: map[string]interface{}{
+				"owner": "networking",
+			},
+			Expected: map[string]interface{}{
+				"cost-center": "12345",
+				"owner":       "networking",
+			},
+		},
+		{
+			Name: "Ignored Tags are Retained",
+			ProviderTags: ProviderTags{
+				DefaultTags: map[string]string{
+					"cost-center": "12345",
+				},
+				IgnoreKeys:        []string{"Cost-Center"},
+				IgnoreKeyPrefixes: []string{"hidden-"},
+			},
+			Input: map[string]interface{}{
+				"environment":  "production",
+				"hidden-title": "example",
+			},
+			Expected: map[string]interface{}{
+				"cost-center":  "12345",
+				"environment":  "production",
+				"hidden-title": "example",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := v.ProviderTags.Merge(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestProviderTagsFilterRemote(t *testing.T) {
+	providerTags := ProviderTags{
+		IgnoreKeys:        []string{"Created-By"},
+		IgnoreKeyPrefixes: []string{"hidden-"},
+	}
+
+	testData := []struct {
+		Name       string
+		Remote     map[string]interface{}
+		Configured map[string]interface{}
+		Expected   map[string]interface{}
+	}{
+		{
+			Name: "No Ignored Tags",
+			Remote: map[string]interface{}{
+				"environment": "production",
+			},
+			Configured: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"environment": "production",
+			},
+		},
+		{
+			Name: "Ignored Tags added outside of Terraform are Removed",
+			Remote: map[string]interface{}{
+				"created-by":   "policy",
+				"environment":  "production",
+				"hidden-title": "example",
+			},
+			Configured: map[string]interface{}{
+				"environment": "production",
+			},
+			Expected: map[string]interface{}{
+				"environment": "production",
+			},
+		},
+		{
+			Name: "Ignored Tags which are Configured are Retained",
+			Remote: map[string]interface{}{
+				"created-by":   "policy",
+				"environment":  "production",
+				"hidden-title": "example",
+			},
+			Configured: map[string]interface{}{
+				"Hidden-Title": "example",
+			},
+			Expected: map[string]interface{}{
+				"environment":  "production",
+				"hidden-title": "example",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := providerTags.FilterRemote(v.Remote, v.Configured)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestProviderTagsResourceTags(t *testing.T) {
+	providerTags := ProviderTags{
+		DefaultTags: map[string]string{
+			"cost-center": "12345",
+			"owner":       "platform",
+		},
+	}
+
+	testData := []struct {
+		Name       string
+		TagsAll    map[string]interface{}
+		Configured map[string]interface{}
+		Expected   map[string]interface{}
+	}{
+		{
+			Name: "Default Tags are Removed",
+			TagsAll: map[string]interface{}{
+				"cost-center": "12345",
+				"owner":       "platform",
+				"environment": "production",
+			},
+			Configured: map[string]interface{}{
+				"environment": "production",
+			},
+			Expected: map[string]interface{}{
+				"environment": "production",
+			},
+		},
+		{
+			Name: "Default Tags defined on the Resource are Retained",
+			TagsAll: map[string]interface{}{
+				"cost-center": "12345",
+				"owner":       "platform",
+			},
+			Configured: map[string]interface{}{
+				"owner": "platform",
+			},
+			Expected: map[string]interface{}{
+				"owner": "platform",
+			},
+		},
+		{
+			Name: "Default Tags with a different value are Retained",
+			TagsAll: map[string]interface{}{
+				"cost-center": "12345",
+				"owner":       "networking",
+			},
+			Configured: map[string]interface{}{},
+			Expected: map[string]interface{}{
+				"owner": "networking",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := providerTags.ResourceTags(v.TagsAll, v.Configured)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("Expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestProviderTagsRemovedKeys(t *testing.T) {
+	providerTags := ProviderTags{
+		IgnoreKeys: []string{"ignored"},
+	}
+
+	oldTags := map[string]interface{}{
+		"cost-center": "12345",
+		"environment": "production",
+		"ignored":     "value",
+		"owner":       "platform",
+	}
+	newTags := map[string]interface{}{
+		"Environment": "production",
+	}
+
+	expected := []string{"cost-center", "owner"}
+	actual := providerTags.RemovedKeys(oldTags, newTags)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+}
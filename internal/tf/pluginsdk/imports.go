@@ -6,6 +6,7 @@ package pluginsdk
 import (
 	"context"
 	"log"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -26,7 +27,7 @@ func ImporterValidatingResourceId(validateFunc IDValidationFunc) *schema.Resourc
 // ImporterValidatingResourceIdThen validates the ID provided at import time is valid
 // using the validateFunc then runs the 'thenFunc', allowing the import to be customised.
 func ImporterValidatingResourceIdThen(validateFunc IDValidationFunc, thenFunc ImporterFunc) *schema.ResourceImporter {
-	return &schema.ResourceImporter{
+	importer := &schema.ResourceImporter{
 		StateContext: func(ctx context.Context, d *ResourceData, meta interface{}) ([]*ResourceData, error) {
 			log.Printf("[DEBUG] Importing Resource - parsing %q", d.Id())
 
@@ -44,4 +45,55 @@ func ImporterValidatingResourceIdThen(validateFunc IDValidationFunc, thenFunc Im
 			return thenFunc(ctx, d, meta)
 		},
 	}
+	RecordImporterIDValidationFunc(importer, validateFunc)
+
+	return importer
+}
+
+var (
+	// collectingImporterValidationFuncs ensures only a single caller collects the validateFuncs at once
+	collectingImporterValidationFuncs sync.Mutex
+
+	// importerValidationFuncs holds the validateFunc used by each importer built whilst the validateFuncs are being
+	// collected - this is nil otherwise, so that the importers aren't retained once they're no longer used
+	importerValidationFuncs     map[*schema.ResourceImporter]interface{}
+	importerValidationFuncsLock sync.Mutex
+)
+
+// CollectImporterIDValidationFuncs runs the build function, returning the validateFunc used to validate the ID provided
+// at import time for each importer built whilst it runs - keyed by the importer. The value is the validateFunc passed
+// to the function which built the importer, e.g. an IDValidationFunc for ImporterValidatingResourceIdThen.
+//
+// This is intended for use by the tooling (e.g. to determine the Resource ID format for each Resource), and so
+// importers are only tracked whilst this is running.
+func CollectImporterIDValidationFuncs(build func()) map[*schema.ResourceImporter]interface{} {
+	collectingImporterValidationFuncs.Lock()
+	defer collectingImporterValidationFuncs.Unlock()
+
+	importerValidationFuncsLock.Lock()
+	importerValidationFuncs = make(map[*schema.ResourceImporter]interface{})
+	importerValidationFuncsLock.Unlock()
+
+	defer func() {
+		importerValidationFuncsLock.Lock()
+		importerValidationFuncs = nil
+		importerValidationFuncsLock.Unlock()
+	}()
+
+	build()
+
+	importerValidationFuncsLock.Lock()
+	defer importerValidationFuncsLock.Unlock()
+	return importerValidationFuncs
+}
+
+// RecordImporterIDValidationFunc records the validateFunc used by the importer when called from within
+// CollectImporterIDValidationFuncs, and is a no-op otherwise.
+func RecordImporterIDValidationFunc(importer *schema.ResourceImporter, validateFunc interface{}) {
+	importerValidationFuncsLock.Lock()
+	defer importerValidationFuncsLock.Unlock()
+
+	if importerValidationFuncs != nil {
+		importerValidationFuncs[importer] = validateFunc
+	}
 }
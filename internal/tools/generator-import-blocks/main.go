@@ -0,0 +1,356 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/helpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func main() {
+	inventoryPath := flag.String("inventory", "", "The path to a JSON file containing the ARM Resource inventory")
+	outputPath := flag.String("output", "", "The path to write the generated Terraform Configuration to, defaults to stdout")
+	storageDomainSuffix := flag.String("storage-domain-suffix", "core.windows.net", "The Storage Domain Suffix used to validate Storage Data Plane IDs")
+	showHelp := flag.Bool("help", false, "Display this message")
+
+	flag.Parse()
+
+	if *showHelp || *inventoryPath == "" {
+		flag.Usage()
+		return
+	}
+
+	if err := run(*inventoryPath, *outputPath, *storageDomainSuffix); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(inventoryPath, outputPath, storageDomainSuffix string) error {
+	contents, err := os.ReadFile(inventoryPath)
+	if err != nil {
+		return fmt.Errorf("reading the inventory at %q: %+v", inventoryPath, err)
+	}
+
+	items, err := parseInventory(contents)
+	if err != nil {
+		return fmt.Errorf("parsing the inventory at %q: %+v", inventoryPath, err)
+	}
+
+	resourceTypes, unsupported := registeredResourceTypes(storageDomainSuffix)
+	if len(unsupported) > 0 {
+		log.Printf("[DEBUG] %d Resource Types don't validate their ID at import time and can't be matched: %s", len(unsupported), strings.Join(unsupported, ", "))
+	}
+
+	results := matchResourceTypes(items, resourceTypes)
+
+	output := generateConfiguration(results.matched, resourceTypes)
+	if outputPath == "" {
+		fmt.Print(string(output))
+	} else if err := os.WriteFile(outputPath, output, 0o644); err != nil {
+		return fmt.Errorf("writing the generated configuration to %q: %+v", outputPath, err)
+	}
+
+	fmt.Fprint(os.Stderr, results.report())
+
+	return nil
+}
+
+// inventoryItem is a single ARM Resource within the inventory, the field names match those returned by both
+// `az resource list` and Resource Graph.
+type inventoryItem struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Location      string `json:"location"`
+	ResourceGroup string `json:"resourceGroup"`
+}
+
+// parseInventory parses a JSON array of ARM Resources, either at the top-level (e.g. `az resource list`) or within
+// the `data` (Resource Graph) or `value` (ARM List API) fields.
+func parseInventory(input []byte) ([]inventoryItem, error) {
+	items := make([]inventoryItem, 0)
+	if err := json.Unmarshal(input, &items); err == nil {
+		return filterInventory(items), nil
+	}
+
+	var wrapper struct {
+		Data  *[]inventoryItem `json:"data"`
+		Value *[]inventoryItem `json:"value"`
+	}
+	if err := json.Unmarshal(input, &wrapper); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of resources, or an object containing one within `data` or `value`: %+v", err)
+	}
+
+	if wrapper.Data != nil {
+		return filterInventory(*wrapper.Data), nil
+	}
+	if wrapper.Value != nil {
+		return filterInventory(*wrapper.Value), nil
+	}
+
+	return nil, fmt.Errorf("expected a JSON array of resources, or an object containing one within `data` or `value`")
+}
+
+func filterInventory(input []inventoryItem) []inventoryItem {
+	output := make([]inventoryItem, 0)
+	seen := make(map[string]struct{})
+
+	for _, item := range input {
+		if item.Id == "" {
+			continue
+		}
+		key := strings.ToLower(item.Id)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		output = append(output, item)
+	}
+
+	return output
+}
+
+type resourceType struct {
+	name         string
+	schema       map[string]*pluginsdk.Schema
+	validateFunc pluginsdk.IDValidationFunc
+}
+
+// registeredResourceTypes returns each Resource Type which validates its ID at import time, alongside the names
+// of those Resource Types which don't and so can't be matched.
+func registeredResourceTypes(storageDomainSuffix string) (map[string]resourceType, []string) {
+	output := make(map[string]resourceType)
+	unsupported := make([]string, 0)
+
+	for _, service := range provider.SupportedTypedServices() {
+		for _, r := range service.Resources() {
+			validateFunc := r.IDValidationFunc()
+			output[r.ResourceType()] = resourceType{
+				name:   r.ResourceType(),
+				schema: r.Arguments(),
+				validateFunc: func(id string) error {
+					if _, errs := validateFunc(id, "id"); len(errs) > 0 {
+						return errs[0]
+					}
+					return nil
+				},
+			}
+		}
+	}
+
+	for _, service := range provider.SupportedUntypedServices() {
+		var resources map[string]*pluginsdk.Resource
+		importerValidateFuncs := pluginsdk.CollectImporterIDValidationFuncs(func() {
+			resources = service.SupportedResources()
+		})
+
+		for name, r := range resources {
+			if r.Importer == nil {
+				unsupported = append(unsupported, name)
+				continue
+			}
+
+			switch validateFunc := importerValidateFuncs[r.Importer].(type) {
+			case pluginsdk.IDValidationFunc:
+				output[name] = resourceType{
+					name:         name,
+					schema:       r.Schema,
+					validateFunc: validateFunc,
+				}
+
+			case helpers.StorageIDValidationFunc:
+				output[name] = resourceType{
+					name:   name,
+					schema: r.Schema,
+					validateFunc: func(id string) error {
+						return validateFunc(id, storageDomainSuffix)
+					},
+				}
+
+			default:
+				unsupported = append(unsupported, name)
+			}
+		}
+	}
+
+	sort.Strings(unsupported)
+	return output, unsupported
+}
+
+type matchedItem struct {
+	item          inventoryItem
+	resourceTypes []string
+}
+
+type matchResults struct {
+	matched   []matchedItem
+	ambiguous []matchedItem
+	unmatched []inventoryItem
+}
+
+// matchResourceTypes determines which Resource Types accept each of the IDs within the inventory at import time.
+func matchResourceTypes(items []inventoryItem, resourceTypes map[string]resourceType) matchResults {
+	results := matchResults{
+		matched:   make([]matchedItem, 0),
+		ambiguous: make([]matchedItem, 0),
+		unmatched: make([]inventoryItem, 0),
+	}
+
+	names := make([]string, 0)
+	for name := range resourceTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, item := range items {
+		matches := make([]string, 0)
+		for _, name := range names {
+			if err := resourceTypes[name].validateFunc(item.Id); err == nil {
+				matches = append(matches, name)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			results.unmatched = append(results.unmatched, item)
+		case 1:
+			results.matched = append(results.matched, matchedItem{item: item, resourceTypes: matches})
+		default:
+			results.ambiguous = append(results.ambiguous, matchedItem{item: item, resourceTypes: matches})
+		}
+	}
+
+	return results
+}
+
+func (r matchResults) report() string {
+	lines := []string{
+		fmt.Sprintf("Matched %d IDs to a single Resource Type.", len(r.matched)),
+	}
+
+	if len(r.ambiguous) > 0 {
+		lines = append(lines, "", fmt.Sprintf("%d IDs matched more than one Resource Type and were skipped:", len(r.ambiguous)))
+		for _, v := range r.ambiguous {
+			lines = append(lines, fmt.Sprintf("  %s (%s)", v.item.Id, strings.Join(v.resourceTypes, ", ")))
+		}
+	}
+
+	if len(r.unmatched) > 0 {
+		lines = append(lines, "", fmt.Sprintf("%d IDs didn't match any Resource Type and were skipped:", len(r.unmatched)))
+		for _, v := range r.unmatched {
+			lines = append(lines, fmt.Sprintf("  %s", v.Id))
+		}
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+var invalidLabelCharacters = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// resourceLabel converts the name of a Resource into a valid, unique, Terraform label for the Resource Type.
+func resourceLabel(name string, existing map[string]struct{}) string {
+	label := strings.Trim(invalidLabelCharacters.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" {
+		label = "resource"
+	}
+	if label[0] >= '0' && label[0] <= '9' || label[0] == '-' {
+		label = "_" + label
+	}
+
+	candidate := label
+	for i := 2; ; i++ {
+		if _, ok := existing[candidate]; !ok {
+			break
+		}
+		candidate = fmt.Sprintf("%s_%d", label, i)
+	}
+	existing[candidate] = struct{}{}
+
+	return candidate
+}
+
+// generateConfiguration generates an `import` block for each matched ID, alongside a skeleton `resource` block
+// containing the arguments which can be determined from the inventory and a placeholder for the other Required ones.
+func generateConfiguration(matched []matchedItem, resourceTypes map[string]resourceType) []byte {
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+
+	labels := make(map[string]map[string]struct{})
+	for i, v := range matched {
+		typeName := v.resourceTypes[0]
+		if _, ok := labels[typeName]; !ok {
+			labels[typeName] = make(map[string]struct{})
+		}
+		label := resourceLabel(v.item.Name, labels[typeName])
+
+		if i > 0 {
+			body.AppendNewline()
+		}
+
+		importBlock := body.AppendNewBlock("import", nil).Body()
+		importBlock.SetAttributeValue("id", cty.StringVal(v.item.Id))
+		importBlock.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: typeName},
+			hcl.TraverseAttr{Name: label},
+		})
+		body.AppendNewline()
+
+		resourceBlock := body.AppendNewBlock("resource", []string{typeName, label}).Body()
+		writeSkeletonArguments(resourceBlock, v.item, resourceTypes[typeName].schema)
+	}
+
+	return hclwrite.Format(file.Bytes())
+}
+
+func writeSkeletonArguments(body *hclwrite.Body, item inventoryItem, schema map[string]*pluginsdk.Schema) {
+	known := map[string]string{
+		"name":                item.Name,
+		"location":            item.Location,
+		"resource_group_name": item.ResourceGroup,
+	}
+
+	names := make([]string, 0)
+	for name, v := range schema {
+		if v.Required {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	placeholders := make([]string, 0)
+	for _, name := range names {
+		if value := known[name]; value != "" {
+			body.SetAttributeValue(name, cty.StringVal(value))
+			continue
+		}
+		placeholders = append(placeholders, name)
+	}
+
+	for _, name := range placeholders {
+		kind := "argument"
+		if _, ok := schema[name].Elem.(*pluginsdk.Resource); ok {
+			kind = "block"
+		}
+		body.AppendUnstructuredTokens(hclwrite.Tokens{
+			{
+				Type:  hclsyntax.TokenComment,
+				Bytes: []byte(fmt.Sprintf("# TODO: the `%s` %s is Required\n", name, kind)),
+			},
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestParseInventory(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected []string
+		Error    bool
+	}{
+		{
+			Input:    `[{"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1", "name": "rg1"}]`,
+			Expected: []string{"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg1"},
+		},
+		{
+			Input:    `{"count": 2, "data": [{"id": "/a"}, {"id": "/b"}]}`,
+			Expected: []string{"/a", "/b"},
+		},
+		{
+			Input:    `{"value": [{"id": "/a"}, {"id": "/A"}, {"name": "no-id"}]}`,
+			Expected: []string{"/a"},
+		},
+		{
+			Input: `{"something": []}`,
+			Error: true,
+		},
+		{
+			Input: `not json`,
+			Error: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Input)
+
+		items, err := parseInventory([]byte(tc.Input))
+		if err != nil {
+			if tc.Error {
+				continue
+			}
+			t.Fatalf("parsing: %+v", err)
+		}
+		if tc.Error {
+			t.Fatalf("expected an error but didn't get one")
+		}
+
+		actual := make([]string, 0)
+		for _, item := range items {
+			actual = append(actual, item.Id)
+		}
+		if strings.Join(actual, ",") != strings.Join(tc.Expected, ",") {
+			t.Fatalf("expected %v but got %v", tc.Expected, actual)
+		}
+	}
+}
+
+func TestResourceLabel(t *testing.T) {
+	existing := make(map[string]struct{})
+
+	cases := []struct {
+		Input    string
+		Expected string
+	}{
+		{
+			Input:    "Example-Resources",
+			Expected: "example-resources",
+		},
+		{
+			Input:    "example.vnet/subnet 1",
+			Expected: "example_vnet_subnet_1",
+		},
+		{
+			Input:    "1st-account",
+			Expected: "_1st-account",
+		},
+		{
+			Input:    "",
+			Expected: "resource",
+		},
+		{
+			Input:    "example-resources",
+			Expected: "example-resources_2",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Input)
+
+		if actual := resourceLabel(tc.Input, existing); actual != tc.Expected {
+			t.Fatalf("expected %q but got %q", tc.Expected, actual)
+		}
+	}
+}
+
+func TestMatchResourceTypesAndGenerateConfiguration(t *testing.T) {
+	prefixValidator := func(prefix string) pluginsdk.IDValidationFunc {
+		return func(id string) error {
+			if !strings.HasPrefix(id, prefix) || strings.Contains(strings.TrimPrefix(id, prefix), "/") {
+				return fmt.Errorf("expected an ID starting with %q", prefix)
+			}
+			return nil
+		}
+	}
+
+	resourceTypes := map[string]resourceType{
+		"azurerm_resource_group": {
+			name: "azurerm_resource_group",
+			schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+				"location": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+				"tags": {
+					Type:     pluginsdk.TypeMap,
+					Optional: true,
+				},
+			},
+			validateFunc: prefixValidator("/subscriptions/sub1/resourceGroups/"),
+		},
+		"azurerm_linux_virtual_machine": {
+			name: "azurerm_linux_virtual_machine",
+			schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+			},
+			validateFunc: prefixValidator("/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachines/"),
+		},
+		"azurerm_windows_virtual_machine": {
+			name: "azurerm_windows_virtual_machine",
+			schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+			},
+			validateFunc: prefixValidator("/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachines/"),
+		},
+		"azurerm_virtual_network": {
+			name: "azurerm_virtual_network",
+			schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+				"resource_group_name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+				},
+				"address_space": {
+					Type:     pluginsdk.TypeList,
+					Required: true,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+			validateFunc: prefixValidator("/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/"),
+		},
+	}
+
+	items := []inventoryItem{
+		{
+			Id:       "/subscriptions/sub1/resourceGroups/rg1",
+			Name:     "rg1",
+			Location: "westeurope",
+		},
+		{
+			Id:            "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1",
+			Name:          "vnet1",
+			ResourceGroup: "rg1",
+		},
+		{
+			Id:   "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Compute/virtualMachines/vm1",
+			Name: "vm1",
+		},
+		{
+			Id:   "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Web/sites/site1",
+			Name: "site1",
+		},
+	}
+
+	results := matchResourceTypes(items, resourceTypes)
+	if len(results.matched) != 2 {
+		t.Fatalf("expected 2 matched IDs but got %d", len(results.matched))
+	}
+	if len(results.ambiguous) != 1 || strings.Join(results.ambiguous[0].resourceTypes, ",") != "azurerm_linux_virtual_machine,azurerm_windows_virtual_machine" {
+		t.Fatalf("expected the Virtual Machine to match both the Linux and Windows Virtual Machine but got %+v", results.ambiguous)
+	}
+	if len(results.unmatched) != 1 || results.unmatched[0].Name != "site1" {
+		t.Fatalf("expected the Web App to be unmatched but got %+v", results.unmatched)
+	}
+
+	expected := `import {
+  id = "/subscriptions/sub1/resourceGroups/rg1"
+  to = azurerm_resource_group.rg1
+}
+
+resource "azurerm_resource_group" "rg1" {
+  location = "westeurope"
+  name     = "rg1"
+}
+
+import {
+  id = "/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.Network/virtualNetworks/vnet1"
+  to = azurerm_virtual_network.vnet1
+}
+
+resource "azurerm_virtual_network" "vnet1" {
+  name                = "vnet1"
+  resource_group_name = "rg1"
+  # TODO: the ` + "`address_space`" + ` argument is Required
+}
+`
+	if actual := string(generateConfiguration(results.matched, resourceTypes)); actual != expected {
+		t.Fatalf("expected:\n%s\nbut got:\n%s", expected, actual)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// this generator builds a registry of the Resource ID parsers within each `internal/services/*/parse` package,
+// so that Resource IDs which aren't registered with the recaser (by go-azure-sdk) can be parsed by the Provider Functions
+
+var (
+	parserRegex    = regexp.MustCompile(`(?m)^func (\w+)ID(Insensitively)?\(input string\) \(\*(\w+), error\)`)
+	formatterRegex = regexp.MustCompile(`(?m)^func \(id \*?(\w+)\) ID\(\) string`)
+)
+
+func main() {
+	rootDirectory := flag.String("path", "", "The relative path to the root directory")
+	outputFile := flag.String("output", "", "The relative path to the file which should be generated")
+	showHelp := flag.Bool("help", false, "Display this message")
+
+	flag.Parse()
+
+	if *showHelp || *rootDirectory == "" || *outputFile == "" {
+		flag.Usage()
+		return
+	}
+
+	if err := run(*rootDirectory, *outputFile); err != nil {
+		panic(err)
+	}
+}
+
+type parser struct {
+	servicePackageName string
+	typeName           string
+	functionName       string
+}
+
+func run(rootDirectory, outputFile string) error {
+	directories, err := filepath.Glob(filepath.Join(rootDirectory, "internal", "services", "*", "parse"))
+	if err != nil {
+		return fmt.Errorf("finding the parse packages: %+v", err)
+	}
+
+	parsers := make([]parser, 0)
+	for _, directory := range directories {
+		servicePackageName := filepath.Base(filepath.Dir(directory))
+		found, err := parsersWithinPackage(directory)
+		if err != nil {
+			return fmt.Errorf("finding the parsers within %q: %+v", directory, err)
+		}
+		for _, v := range found {
+			v.servicePackageName = servicePackageName
+			parsers = append(parsers, v)
+		}
+	}
+
+	sort.Slice(parsers, func(i, j int) bool {
+		if parsers[i].servicePackageName != parsers[j].servicePackageName {
+			return parsers[i].servicePackageName < parsers[j].servicePackageName
+		}
+		return parsers[i].typeName < parsers[j].typeName
+	})
+
+	code, err := format.Source([]byte(codeForParsers(parsers)))
+	if err != nil {
+		return fmt.Errorf("formatting the generated code: %+v", err)
+	}
+
+	return os.WriteFile(outputFile, code, 0o644)
+}
+
+// parsersWithinPackage returns the parser for each Resource ID type within the package - using the insensitive
+// parser where available, since this also corrects the casing of the segment keys
+func parsersWithinPackage(directory string) ([]parser, error) {
+	files, err := filepath.Glob(filepath.Join(directory, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	formatters := make(map[string]struct{})
+	functions := make(map[string]parser)
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %+v", file, err)
+		}
+
+		for _, match := range formatterRegex.FindAllStringSubmatch(string(contents), -1) {
+			formatters[match[1]] = struct{}{}
+		}
+		for _, match := range parserRegex.FindAllStringSubmatch(string(contents), -1) {
+			typeName := match[3]
+			if _, exists := functions[typeName]; exists && match[2] == "" {
+				continue
+			}
+			functions[typeName] = parser{
+				typeName:     typeName,
+				functionName: fmt.Sprintf("%sID%s", match[1], match[2]),
+			}
+		}
+	}
+
+	output := make([]parser, 0)
+	for typeName, v := range functions {
+		// only Resource ID types which can be formatted can be used to normalise the Resource ID
+		if _, ok := formatters[typeName]; ok {
+			output = append(output, v)
+		}
+	}
+	return output, nil
+}
+
+func codeForParsers(parsers []parser) string {
+	imports := make([]string, 0)
+	entries := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, v := range parsers {
+		alias := fmt.Sprintf("%sParse", v.servicePackageName)
+		if _, ok := seen[alias]; !ok {
+			seen[alias] = struct{}{}
+			imports = append(imports, fmt.Sprintf("\t%s \"github.com/hashicorp/terraform-provider-azurerm/internal/services/%s/parse\"", alias, v.servicePackageName))
+		}
+
+		entries = append(entries, fmt.Sprintf(`	{
+		name: %[1]q,
+		parse: func(input string) (legacyResourceId, error) {
+			id, err := %[2]s.%[3]s(input)
+			if err != nil {
+				return nil, err
+			}
+			return id, nil
+		},
+	},`, fmt.Sprintf("%s.%s", v.servicePackageName, v.typeName), alias, v.functionName))
+	}
+
+	return fmt.Sprintf(`// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+// NOTE: this file is generated via 'go:generate' - manual changes will be overwritten
+
+import (
+%s
+)
+
+var legacyResourceIdParsers = []legacyResourceIdParser{
+%s
+}
+`, strings.Join(imports, "\n"), strings.Join(entries, "\n"))
+}
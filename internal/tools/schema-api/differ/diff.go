@@ -5,6 +5,7 @@ package differ
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 	schema_rules "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/schema-rules"
@@ -15,104 +16,175 @@ type Differ struct {
 	current *providerjson.ProviderWrapper
 }
 
-func (d *Differ) Diff(fileName string, providerName string) []string {
+// Violation describes a single breaking change detected between the base (released) and current schema
+type Violation struct {
+	// Rule is the name of the Breaking Change Rule which was violated
+	Rule string `json:"rule"`
+
+	// ResourceType is the name of the Resource or Data Source, e.g. `azurerm_resource_group`
+	ResourceType string `json:"resourceType"`
+
+	// IsDataSource specifies whether ResourceType refers to a Data Source rather than a Resource
+	IsDataSource bool `json:"isDataSource"`
+
+	// PropertyPath is the path to the property within the Resource or Data Source, e.g. `identity.type`.
+	// This is empty when the violation applies to the Resource or Data Source as a whole.
+	PropertyPath string `json:"propertyPath,omitempty"`
+
+	// Message is the human-readable description of the violation
+	Message string `json:"message"`
+}
+
+// String returns the Violation in the format previously output by the detect mode
+func (v Violation) String() string {
+	kind := "resource"
+	if v.IsDataSource {
+		kind = "data source"
+	}
+
+	if v.PropertyPath != "" {
+		return fmt.Sprintf("%s %q (%s): %s", kind, v.ResourceType, v.PropertyPath, v.Message)
+	}
+
+	return fmt.Sprintf("%s %q: %s", kind, v.ResourceType, v.Message)
+}
+
+func (d *Differ) Diff(fileName string, providerName string) ([]Violation, error) {
 	if err := d.loadFromProvider(providerjson.LoadData(), providerName); err != nil {
-		return []string{err.Error()}
+		return nil, fmt.Errorf("loading the current schema: %+v", err)
 	}
 
 	if err := d.loadFromFile(fileName); err != nil {
-		return []string{err.Error()}
+		return nil, fmt.Errorf("loading the base schema from %q: %+v", fileName, err)
 	}
 
 	if d.base.ProviderName != d.current.ProviderName {
-		return []string{fmt.Sprintf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)}
+		return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)
 	}
 
-	violations := make([]string, 0)
+	violations := make([]Violation, 0)
+	violations = append(violations, compareResources(d.base.ProviderSchema.ResourcesMap, d.current.ProviderSchema.ResourcesMap, false)...)
+	violations = append(violations, compareResources(d.base.ProviderSchema.DataSourcesMap, d.current.ProviderSchema.DataSourcesMap, true)...)
 
-	for resource, rs := range d.current.ProviderSchema.ResourcesMap {
-		_, ok := d.base.ProviderSchema.ResourcesMap[resource]
-		if !ok {
-			// New resource, no breaking changes to worry about
-			continue
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].IsDataSource != violations[j].IsDataSource {
+			return !violations[i].IsDataSource
 		}
-		for propertyName, propertySchema := range rs.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.ResourcesMap[resource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeResource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+		if violations[i].ResourceType != violations[j].ResourceType {
+			return violations[i].ResourceType < violations[j].ResourceType
 		}
+		if violations[i].PropertyPath != violations[j].PropertyPath {
+			return violations[i].PropertyPath < violations[j].PropertyPath
+		}
+		return violations[i].Rule < violations[j].Rule
+	})
+
+	return violations, nil
+}
+
+func compareResources(base map[string]providerjson.ResourceJSON, current map[string]providerjson.ResourceJSON, isDataSource bool) (violations []Violation) {
+	resourceRules := schema_rules.ResourceBreakingChangeRules
+	propertyRules := schema_rules.BreakingChangeRules
+	if isDataSource {
+		resourceRules = schema_rules.ResourceBreakingChangeRulesDataSource
+		propertyRules = schema_rules.BreakingChangeRulesDataSource
 	}
 
-	for dataSource, ds := range d.current.ProviderSchema.DataSourcesMap {
-		_, ok := d.base.ProviderSchema.DataSourcesMap[dataSource]
-		if !ok {
-			// New data source, no breaking changes to worry about
-			continue
+	for _, resourceType := range sortedKeys(base, current) {
+		var baseResource, currentResource *providerjson.ResourceJSON
+		if v, ok := base[resourceType]; ok {
+			baseResource = &v
 		}
-		for propertyName, propertySchema := range ds.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.DataSourcesMap[dataSource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeDataSource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
+		if v, ok := current[resourceType]; ok {
+			currentResource = &v
+		}
+
+		for _, rule := range resourceRules {
+			if err := rule.Check(baseResource, currentResource, resourceType); err != nil {
+				violations = append(violations, Violation{
+					Rule:         rule.Name(),
+					ResourceType: resourceType,
+					IsDataSource: isDataSource,
+					Message:      *err,
+				})
 			}
 		}
+
+		if baseResource == nil || currentResource == nil {
+			// New resources have no breaking changes to worry about, and removed resources are covered above
+			continue
+		}
+
+		for _, v := range compareNode(baseResource.Schema, currentResource.Schema, "", propertyRules) {
+			v.ResourceType = resourceType
+			v.IsDataSource = isDataSource
+			violations = append(violations, v)
+		}
 	}
 
-	return violations
+	return
 }
 
-func compareNodeResource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeResource(newBase, newCurrent[k], k)...)
+func compareNode(base map[string]providerjson.SchemaJSON, current map[string]providerjson.SchemaJSON, pathPrefix string, rules []schema_rules.BreakingChangeRule) (violations []Violation) {
+	for _, propertyName := range sortedKeys(base, current) {
+		// New properties are checked against an empty base, removed properties against an empty current
+		baseItem := base[propertyName]
+		currentItem := current[propertyName]
+		path := propertyName
+		if pathPrefix != "" {
+			path = fmt.Sprintf("%s.%s", pathPrefix, propertyName)
 		}
-	}
 
-	for _, v := range schema_rules.BreakingChangeRules {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+		if baseBlock := blockSchema(baseItem); baseBlock != nil {
+			if currentBlock := blockSchema(currentItem); currentBlock != nil {
+				violations = append(violations, compareNode(baseBlock, currentBlock, path, rules)...)
+			}
+		}
+
+		for _, rule := range rules {
+			if err := rule.Check(baseItem, currentItem, propertyName); err != nil {
+				violations = append(violations, Violation{
+					Rule:         rule.Name(),
+					PropertyPath: path,
+					Message:      *err,
+				})
+			}
 		}
 	}
 
 	return
 }
 
-func compareNodeDataSource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeDataSource(newBase, newCurrent[k], k)...)
-		}
+// blockSchema returns the nested Schema when the property is a block, the base (released) schema is loaded from
+// JSON and contains a ResourceJSON, whereas the current schema is loaded from the Provider and contains a pointer.
+func blockSchema(input providerjson.SchemaJSON) map[string]providerjson.SchemaJSON {
+	if input.Type != providerjson.SchemaTypeList && input.Type != providerjson.SchemaTypeSet {
+		return nil
 	}
 
-	for _, v := range schema_rules.BreakingChangeRulesDataSource {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+	switch v := input.Elem.(type) {
+	case providerjson.ResourceJSON:
+		return v.Schema
+	case *providerjson.ResourceJSON:
+		if v != nil {
+			return v.Schema
 		}
 	}
 
-	return
+	return nil
 }
 
-func nodeIsBlock(input providerjson.SchemaJSON) bool {
-	if input.Type == providerjson.SchemaTypeList || input.Type == providerjson.SchemaTypeSet {
-		if _, ok := input.Elem.(providerjson.ResourceJSON); ok {
-			return true
+func sortedKeys[T any](first map[string]T, second map[string]T) []string {
+	keys := make([]string, 0, len(first))
+	for k := range first {
+		keys = append(keys, k)
+	}
+	for k := range second {
+		if _, ok := first[k]; !ok {
+			keys = append(keys, k)
 		}
 	}
+	sort.Strings(keys)
 
-	return false
+	return keys
 }
@@ -0,0 +1,160 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	OutputFormatText  = "text"
+	OutputFormatJSON  = "json"
+	OutputFormatSARIF = "sarif"
+)
+
+func PossibleValuesForOutputFormat() []string {
+	return []string{
+		OutputFormatText,
+		OutputFormatJSON,
+		OutputFormatSARIF,
+	}
+}
+
+// WriteViolations writes the violations to the writer in the specified output format
+func WriteViolations(w io.Writer, format string, violations []Violation) error {
+	switch strings.ToLower(format) {
+	case OutputFormatText:
+		for _, v := range violations {
+			if _, err := fmt.Fprintln(w, v.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case OutputFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(violations)
+
+	case OutputFormatSARIF:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(sarifFromViolations(violations))
+	}
+
+	return fmt.Errorf("unsupported output format %q, expected one of %q", format, PossibleValuesForOutputFormat())
+}
+
+// the following types are the subset of the SARIF v2.1.0 format required to report violations
+// see: https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleId    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func sarifFromViolations(violations []Violation) sarifLog {
+	ruleNames := make(map[string]struct{})
+	results := make([]sarifResult, 0, len(violations))
+	for _, v := range violations {
+		ruleNames[v.Rule] = struct{}{}
+
+		name := v.ResourceType
+		if v.IsDataSource {
+			name = fmt.Sprintf("data.%s", name)
+		}
+		kind := "type"
+		if v.PropertyPath != "" {
+			name = fmt.Sprintf("%s.%s", name, v.PropertyPath)
+			kind = "member"
+		}
+
+		results = append(results, sarifResult{
+			RuleId: v.Rule,
+			Level:  "error",
+			Message: sarifMessage{
+				Text: v.String(),
+			},
+			Locations: []sarifLocation{
+				{
+					LogicalLocations: []sarifLogicalLocation{
+						{
+							FullyQualifiedName: name,
+							Kind:               kind,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	rules := make([]sarifRule, 0, len(ruleNames))
+	for name := range ruleNames {
+		rules = append(rules, sarifRule{Id: name})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Id < rules[j].Id
+	})
+
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "schema-api",
+						InformationUri: "https://github.com/hashicorp/terraform-provider-azurerm/tree/main/internal/tools/schema-api",
+						Rules:          rules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+}
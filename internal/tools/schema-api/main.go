@@ -26,6 +26,8 @@ func main() {
 	exportSchema := f.String("export", "", "export the schema to the given path/filename. Intended for use in the release process")
 	detectBreakingChanges := f.String("detect", "", "compare current schema to named dump.")
 	errorOnBreakingChange := f.Bool("error-on-violation", false, "should the detect mode exit with a non-zero error code. Defaults to `false`")
+	outputFormat := f.String("output-format", differ.OutputFormatText, fmt.Sprintf("the format used to output violations in detect mode, one of %q", differ.PossibleValuesForOutputFormat()))
+	outputFile := f.String("output-file", "", "write the violations found in detect mode to the given path/filename rather than stdout")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		fmt.Printf("error parsing args: %+v", err)
@@ -53,14 +55,27 @@ func main() {
 	case pointer.From(detectBreakingChanges) != "":
 		{
 			d := differ.Differ{}
-			if violations := d.Diff(*detectBreakingChanges, *providerName); violations != nil {
-				for _, v := range violations {
-					log.Println(v)
-				}
-				if pointer.From(errorOnBreakingChange) {
-					os.Exit(1)
+			violations, err := d.Diff(*detectBreakingChanges, *providerName)
+			if err != nil {
+				log.Fatalf("error detecting breaking changes: %+v", err)
+			}
+
+			output := os.Stdout
+			if pointer.From(outputFile) != "" {
+				if output, err = os.Create(*outputFile); err != nil {
+					log.Fatalf("error creating output file %q: %+v", *outputFile, err)
 				}
 			}
+			if err := differ.WriteViolations(output, *outputFormat, violations); err != nil {
+				log.Fatalf("error writing violations: %+v", err)
+			}
+			if err := output.Close(); err != nil {
+				log.Fatalf("error closing output: %+v", err)
+			}
+
+			if len(violations) > 0 && pointer.From(errorOnBreakingChange) {
+				os.Exit(1)
+			}
 
 			os.Exit(0)
 		}
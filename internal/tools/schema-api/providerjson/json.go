@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 const (
@@ -33,6 +34,9 @@ type SchemaJSON struct {
 	Elem        interface{} `json:"elem,omitempty"`
 	MaxItems    int         `json:"maxItems,omitempty"`
 	MinItems    int         `json:"minItems,omitempty"`
+
+	// PossibleValues contains the values accepted by the ValidateFunc, where these can be determined
+	PossibleValues []string `json:"possibleValues,omitempty"`
 }
 
 func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
@@ -52,7 +56,14 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 		b.MaxItems = int(max)
 	}
 	if min, ok := m["minItems"].(float64); ok {
-		b.MaxItems = int(min)
+		b.MinItems = int(min)
+	}
+	if values, ok := m["possibleValues"].([]interface{}); ok {
+		for _, v := range values {
+			if value, ok := v.(string); ok {
+				b.PossibleValues = append(b.PossibleValues, value)
+			}
+		}
 	}
 
 	if def, ok := m["default"]; ok && def != nil {
@@ -86,6 +97,9 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 type ResourceJSON struct {
 	Schema   map[string]SchemaJSON `json:"schema"`
 	Timeouts *ResourceTimeoutJSON  `json:"timeouts,omitempty"`
+
+	// IDFormat is an example of the Resource ID expected by the Importer, where this can be determined
+	IDFormat string `json:"idFormat,omitempty"`
 }
 
 type ResourceTimeoutJSON struct {
@@ -96,7 +110,12 @@ type ResourceTimeoutJSON struct {
 }
 
 func LoadData() *ProviderJSON {
-	p := provider.AzureProvider()
+	var p *schema.Provider
+	// the validateFunc used by each Importer is collected whilst building the Provider, so that the Resource ID
+	// format can be determined from these rather than by calling each Importer
+	importerValidateFuncs = pluginsdk.CollectImporterIDValidationFuncs(func() {
+		p = provider.AzureProvider()
+	})
 	return (*ProviderJSON)(p)
 }
 
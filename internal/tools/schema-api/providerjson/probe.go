@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providerjson
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/helpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// probeValue is a value which isn't expected to pass any validation, used to surface the
+// details contained within the validation error messages
+const probeValue = "/schema-api/probe"
+
+// probeStorageDomainSuffix is the Storage Domain Suffix used when probing the validateFunc used by Storage Importers
+const probeStorageDomainSuffix = "core.windows.net"
+
+var (
+	possibleValuesRegex = regexp.MustCompile(`to be one of \[(.*)\], got`)
+	quotedValueRegex    = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+	expectedIdRegex     = regexp.MustCompile(`(?m)^Expected an? .* ID that matched .*:\s*\n\s*\n> (\S+)$`)
+	scopedIdRegex       = regexp.MustCompile(`(?:with|using) the regex "([^"]+)"`)
+)
+
+// importerValidateFuncs contains the validateFunc used by each Importer (keyed by the Importer) which was built
+// whilst loading the Provider in LoadData, as returned by pluginsdk.CollectImporterIDValidationFuncs
+var importerValidateFuncs map[*schema.ResourceImporter]interface{}
+
+// possibleValuesFromValidateFunc returns the values accepted by a `validation.StringInSlice` ValidateFunc by
+// calling it with a probe value and parsing the resulting error message, since the function is otherwise opaque.
+// Validation functions which don't (exclusively) validate against a list of values return nil - however an error
+// is returned when the error message lists the possible values but these can't be parsed, or aren't accepted.
+func possibleValuesFromValidateFunc(input *schema.Schema) ([]string, error) {
+	if input == nil || input.Type != schema.TypeString || input.ValidateFunc == nil {
+		return nil, nil
+	}
+
+	errs, err := probeValidateFunc(input.ValidateFunc, probeValue)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range errs {
+		if !strings.Contains(e.Error(), "to be one of [") {
+			continue
+		}
+
+		match := possibleValuesRegex.FindStringSubmatch(e.Error())
+		if len(match) != 2 {
+			return nil, fmt.Errorf("parsing the possible values from the validation error %q", e.Error())
+		}
+
+		out := make([]string, 0)
+		for _, quoted := range quotedValueRegex.FindAllString(match[1], -1) {
+			value, err := strconv.Unquote(quoted)
+			if err != nil {
+				return nil, fmt.Errorf("parsing the possible value %s from the validation error %q: %+v", quoted, e.Error(), err)
+			}
+			out = append(out, value)
+		}
+
+		// each of the possible values must be accepted by the same list of values, otherwise the error message wasn't
+		// parsed correctly - however the ValidateFunc can reject it for other reasons (e.g. when validating multiple segments)
+		for _, value := range out {
+			errs, err := probeValidateFunc(input.ValidateFunc, value)
+			if err != nil {
+				return nil, err
+			}
+			for _, valueErr := range errs {
+				if valueMatch := possibleValuesRegex.FindStringSubmatch(valueErr.Error()); len(valueMatch) == 2 && valueMatch[1] == match[1] {
+					return nil, fmt.Errorf("the possible value %q parsed from the validation error %q isn't accepted: %+v", value, e.Error(), valueErr)
+				}
+			}
+		}
+
+		return out, nil
+	}
+
+	return nil, nil
+}
+
+// idFormatFromImporter returns an example of the Resource ID expected by the Importer for this Resource, by
+// calling the validateFunc used by the Importer with a probe value and parsing the resulting error message.
+// This is only possible where the Importer validates the Resource ID using a Resource ID Parser, otherwise an
+// empty string is returned - however an error is returned when the error message describes the expected
+// Resource ID but this can't be parsed, or the parsed Resource ID is rejected by the parser. Resource IDs
+// containing a Scope are represented by the regular expression used to match the Scope prefix.
+func idFormatFromImporter(input *schema.Resource) (string, error) {
+	if input == nil || input.Importer == nil {
+		return "", nil
+	}
+
+	var validateFunc pluginsdk.IDValidationFunc
+	switch v := importerValidateFuncs[input.Importer].(type) {
+	case pluginsdk.IDValidationFunc:
+		validateFunc = v
+	case helpers.StorageIDValidationFunc:
+		validateFunc = func(id string) error {
+			return v(id, probeStorageDomainSuffix)
+		}
+	default:
+		// the Importer doesn't validate the Resource ID
+		return "", nil
+	}
+
+	validationErr, err := probeIDValidationFunc(validateFunc, probeValue)
+	if err != nil {
+		return "", err
+	}
+	if validationErr == nil {
+		// the validateFunc doesn't use a Resource ID Parser
+		return "", nil
+	}
+
+	message := validationErr.Error()
+	if match := expectedIdRegex.FindStringSubmatch(message); len(match) == 2 {
+		format := strings.TrimSpace(match[1])
+
+		// the example Resource ID must be parsed by the Resource ID Parser, otherwise the error message wasn't parsed
+		// correctly - however the Importer can reject it for other reasons (e.g. only supporting a given Constant)
+		validationErr, err := probeIDValidationFunc(validateFunc, format)
+		if err != nil {
+			return "", err
+		}
+		if validationErr != nil && describesExpectedResourceId(validationErr.Error()) {
+			return "", fmt.Errorf("the Resource ID %q parsed from the validation error %q can't be parsed: %+v", format, message, validationErr)
+		}
+
+		return format, nil
+	}
+
+	if match := scopedIdRegex.FindStringSubmatch(message); len(match) == 2 {
+		if _, err := regexp.Compile(match[1]); err != nil {
+			return "", fmt.Errorf("the regular expression %q parsed from the validation error %q isn't valid: %+v", match[1], message, err)
+		}
+
+		return match[1], nil
+	}
+
+	if describesExpectedResourceId(message) {
+		return "", fmt.Errorf("parsing the Resource ID format from the validation error %q", message)
+	}
+
+	return "", nil
+}
+
+// describesExpectedResourceId returns whether the validation error is returned by a Resource ID Parser, and so
+// describes the Resource ID which was expected
+func describesExpectedResourceId(message string) bool {
+	return strings.Contains(message, "ID that matched") || strings.Contains(message, "didn't match")
+}
+
+// probeValidateFunc calls the ValidateFunc with the value, returning an error rather than panicking
+func probeValidateFunc(validateFunc schema.SchemaValidateFunc, value string) (errs []error, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("probing the ValidateFunc with %q: %+v", value, r)
+		}
+	}()
+
+	_, errs = validateFunc(value, "probe")
+	return errs, nil
+}
+
+// probeIDValidationFunc calls the validateFunc with the Resource ID, returning an error rather than panicking
+func probeIDValidationFunc(validateFunc pluginsdk.IDValidationFunc, id string) (validationErr error, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("probing the Importer's validateFunc with %q: %+v", id, r)
+		}
+	}()
+
+	return validateFunc(id), nil
+}
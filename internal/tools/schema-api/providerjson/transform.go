@@ -18,10 +18,20 @@ func resourceFromRaw(input *schema.Resource) (*ResourceJSON, error) {
 	translatedSchema := make(map[string]SchemaJSON)
 
 	for k, s := range input.Schema {
-		translatedSchema[k] = schemaFromRaw(s)
+		translated, err := schemaFromRaw(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %+v", k, err)
+		}
+		translatedSchema[k] = *translated
 	}
 	result.Schema = translatedSchema
 
+	idFormat, err := idFormatFromImporter(input)
+	if err != nil {
+		return nil, fmt.Errorf("determining the ID format: %+v", err)
+	}
+	result.IDFormat = idFormat
+
 	if input.Timeouts != nil {
 		timeouts := &ResourceTimeoutJSON{}
 		if t := input.Timeouts; t != nil {
@@ -44,8 +54,18 @@ func resourceFromRaw(input *schema.Resource) (*ResourceJSON, error) {
 	return result, nil
 }
 
-func schemaFromRaw(input *schema.Schema) SchemaJSON {
-	return SchemaJSON{
+func schemaFromRaw(input *schema.Schema) (*SchemaJSON, error) {
+	elem, err := decodeElem(input.Elem)
+	if err != nil {
+		return nil, err
+	}
+
+	possibleValues, err := possibleValuesFromValidateFunc(input)
+	if err != nil {
+		return nil, fmt.Errorf("determining the possible values: %+v", err)
+	}
+
+	return &SchemaJSON{
 		Type:        input.Type.String(),
 		ConfigMode:  decodeConfigMode(input.ConfigMode),
 		Optional:    input.Optional,
@@ -54,10 +74,12 @@ func schemaFromRaw(input *schema.Schema) SchemaJSON {
 		Description: input.Description,
 		Computed:    input.Computed,
 		ForceNew:    input.ForceNew,
-		Elem:        decodeElem(input.Elem),
+		Elem:        elem,
 		MaxItems:    input.MaxItems,
 		MinItems:    input.MinItems,
-	}
+
+		PossibleValues: possibleValues,
+	}, nil
 }
 
 func SchemaFromMap(input map[string]interface{}) SchemaJSON {
@@ -99,7 +121,8 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 	}
 
 	if t, ok := input["elem"]; ok {
-		result.Elem = decodeElem(t)
+		// the values decoded from JSON are never a Schema or Resource, so decoding these can't fail
+		result.Elem, _ = decodeElem(t)
 	}
 
 	if t, ok := input["minItems"]; ok {
@@ -110,6 +133,12 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.MaxItems = int(t.(float64))
 	}
 
+	if t, ok := input["possibleValues"]; ok {
+		for _, v := range t.([]interface{}) {
+			result.PossibleValues = append(result.PossibleValues, v.(string))
+		}
+	}
+
 	return result
 }
 
@@ -135,23 +164,26 @@ func decodeConfigMode(input schema.SchemaConfigMode) (out string) {
 	return
 }
 
-func decodeElem(input interface{}) interface{} {
+func decodeElem(input interface{}) (interface{}, error) {
 	switch t := input.(type) {
 	case bool:
-		return t
+		return t, nil
 	case string:
-		return t
+		return t, nil
 	case int:
-		return t
+		return t, nil
 	case float32, float64:
-		return t
+		return t, nil
 	case *schema.Schema:
-		return schemaFromRaw(t)
+		s, err := schemaFromRaw(t)
+		if err != nil {
+			return nil, err
+		}
+		return *s, nil
 	case *schema.Resource:
-		r, _ := resourceFromRaw(t)
-		return r
+		return resourceFromRaw(t)
 	}
-	return nil
+	return nil, nil
 }
 
 func ProviderFromRaw(input *ProviderJSON) (*ProviderSchemaJSON, error) {
@@ -166,13 +198,17 @@ func ProviderFromRaw(input *ProviderJSON) (*ProviderSchemaJSON, error) {
 	dataSourceSchemas := make(map[string]ResourceJSON)
 
 	for k, v := range input.Schema {
-		providerSchema[k] = schemaFromRaw(v)
+		s, err := schemaFromRaw(v)
+		if err != nil {
+			return nil, fmt.Errorf("provider schema %q: %+v", k, err)
+		}
+		providerSchema[k] = *s
 	}
 
 	for k, v := range input.ResourcesMap {
 		resource, err := resourceFromRaw(v)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("resource %q: %+v", k, err)
 		}
 		resourceSchemas[k] = *resource
 	}
@@ -180,7 +216,7 @@ func ProviderFromRaw(input *ProviderJSON) (*ProviderSchemaJSON, error) {
 	for k, v := range input.DataSourcesMap {
 		dataSource, err := resourceFromRaw(v)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("data source %q: %+v", k, err)
 		}
 		dataSourceSchemas[k] = *dataSource
 	}
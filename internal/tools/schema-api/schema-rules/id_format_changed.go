@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type idFormatChanged struct{}
+
+var _ ResourceBreakingChangeRule = idFormatChanged{}
+
+func (idFormatChanged) Name() string {
+	return "IDFormatChanged"
+}
+
+// Check - Checks that the format of the Resource ID accepted by the Importer has not changed. Since the ID Format is
+// an example Resource ID, only the static segments (and the Resource Provider) are compared, so changes to the example
+// values used for user-specified segments aren't flagged.
+func (idFormatChanged) Check(base *providerjson.ResourceJSON, current *providerjson.ResourceJSON, resourceName string) *string {
+	if base == nil || current == nil || base.IDFormat == "" || current.IDFormat == "" {
+		return nil
+	}
+
+	if normalizeIDFormat(base.IDFormat) != normalizeIDFormat(current.IDFormat) {
+		return pointer.To(fmt.Sprintf("Cannot change the ID format of %q (%q to %q)", resourceName, base.IDFormat, current.IDFormat))
+	}
+
+	return nil
+}
+
+func normalizeIDFormat(input string) string {
+	// Resource IDs containing a Scope are represented by the regular expression matching the Scope prefix
+	if !strings.HasPrefix(input, "/") {
+		return input
+	}
+
+	segments := strings.Split(strings.TrimPrefix(input, "/"), "/")
+	normalized := make([]string, 0, len(segments))
+	for i, segment := range segments {
+		if i%2 == 0 || strings.EqualFold(segments[i-1], "providers") {
+			normalized = append(normalized, strings.ToLower(segment))
+			continue
+		}
+		normalized = append(normalized, "{}")
+	}
+
+	return "/" + strings.Join(normalized, "/")
+}
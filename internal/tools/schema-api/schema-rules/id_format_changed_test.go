@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestIDFormatChanged_Check(t *testing.T) {
+	testData := []struct {
+		base     string
+		current  string
+		violates bool
+	}{
+		{
+			// unknown formats can't be compared
+			base:     "",
+			current:  "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group",
+			violates: false,
+		},
+		{
+			base:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Storage/storageAccounts/storageAccountValue",
+			current:  "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Storage/storageAccounts/storageAccountValue",
+			violates: false,
+		},
+		{
+			// only the example values have changed
+			base:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Storage/storageAccounts/storageAccountValue",
+			current:  "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Storage/storageAccounts/accountName",
+			violates: false,
+		},
+		{
+			// casing of the static segments
+			base:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Storage/storageAccounts/storageAccountValue",
+			current:  "/subscriptions/12345678-1234-9876-4563-123456789012/resourcegroups/example-resource-group/providers/Microsoft.Storage/storageaccounts/storageAccountValue",
+			violates: false,
+		},
+		{
+			base:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Storage/storageAccounts/storageAccountValue",
+			current:  "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Storage/accounts/storageAccountValue",
+			violates: true,
+		},
+		{
+			base:     "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Storage/storageAccounts/storageAccountValue",
+			current:  "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.ClassicStorage/storageAccounts/storageAccountValue",
+			violates: true,
+		},
+		{
+			base:     "^((.){1,})/providers/Microsoft.Chaos/targets/(.){1,}/capabilities/(.){1,}",
+			current:  "^((.){1,})/providers/Microsoft.Chaos/targets/(.){1,}/capabilities/(.){1,}/versions/(.){1,}",
+			violates: true,
+		},
+	}
+
+	data := idFormatChanged{}
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q to %q", v.base, v.current)
+
+		res := data.Check(&providerjson.ResourceJSON{IDFormat: v.base}, &providerjson.ResourceJSON{IDFormat: v.current}, "")
+		if v.violates && res == nil {
+			t.Errorf("expected violation, but didn't get one")
+		}
+		if !v.violates && res != nil {
+			t.Errorf("expected no violation, got %+v", *res)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestMaxItemsReduced_Check(t *testing.T) {
+	testData := []struct {
+		base     int
+		current  int
+		violates bool
+	}{
+		{base: 0, current: 0, violates: false},
+		{base: 1, current: 1, violates: false},
+		{base: 1, current: 2, violates: false},
+		{base: 1, current: 0, violates: false},
+		{base: 2, current: 1, violates: true},
+		{base: 0, current: 1, violates: true},
+	}
+
+	data := maxItemsReduced{}
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing MaxItems %d to %d", v.base, v.current)
+
+		base := providerjson.SchemaJSON{Type: providerjson.SchemaTypeList, MaxItems: v.base}
+		current := providerjson.SchemaJSON{Type: providerjson.SchemaTypeList, MaxItems: v.current}
+		res := data.Check(base, current, "")
+		if v.violates && res == nil {
+			t.Errorf("expected violation, but didn't get one")
+		}
+		if !v.violates && res != nil {
+			t.Errorf("expected no violation, got %+v", *res)
+		}
+	}
+}
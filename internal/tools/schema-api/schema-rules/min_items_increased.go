@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type minItemsIncreased struct{}
+
+var _ BreakingChangeRule = minItemsIncreased{}
+
+func (minItemsIncreased) Name() string {
+	return "MinItemsIncreased"
+}
+
+// Check - Checks that the MinItems of an existing property has not been increased
+func (minItemsIncreased) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type != "" && current.Type != "" && current.MinItems > base.MinItems {
+		return pointer.To(fmt.Sprintf("Cannot increase the MinItems of property %q (%d to %d)", propertyName, base.MinItems, current.MinItems))
+	}
+
+	return nil
+}
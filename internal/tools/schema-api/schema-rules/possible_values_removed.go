@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type possibleValuesRemoved struct{}
+
+var _ BreakingChangeRule = possibleValuesRemoved{}
+
+func (possibleValuesRemoved) Name() string {
+	return "PossibleValuesRemoved"
+}
+
+// Check - Checks that the values accepted by the validation of an existing property haven't been removed. Removing the
+// validation entirely (or replacing it with validation where the possible values can't be determined) isn't flagged.
+func (possibleValuesRemoved) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if len(base.PossibleValues) == 0 || len(current.PossibleValues) == 0 {
+		return nil
+	}
+
+	existing := make(map[string]struct{}, len(current.PossibleValues))
+	for _, v := range current.PossibleValues {
+		existing[v] = struct{}{}
+	}
+
+	removed := make([]string, 0)
+	for _, v := range base.PossibleValues {
+		if _, ok := existing[v]; !ok {
+			removed = append(removed, v)
+		}
+	}
+
+	if len(removed) > 0 {
+		return pointer.To(fmt.Sprintf("Cannot remove possible values from property %q (removed %q)", propertyName, strings.Join(removed, ", ")))
+	}
+
+	return nil
+}
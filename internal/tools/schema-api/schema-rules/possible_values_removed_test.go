@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var possibleValuesRemovedBaseNode = providerjson.SchemaJSON{
+	Type:           providerjson.SchemaTypeString,
+	Optional:       true,
+	PossibleValues: []string{"Basic", "Standard"},
+}
+
+var possibleValuesRemovedPasses = providerjson.SchemaJSON{
+	Type:           providerjson.SchemaTypeString,
+	Optional:       true,
+	PossibleValues: []string{"Basic", "Premium", "Standard"},
+}
+
+var possibleValuesRemovedValidationRemoved = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeString,
+	Optional: true,
+}
+
+var possibleValuesRemovedViolates = providerjson.SchemaJSON{
+	Type:           providerjson.SchemaTypeString,
+	Optional:       true,
+	PossibleValues: []string{"Premium", "Standard"}, // violation
+}
+
+func TestPossibleValuesRemoved_Check(t *testing.T) {
+	data := possibleValuesRemoved{}
+	if res := data.Check(possibleValuesRemovedBaseNode, possibleValuesRemovedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(possibleValuesRemovedBaseNode, possibleValuesRemovedValidationRemoved, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(possibleValuesRemovedBaseNode, possibleValuesRemovedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
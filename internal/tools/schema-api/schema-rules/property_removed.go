@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type propertyRemoved struct{}
+
+var _ BreakingChangeRule = propertyRemoved{}
+
+func (propertyRemoved) Name() string {
+	return "PropertyRemoved"
+}
+
+// Check - Checks that an existing attribute or block has not been removed
+func (propertyRemoved) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type != "" && current.Type == "" {
+		return pointer.To(fmt.Sprintf("Cannot remove property %q", propertyName))
+	}
+
+	return nil
+}
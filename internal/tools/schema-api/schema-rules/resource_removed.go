@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+type resourceRemoved struct{}
+
+var _ ResourceBreakingChangeRule = resourceRemoved{}
+
+func (resourceRemoved) Name() string {
+	return "ResourceRemoved"
+}
+
+// Check - Checks that an existing Resource or Data Source has not been removed
+func (resourceRemoved) Check(base *providerjson.ResourceJSON, current *providerjson.ResourceJSON, resourceName string) *string {
+	if base != nil && current == nil {
+		return pointer.To(fmt.Sprintf("Cannot remove %q", resourceName))
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+func TestResourceRemoved_Check(t *testing.T) {
+	data := resourceRemoved{}
+	resource := &providerjson.ResourceJSON{}
+	if res := data.Check(resource, resource, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(nil, resource, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(resource, nil, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
@@ -6,17 +6,44 @@ package schema_rules
 import "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 
 type BreakingChangeRule interface {
+	// Name returns the name of this rule, used to identify the rule in machine-readable output
+	Name() string
+
 	Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string
 }
 
+// ResourceBreakingChangeRule checks for breaking changes to a Resource/Data Source as a whole, where either
+// `base` or `current` will be nil when the Resource/Data Source has been added or removed.
+type ResourceBreakingChangeRule interface {
+	// Name returns the name of this rule, used to identify the rule in machine-readable output
+	Name() string
+
+	Check(base *providerjson.ResourceJSON, current *providerjson.ResourceJSON, resourceName string) *string
+}
+
 var BreakingChangeRules = []BreakingChangeRule{
 	becomeComputedOnly{},
+	forceNewAdded{},
+	maxItemsReduced{},
+	minItemsIncreased{},
 	newRequiredPropertyExistingResource{},
 	optionalRemoveComputed{},
 	optionalToRequired{},
+	possibleValuesRemoved{},
+	propertyRemoved{},
 	propertyType{},
 }
 
 var BreakingChangeRulesDataSource = []BreakingChangeRule{
+	propertyRemoved{},
 	propertyType{},
 }
+
+var ResourceBreakingChangeRules = []ResourceBreakingChangeRule{
+	idFormatChanged{},
+	resourceRemoved{},
+}
+
+var ResourceBreakingChangeRulesDataSource = []ResourceBreakingChangeRule{
+	resourceRemoved{},
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// resourceDataSpans contains the span for the operation currently being performed against each ResourceData.
+//
+// The untyped Resources build the context used for their API calls from the Provider's StopContext rather than the
+// context for the operation, so the span is looked up by ResourceData when the timeout for the operation is applied.
+var resourceDataSpans sync.Map
+
+// TrackResourceData associates the span within the context with the ResourceData until the returned function is called.
+func TrackResourceData(ctx context.Context, d *pluginsdk.ResourceData) func() {
+	span := trace.SpanFromContext(ctx)
+	if d == nil || !span.SpanContext().IsValid() {
+		return func() {}
+	}
+
+	resourceDataSpans.Store(d, span)
+	return func() {
+		resourceDataSpans.Delete(d)
+	}
+}
+
+// ContextForResourceData returns the context containing the span for the operation currently being performed
+// against the ResourceData, or the context unchanged when there isn't one.
+func ContextForResourceData(ctx context.Context, d *pluginsdk.ResourceData) context.Context {
+	if d == nil || trace.SpanFromContext(ctx).SpanContext().IsValid() {
+		return ctx
+	}
+
+	if v, ok := resourceDataSpans.Load(d); ok {
+		return trace.ContextWithSpan(ctx, v.(trace.Span))
+	}
+
+	return ctx
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestContextForResourceData(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+	})
+
+	d := &pluginsdk.ResourceData{}
+	other := &pluginsdk.ResourceData{}
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "operation")
+	defer span.End()
+
+	untrack := TrackResourceData(ctx, d)
+
+	if actual := trace.SpanFromContext(ContextForResourceData(context.Background(), d)); actual.SpanContext().SpanID() != span.SpanContext().SpanID() {
+		t.Fatalf("expected the context to contain the span for the operation")
+	}
+	if trace.SpanFromContext(ContextForResourceData(context.Background(), other)).SpanContext().IsValid() {
+		t.Fatalf("expected the context for another ResourceData not to contain a span")
+	}
+
+	untrack()
+
+	if trace.SpanFromContext(ContextForResourceData(context.Background(), d)).SpanContext().IsValid() {
+		t.Fatalf("expected the context not to contain a span once the ResourceData is no longer tracked")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hashicorp/terraform-provider-azurerm/version"
+)
+
+const (
+	// EnvExporterEndpoint is the standard OpenTelemetry environment variable containing the OTLP endpoint
+	// (e.g. `http://localhost:4318`) which spans for all signals should be exported to.
+	EnvExporterEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	// EnvExporterTracesEndpoint is the standard OpenTelemetry environment variable containing the OTLP endpoint
+	// (e.g. `http://localhost:4318/v1/traces`) which spans should be exported to, this takes precedence over
+	// EnvExporterEndpoint.
+	EnvExporterTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+
+	instrumentationName = "github.com/hashicorp/terraform-provider-azurerm"
+	serviceName         = "terraform-provider-azurerm"
+)
+
+const (
+	AttributeCorrelationRequestID = attribute.Key("azure.correlation_request_id")
+	AttributeRequestID            = attribute.Key("azure.request_id")
+	AttributeLongRunningPoll      = attribute.Key("azure.long_running_operation.poll")
+	AttributeResourceType         = attribute.Key("terraform.resource_type")
+	AttributeOperation            = attribute.Key("terraform.operation")
+	AttributeResourceID           = attribute.Key("terraform.resource_id")
+)
+
+const (
+	OperationCreate = "Create"
+	OperationRead   = "Read"
+	OperationUpdate = "Update"
+	OperationDelete = "Delete"
+)
+
+// Enabled returns whether an OTLP endpoint has been configured, and as such whether spans will be exported.
+func Enabled() bool {
+	return os.Getenv(EnvExporterEndpoint) != "" || os.Getenv(EnvExporterTracesEndpoint) != ""
+}
+
+// Configure registers a Tracer Provider which exports spans over OTLP/HTTP when an endpoint is configured using
+// the standard OpenTelemetry environment variables, otherwise spans are discarded. The returned function flushes
+// any pending spans and must be called prior to the Provider exiting.
+func Configure(ctx context.Context) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error {
+			return nil
+		}, nil
+	}
+
+	// the exporter determines the endpoint, headers and (in)security from the `OTEL_EXPORTER_OTLP_*` environment variables
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("building the OTLP exporter: %+v", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version.ProviderVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("building the OpenTelemetry resource: %+v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	log.Printf("[DEBUG] Exporting OpenTelemetry spans over OTLP")
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span which is a child of any span within the context, using the global Tracer Provider.
+func StartSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName, trace.WithInstrumentationVersion(version.ProviderVersion)).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// StartOperationSpan starts a span for a Create, Read, Update or Delete operation of a Resource or Data Source.
+func StartOperationSpan(ctx context.Context, resourceType, operation, id string) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		AttributeResourceType.String(resourceType),
+		AttributeOperation.String(operation),
+	}
+	if id != "" {
+		attributes = append(attributes, AttributeResourceID.String(id))
+	}
+
+	return StartSpan(ctx, fmt.Sprintf("%s.%s", resourceType, operation), attributes...)
+}
+
+// EndSpan records the error (if any) against the span, and then ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
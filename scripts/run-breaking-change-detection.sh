@@ -4,11 +4,11 @@
 
 
 function runDetect {
-  go run internal/tools/schema-api/main.go -detect .release/provider-schema.json
+  go run internal/tools/schema-api/main.go -detect .release/provider-schema.json "$@"
 }
 
 function main {
-  runDetect
+  runDetect "$@"
 }
 
-main
\ No newline at end of file
+main "$@"
\ No newline at end of file